@@ -0,0 +1,94 @@
+package bounds
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Box is an axis aligned bounding box
+// An empty Box has its Min above its Max, so that adding the first point makes it contain just that point
+type Box struct {
+	Min tuples.Point
+	Max tuples.Point
+}
+
+// NewBox creates a new Box between two corners
+func NewBox(min, max tuples.Point) Box {
+	return Box{min, max}
+}
+
+// Empty creates a Box that contains nothing
+func Empty() Box {
+	inf := math.Inf(1)
+	return Box{tuples.NewPoint(inf, inf, inf), tuples.NewPoint(-inf, -inf, -inf)}
+}
+
+// String formats the Box as a string
+func (b Box) String() string {
+	return fmt.Sprintf("Box( %v, %v )", b.Min, b.Max)
+}
+
+// IsEmpty checks if the Box contains nothing
+func (b Box) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// Add grows the Box to contain a Point
+func (b Box) Add(p tuples.Point) Box {
+	return Box{
+		tuples.NewPoint(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)),
+		tuples.NewPoint(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)),
+	}
+}
+
+// Union grows the Box to contain another Box
+func (b Box) Union(other Box) Box {
+	if other.IsEmpty() {
+		return b
+	}
+	return b.Add(other.Min).Add(other.Max)
+}
+
+// Corners returns the eight corners of the Box
+func (b Box) Corners() [8]tuples.Point {
+	return [8]tuples.Point{
+		tuples.NewPoint(b.Min.X, b.Min.Y, b.Min.Z),
+		tuples.NewPoint(b.Min.X, b.Min.Y, b.Max.Z),
+		tuples.NewPoint(b.Min.X, b.Max.Y, b.Min.Z),
+		tuples.NewPoint(b.Min.X, b.Max.Y, b.Max.Z),
+		tuples.NewPoint(b.Max.X, b.Min.Y, b.Min.Z),
+		tuples.NewPoint(b.Max.X, b.Min.Y, b.Max.Z),
+		tuples.NewPoint(b.Max.X, b.Max.Y, b.Min.Z),
+		tuples.NewPoint(b.Max.X, b.Max.Y, b.Max.Z),
+	}
+}
+
+// Transform calculates the Box that contains the transformed corners of the Box
+func (b Box) Transform(m matrix.Matrix) Box {
+	if b.IsEmpty() {
+		return b
+	}
+	m4 := m.ToMatrix4()
+	result := Empty()
+	for _, corner := range b.Corners() {
+		result = result.Add(m4.MultiplyPoint(corner))
+	}
+	return result
+}
+
+// Scale measures the magnitude of the coordinates in the Box:
+// the larger of its diagonal and the largest absolute value of any of its coordinates
+// It is 0 for an empty Box
+func (b Box) Scale() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	scale := b.Max.Subtract(b.Min).Magnitude()
+	for _, v := range []float64{b.Min.X, b.Min.Y, b.Min.Z, b.Max.X, b.Max.Y, b.Max.Z} {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	return scale
+}
@@ -0,0 +1,109 @@
+package bounds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: Adding points to an empty box
+// Given b ← empty_box()
+// When p1 ← point(-5, 2, 0) is added to b
+// And p2 ← point(7, 0, -3) is added to b
+// Then b.min = point(-5, 0, -3)
+// And b.max = point(7, 2, 0)
+func Test_Adding_Points_to_an_Empty_Box(t *testing.T) {
+	// Given
+	b := Empty()
+	if !b.IsEmpty() {
+		t.Errorf("%v is not empty", b)
+	}
+	// When
+	b = b.Add(tuples.NewPoint(-5, 2, 0))
+	// And
+	b = b.Add(tuples.NewPoint(7, 0, -3))
+	// Expected
+	wantedMin := tuples.NewPoint(-5, 0, -3)
+	wantedMax := tuples.NewPoint(7, 2, 0)
+	// Then
+	if !b.Min.Equals(wantedMin) || !b.Max.Equals(wantedMax) {
+		t.Errorf("box = %v, expected Box( %v, %v )", b, wantedMin, wantedMax)
+	}
+}
+
+// Scenario: The union of two boxes
+// Given b1 ← box(point(-5, -2, 0), point(7, 4, 4))
+// And b2 ← box(point(8, -7, -2), point(14, 2, 8))
+// When b ← union(b1, b2)
+// Then b = box(point(-5, -7, -2), point(14, 4, 8))
+// And union(b, empty_box()) = b
+func Test_the_Union_of_Two_Boxes(t *testing.T) {
+	// Given
+	b1 := NewBox(tuples.NewPoint(-5, -2, 0), tuples.NewPoint(7, 4, 4))
+	// And
+	b2 := NewBox(tuples.NewPoint(8, -7, -2), tuples.NewPoint(14, 2, 8))
+	// When
+	b := b1.Union(b2)
+	// Expected
+	wanted := NewBox(tuples.NewPoint(-5, -7, -2), tuples.NewPoint(14, 4, 8))
+	// Then
+	if b != wanted {
+		t.Errorf("Union( %v, %v ) = %v, expected %v", b1, b2, b, wanted)
+	}
+	// And
+	if u := b.Union(Empty()); u != b {
+		t.Errorf("Union( %v, empty ) = %v, expected %v", b, u, b)
+	}
+}
+
+// Scenario: Transforming a box
+// Given b ← box(point(-1, -1, -1), point(1, 1, 1))
+// And M ← rotation_y(π / 4) * scaling(1, 2, 1)
+// When b2 ← transform(b, M)
+// Then b2 = box(point(-√2, -2, -√2), point(√2, 2, √2))
+func Test_Transforming_a_Box(t *testing.T) {
+	// Given
+	b := NewBox(tuples.NewPoint(-1, -1, -1), tuples.NewPoint(1, 1, 1))
+	// And
+	c, s := math.Cos(math.Pi/4), math.Sin(math.Pi/4)
+	m := matrix.NewMatrix([][]float64{
+		{c, 0, s, 0},
+		{0, 2, 0, 0},
+		{-s, 0, c, 0},
+		{0, 0, 0, 1},
+	})
+	// When
+	b2 := b.Transform(*m)
+	// Expected
+	wantedMin := tuples.NewPoint(-math.Sqrt2, -2, -math.Sqrt2)
+	wantedMax := tuples.NewPoint(math.Sqrt2, 2, math.Sqrt2)
+	// Then
+	if !b2.Min.Equals(wantedMin) || !b2.Max.Equals(wantedMax) {
+		t.Errorf("Transform( %v, %v ) = %v, expected Box( %v, %v )", b, m, b2, wantedMin, wantedMax)
+	}
+}
+
+// Scenario: The scale of a box
+// Given the boxes b:
+// | box(point(-1, -1, -1), point(1, 1, 1))      | √12 |
+// | box(point(1e6, 0, 0), point(1e6 + 1, 1, 1)) | 1e6 + 1 |
+// | empty_box()                                 | 0 |
+// Then scale(b) is the larger of the diagonal and the largest coordinate
+func Test_the_Scale_of_a_Box(t *testing.T) {
+	cases := []struct {
+		box    Box
+		wanted float64
+	}{
+		{NewBox(tuples.NewPoint(-1, -1, -1), tuples.NewPoint(1, 1, 1)), math.Sqrt(12)},
+		{NewBox(tuples.NewPoint(1e6, 0, 0), tuples.NewPoint(1e6+1, 1, 1)), 1e6 + 1},
+		{Empty(), 0},
+	}
+	for _, c := range cases {
+		// Then
+		if s := c.box.Scale(); math.Abs(s-c.wanted) > tuples.Epsilon {
+			t.Errorf("Scale( %v ) = %g, expected %g", c.box, s, c.wanted)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/sampling"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Camera maps the three-dimensional scene onto a two-dimensional canvas
+// Its view transformation is set through SetTransform or LookAt, which cache its inverse
+// Every pixel is sampled SamplesPerPixel times at positions chosen by the Sampler,
+// and the samples are combined into pixels by the Filter.
+// When MaxSamplesPerPixel exceeds SamplesPerPixel, sampling is adaptive: noisy pixels get
+// further batches of SamplesPerPixel samples until the standard error of their luminance
+// drops to the NoiseThreshold or MaxSamplesPerPixel is reached.
+// The shutter is open from ShutterOpen until ShutterClose; the samples are spread
+// over that interval, so moving objects are blurred
+// A thin lens with ApertureRadius > 0 blurs everything that is not at FocalDistance from the camera;
+// its aperture is circular, or a regular polygon when ApertureBlades is 3 or more
+// The Projection determines how the scene is mapped onto the canvas; a new Camera starts with a perspective projection
+// The Integrator calculates the color of every sample, with a random generator seeded by Seed and the pixel;
+// when it is not set, samples are shaded by the ColorAt of the world
+type Camera struct {
+	HSize              int
+	VSize              int
+	Projection         Projection
+	ShutterOpen        float64
+	ShutterClose       float64
+	SamplesPerPixel    int
+	MaxSamplesPerPixel int
+	NoiseThreshold     float64
+	ApertureRadius     float64
+	FocalDistance      float64
+	ApertureBlades     int
+	Sampler            sampling.Sampler
+	Filter             sampling.Filter
+	Seed               int64
+	Integrator         world.Integrator
+	transform          matrix.Matrix
+	inverse            matrix.Matrix4
+}
+
+// NewCamera creates a new Camera with a canvas size and field of view in radians
+func NewCamera(hSize, vSize int, fieldOfView float64) *Camera {
+	c := Camera{
+		HSize:           hSize,
+		VSize:           vSize,
+		Projection:      NewPerspectiveProjection(fieldOfView),
+		SamplesPerPixel: 1,
+		FocalDistance:   1,
+		Sampler:         sampling.NewRegularSampler(),
+		Filter:          sampling.NewBoxFilter(0.5),
+		transform:       *matrix.Identity(4),
+		inverse:         matrix.Identity4(),
+	}
+	return &c
+}
+
+// String formats the Camera as a string
+func (c Camera) String() string {
+	return fmt.Sprintf("Camera( %d, %d, %v, %v )", c.HSize, c.VSize, c.Projection, c.transform)
+}
+
+// PixelSize returns the width of a pixel on the canvas, at distance 1 in front of the camera for a perspective
+// projection and in world units for an orthographic projection
+// Other projections do not map pixels onto a plane, and have PixelSize 0
+func (c Camera) PixelSize() float64 {
+	halfWidth, _ := halfExtent(float64(c.HSize) / float64(c.VSize))
+	switch p := c.Projection.(type) {
+	case PerspectiveProjection:
+		return 2 * math.Tan(p.FieldOfView/2) * halfWidth / float64(c.HSize)
+	case OrthographicProjection:
+		return p.Width * halfWidth / float64(c.HSize)
+	}
+	return 0
+}
+
+// SetProjection sets how the scene is mapped onto the canvas
+func (c *Camera) SetProjection(projection Projection) {
+	c.Projection = projection
+}
+
+// SetTransform sets the view transformation of the camera to a copy of the transformation, and caches its inverse
+// A transformation that is not 4x4 or cannot be inverted is rejected, leaving the camera unchanged
+func (c *Camera) SetTransform(transform matrix.Matrix) error {
+	m4, err := transform.CheckedToMatrix4()
+	if err != nil {
+		return fmt.Errorf("camera transform %v: %w", transform, err)
+	}
+	inverse, err := m4.CheckedInverse()
+	if err != nil {
+		return fmt.Errorf("camera transform %v: %w", transform, err)
+	}
+	c.transform = *transform.Copy()
+	c.inverse = inverse
+	return nil
+}
+
+// Transform returns a copy of the view transformation of the camera
+func (c Camera) Transform() matrix.Matrix {
+	return *c.transform.Copy()
+}
+
+// LookAt points the camera from a point to another point, using the view transformation,
+// and focuses on the point it looks at
+// It fails when the up vector is parallel to the viewing direction
+func (c *Camera) LookAt(fromPoint, toPoint tuples.Point, upVector tuples.Vector) error {
+	if err := c.SetTransform(transformations.NewViewTransform(fromPoint, toPoint, upVector)); err != nil {
+		return err
+	}
+	c.FocalDistance = toPoint.Subtract(fromPoint).Magnitude()
+	return nil
+}
+
+// SetDepthOfField sets the radius of the lens aperture and the distance at which the image is sharp
+func (c *Camera) SetDepthOfField(apertureRadius, focalDistance float64) {
+	c.ApertureRadius = apertureRadius
+	c.FocalDistance = focalDistance
+}
+
+// SetSampling sets the number of samples per pixel, how they are placed and how they are combined
+func (c *Camera) SetSampling(samplesPerPixel int, sampler sampling.Sampler, filter sampling.Filter) {
+	c.SamplesPerPixel = samplesPerPixel
+	c.Sampler = sampler
+	c.Filter = filter
+}
+
+// SetAdaptiveSampling makes noisy pixels take up to maxSamplesPerPixel samples,
+// until the standard error of their luminance is at most threshold
+func (c *Camera) SetAdaptiveSampling(maxSamplesPerPixel int, threshold float64) {
+	c.MaxSamplesPerPixel = maxSamplesPerPixel
+	c.NoiseThreshold = threshold
+}
+
+// SetIntegrator sets how the color of every sample is calculated, such as world.NewPathTracer
+func (c *Camera) SetIntegrator(integrator world.Integrator) {
+	c.Integrator = integrator
+}
+
+// integrator returns the Integrator of the camera, or a WhittedIntegrator when it is not set
+func (c Camera) integrator() world.Integrator {
+	if c.Integrator == nil {
+		return world.NewWhittedIntegrator()
+	}
+	return c.Integrator
+}
+
+// SetShutter sets the interval during which the shutter is open
+func (c *Camera) SetShutter(open, close float64) {
+	c.ShutterOpen = open
+	c.ShutterClose = close
+}
+
+// RayForPixel creates a ray from the camera through the center of the specified pixel,
+// cast at the moment the shutter opens
+// The result is nil when the pixel lies outside of the image of the projection
+func (c Camera) RayForPixel(px, py int) *rays.Ray {
+	return c.RayAt(float64(px)+0.5, float64(py)+0.5, c.ShutterOpen)
+}
+
+// RayAt creates a ray from the camera through a position on the canvas at the specified time
+// The position is measured in pixels from the top left corner of the canvas
+// The result is nil when the position lies outside of the image of the projection
+func (c Camera) RayAt(x, y float64, time float64) *rays.Ray {
+	return c.RayThroughLens(x, y, sampling.Sample{X: 0.5, Y: 0.5}, time)
+}
+
+// RayThroughLens creates a ray from a point on the lens through a position on the canvas at the specified time
+// The lens sample is mapped onto the aperture, its center (0.5, 0.5) is the center of the lens
+// The result is nil when the position lies outside of the image of the projection
+func (c Camera) RayThroughLens(x, y float64, lens sampling.Sample, time float64) *rays.Ray {
+	aspect := float64(c.HSize) / float64(c.VSize)
+	origin, direction, ok := c.Projection.CameraRay(x/float64(c.HSize), y/float64(c.VSize), aspect)
+	if !ok {
+		return nil
+	}
+
+	if c.ApertureRadius > 0 && direction.Z < 0 {
+		// the point on the focal plane that is sharp for every point on the lens
+		focal := origin.Add(direction.Multiply(c.FocalDistance / -direction.Z))
+		lensX, lensY := c.lensPoint(lens)
+		origin = origin.Add(tuples.NewVector(lensX, lensY, 0))
+		direction = focal.Subtract(origin).Normalize()
+	}
+
+	worldOrigin := c.inverse.MultiplyPoint(origin)
+	worldDirection := c.inverse.MultiplyVector(direction).Normalize()
+
+	return rays.NewRayAtTime(worldOrigin, worldDirection, time)
+}
+
+// lensPoint maps a lens sample onto the aperture
+func (c Camera) lensPoint(lens sampling.Sample) (float64, float64) {
+	if c.ApertureRadius <= 0 {
+		return 0, 0
+	}
+	var x, y float64
+	if c.ApertureBlades >= 3 {
+		x, y = sampling.RegularPolygon(lens, c.ApertureBlades, math.Pi/2)
+	} else {
+		x, y = sampling.ConcentricDisk(lens)
+	}
+	return x * c.ApertureRadius, y * c.ApertureRadius
+}
+
+// ShutterTimes distributes n moments over the shutter interval
+// The interval is divided in n equal strata and every time is placed randomly within its stratum
+func (c Camera) ShutterTimes(n int, rng *rand.Rand) []float64 {
+	times := make([]float64, n)
+	duration := c.ShutterClose - c.ShutterOpen
+	for i := 0; i < n; i++ {
+		times[i] = c.ShutterOpen + duration*(float64(i)+rng.Float64())/float64(n)
+	}
+	return times
+}
+
+// Render renders an image of the world as seen by the camera
+func (c Camera) Render(w world.World) *canvas.Canvas {
+	image, _ := c.RenderWithSampleCounts(w)
+	return image
+}
+
+// RenderWithSampleCounts renders an image of the world as seen by the camera,
+// together with a grayscale image of the number of samples taken per pixel,
+// where white is the maximum number of samples
+func (c Camera) RenderWithSampleCounts(w world.World) (*canvas.Canvas, *canvas.Canvas) {
+	film := sampling.NewFilm(c.HSize, c.VSize, c.Filter)
+	counts := canvas.NewCanvas(c.HSize, c.VSize)
+	maxSamples := c.SamplesPerPixel
+	if c.MaxSamplesPerPixel > maxSamples {
+		maxSamples = c.MaxSamplesPerPixel
+	}
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			rng := rand.New(rand.NewSource(pixelSeed(c.Seed, y*c.HSize+x)))
+			stats := pixelStatistics{}
+			c.samplePixel(w, film, x, y, c.Sampler, rng, &stats)
+			for stats.count+c.SamplesPerPixel <= maxSamples && stats.standardError() > c.NoiseThreshold {
+				c.samplePixel(w, film, x, y, c.refinementSampler(), rng, &stats)
+			}
+			gray := float64(stats.count) / float64(maxSamples)
+			counts.Set(x, y, colors.NewColor(gray, gray, gray))
+		}
+	}
+	return film.ToCanvas(), counts
+}
+
+// pixelSeed mixes the seed of the camera with the index of a pixel into the seed of the random generator of the pixel
+// Adding them would give the pixels of neighbouring seeds the same random numbers, shifted by one pixel
+func pixelSeed(seed int64, index int) int64 {
+	return int64(mix64(mix64(uint64(seed)) ^ uint64(index)))
+}
+
+// mix64 scrambles the bits of a value with the finalizer of SplitMix64, mapping different values to different results
+func mix64(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// refinementSampler returns the Sampler for the further batches of adaptive sampling
+// A RegularSampler would place every batch at the same positions, so those batches are jittered instead
+func (c Camera) refinementSampler() sampling.Sampler {
+	if _, ok := c.Sampler.(sampling.RegularSampler); ok {
+		return sampling.NewJitteredSampler()
+	}
+	return c.Sampler
+}
+
+// samplePixel adds a batch of SamplesPerPixel samples of a pixel, placed by the sampler, to the film
+func (c Camera) samplePixel(
+	w world.World,
+	film *sampling.Film,
+	x, y int,
+	sampler sampling.Sampler,
+	rng *rand.Rand,
+	stats *pixelStatistics,
+) {
+	samples := sampler.Samples(c.SamplesPerPixel, rng)
+	integrator := c.integrator()
+	times := c.ShutterTimes(len(samples), rng)
+	lenses := sampler.Samples(len(samples), rng)
+	// decouple the time and lens position of a sample from its position in the pixel
+	rng.Shuffle(len(times), func(i, j int) { times[i], times[j] = times[j], times[i] })
+	rng.Shuffle(len(lenses), func(i, j int) { lenses[i], lenses[j] = lenses[j], lenses[i] })
+	for i, sample := range samples {
+		filmX := float64(x) + sample.X
+		filmY := float64(y) + sample.Y
+		color := colors.Black()
+		if ray := c.RayThroughLens(filmX, filmY, lenses[i], times[i]); ray != nil {
+			color = integrator.Radiance(w, *ray, rng)
+		}
+		film.AddSample(filmX, filmY, color)
+		stats.add(color.Luminance())
+	}
+}
+
+// pixelStatistics keeps track of the mean and variance of the luminance of the samples of a pixel
+type pixelStatistics struct {
+	count int
+	sum   float64
+	sumSq float64
+}
+
+func (s *pixelStatistics) add(luminance float64) {
+	s.count++
+	s.sum += luminance
+	s.sumSq += luminance * luminance
+}
+
+// standardError estimates how far the mean luminance is off from the true luminance of the pixel
+// A single sample tells nothing about the noise, so its error is infinite
+func (s pixelStatistics) standardError() float64 {
+	if s.count < 2 {
+		return math.Inf(1)
+	}
+	n := float64(s.count)
+	mean := s.sum / n
+	variance := math.Max(0, (s.sumSq-n*mean*mean)/(n-1))
+	return math.Sqrt(variance / n)
+}
@@ -0,0 +1,61 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// benchmarkCamera returns the default world and a 100 x 50 camera looking at it
+func benchmarkCamera() (world.World, *Camera) {
+	w := world.DefaultWorld()
+	c := NewCamera(100, 50, math.Pi/3)
+	c.LookAt(tuples.NewPoint(0, 1.5, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	return w, c
+}
+
+// BenchmarkRender renders the default world on a 100 x 50 canvas
+func BenchmarkRender(b *testing.B) {
+	w, c := benchmarkCamera()
+	for i := 0; i < b.N; i++ {
+		c.Render(w)
+	}
+}
+
+// BenchmarkPrimaryRays casts a ray through every pixel and transforms it into the space of every object,
+// with the inverses cached by the camera and the spheres
+func BenchmarkPrimaryRays(b *testing.B) {
+	w, c := benchmarkCamera()
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < c.VSize; y++ {
+			for x := 0; x < c.HSize; x++ {
+				r := c.RayForPixel(x, y)
+				for _, o := range w.Objects {
+					r.Transform4(o.InverseAt(r.Time))
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkPrimaryRaysUncachedInverse does the same work as BenchmarkPrimaryRays, but inverts the transforms
+// of the camera and the spheres with Matrix.Inverse for every ray, as was done before the inverses were cached
+func BenchmarkPrimaryRaysUncachedInverse(b *testing.B) {
+	w, c := benchmarkCamera()
+	aspect := float64(c.HSize) / float64(c.VSize)
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < c.VSize; y++ {
+			for x := 0; x < c.HSize; x++ {
+				origin, direction, _ := c.Projection.CameraRay((float64(x)+0.5)/float64(c.HSize), (float64(y)+0.5)/float64(c.VSize), aspect)
+				inverse := c.Transform().Inverse()
+				r := rays.NewRayAtTime(*inverse.MultiplyPoint(origin), inverse.MultiplyVector(direction).Normalize(), c.ShutterOpen)
+				for _, o := range w.Objects {
+					r.Transform(*o.TransformAt(r.Time).Inverse())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,587 @@
+package camera
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/sampling"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Scenario: Constructing a camera
+// Given hsize ← 160
+// And vsize ← 120
+// And field_of_view ← π/2
+// When c ← camera(hsize, vsize, field_of_view)
+// Then c.hsize = 160
+// And c.vsize = 120
+// And c.field_of_view = π/2
+// And c.transform = identity_matrix
+func Test_Constructing_a_Camera(t *testing.T) {
+	// Given
+	hSize := 160
+	// And
+	vSize := 120
+	// And
+	fieldOfView := math.Pi / 2
+	// When
+	c := NewCamera(hSize, vSize, fieldOfView)
+	// Then
+	if c.HSize != hSize {
+		t.Errorf("%v has HSize %d, expected %d", c, c.HSize, hSize)
+	}
+	// And
+	if c.VSize != vSize {
+		t.Errorf("%v has VSize %d, expected %d", c, c.VSize, vSize)
+	}
+	// And
+	if c.Projection != NewPerspectiveProjection(fieldOfView) {
+		t.Errorf("%v has Projection %v, expected %v", c, c.Projection, NewPerspectiveProjection(fieldOfView))
+	}
+	// And
+	if !matrix.Identity(4).Equals(c.Transform()) {
+		t.Errorf("%v has Transform %v, expected %v", c, c.Transform(), matrix.Identity(4))
+	}
+}
+
+// Scenario: The pixel size for a horizontal canvas
+// Given c ← camera(200, 125, π/2)
+// Then c.pixel_size = 0.01
+func Test_the_Pixel_Size_for_a_Horizontal_Canvas(t *testing.T) {
+	// Given
+	c := NewCamera(200, 125, math.Pi/2)
+	// Then
+	if math.Abs(c.PixelSize()-0.01) > tuples.Epsilon {
+		t.Errorf("%v has PixelSize %9.6f, expected %9.6f", c, c.PixelSize(), 0.01)
+	}
+}
+
+// Scenario: The pixel size for a vertical canvas
+// Given c ← camera(125, 200, π/2)
+// Then c.pixel_size = 0.01
+func Test_the_Pixel_Size_for_a_Vertical_Canvas(t *testing.T) {
+	// Given
+	c := NewCamera(125, 200, math.Pi/2)
+	// Then
+	if math.Abs(c.PixelSize()-0.01) > tuples.Epsilon {
+		t.Errorf("%v has PixelSize %9.6f, expected %9.6f", c, c.PixelSize(), 0.01)
+	}
+}
+
+// Scenario: Constructing a ray through the center of the canvas
+// Given c ← camera(201, 101, π/2)
+// When r ← ray_for_pixel(c, 100, 50)
+// Then r.origin = point(0, 0, 0)
+// And r.direction = vector(0, 0, -1)
+func Test_Constructing_a_Ray_Through_the_Center_of_the_Canvas(t *testing.T) {
+	// Given
+	c := NewCamera(201, 101, math.Pi/2)
+	// When
+	r := c.RayForPixel(100, 50)
+	// Expected
+	wantedOrigin := tuples.NewPoint(0, 0, 0)
+	wantedDirection := tuples.NewVector(0, 0, -1)
+	// Then
+	if !wantedOrigin.Equals(r.Origin) {
+		t.Errorf("RayForPixel(100, 50) has origin %v, expected %v", r.Origin, wantedOrigin)
+	}
+	// And
+	if !wantedDirection.Equals(r.Direction) {
+		t.Errorf("RayForPixel(100, 50) has direction %v, expected %v", r.Direction, wantedDirection)
+	}
+}
+
+// Scenario: Constructing a ray through a corner of the canvas
+// Given c ← camera(201, 101, π/2)
+// When r ← ray_for_pixel(c, 0, 0)
+// Then r.origin = point(0, 0, 0)
+// And r.direction = vector(0.66519, 0.33259, -0.66851)
+func Test_Constructing_a_Ray_Through_a_Corner_of_the_Canvas(t *testing.T) {
+	// Given
+	c := NewCamera(201, 101, math.Pi/2)
+	// When
+	r := c.RayForPixel(0, 0)
+	// Expected
+	wantedOrigin := tuples.NewPoint(0, 0, 0)
+	wantedDirection := tuples.NewVector(0.66519, 0.33259, -0.66851)
+	// Then
+	if !wantedOrigin.Equals(r.Origin) {
+		t.Errorf("RayForPixel(0, 0) has origin %v, expected %v", r.Origin, wantedOrigin)
+	}
+	// And
+	if !wantedDirection.Equals(r.Direction) {
+		t.Errorf("RayForPixel(0, 0) has direction %v, expected %v", r.Direction, wantedDirection)
+	}
+}
+
+// Scenario: Constructing a ray when the camera is transformed
+// Given c ← camera(201, 101, π/2)
+// When c.transform ← rotation_y(π/4) * translation(0, -2, 5)
+// And r ← ray_for_pixel(c, 100, 50)
+// Then r.origin = point(0, 2, -5)
+// And r.direction = vector(√2/2, 0, -√2/2)
+func Test_Constructing_a_Ray_When_the_Camera_is_Transformed(t *testing.T) {
+	// Given
+	c := NewCamera(201, 101, math.Pi/2)
+	// When
+	c.SetTransform(*transformations.RotationY(math.Pi / 4).Multiply(*transformations.Translation(0, -2, 5)))
+	// And
+	r := c.RayForPixel(100, 50)
+	// Expected
+	wantedOrigin := tuples.NewPoint(0, 2, -5)
+	wantedDirection := tuples.NewVector(math.Sqrt2/2, 0, -math.Sqrt2/2)
+	// Then
+	if !wantedOrigin.Equals(r.Origin) {
+		t.Errorf("RayForPixel(100, 50) has origin %v, expected %v", r.Origin, wantedOrigin)
+	}
+	// And
+	if !wantedDirection.Equals(r.Direction) {
+		t.Errorf("RayForPixel(100, 50) has direction %v, expected %v", r.Direction, wantedDirection)
+	}
+}
+
+// Scenario: A camera keeps its own copy of its transformation
+// Given c ← camera(201, 101, π/2)
+// And m ← translation(0, -2, 5)
+// And set_transform(c, m)
+// When m is changed to translation(0, 0, 5)
+// And transform(c) is changed to translation(0, 0, 9)
+// Then c.transform = translation(0, -2, 5)
+// And ray_for_pixel(c, 100, 50).origin = point(0, 2, -5)
+func Test_a_Camera_Keeps_its_Own_Copy_of_its_Transformation(t *testing.T) {
+	// Given
+	c := NewCamera(201, 101, math.Pi/2)
+	// And
+	m := transformations.Translation(0, -2, 5)
+	c.SetTransform(*m)
+	// When
+	m.Set(1, 3, 0)
+	// And
+	c.Transform().Set(2, 3, 9)
+	// Expected
+	wanted := transformations.Translation(0, -2, 5)
+	wantedOrigin := tuples.NewPoint(0, 2, -5)
+	// Then
+	if trans := c.Transform(); !wanted.Equals(trans) {
+		t.Errorf("%v has Transform %v, expected %v", c, trans, wanted)
+	}
+	// And
+	if r := c.RayForPixel(100, 50); !wantedOrigin.Equals(r.Origin) {
+		t.Errorf("RayForPixel(100, 50) has origin %v, expected %v", r.Origin, wantedOrigin)
+	}
+}
+
+// Scenario: Rendering a world with a camera
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2)
+// And from ← point(0, 0, -5)
+// And to ← point(0, 0, 0)
+// And up ← vector(0, 1, 0)
+// And c.transform ← view_transform(from, to, up)
+// When image ← render(c, w)
+// Then pixel_at(image, 5, 5) = color(0.38066, 0.47583, 0.2855)
+func Test_Rendering_a_World_with_a_Camera(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	// And
+	from := tuples.NewPoint(0, 0, -5)
+	// And
+	to := tuples.NewPoint(0, 0, 0)
+	// And
+	up := tuples.NewVector(0, 1, 0)
+	// And
+	c.SetTransform(transformations.NewViewTransform(from, to, up))
+	// When
+	image := c.Render(w)
+	// Expected
+	wanted := colors.NewColor(0.38066, 0.47583, 0.2855)
+	// Then
+	if !wanted.Equals(image.Get(5, 5)) {
+		t.Errorf("Pixel (5, 5) = %v, expected %v", image.Get(5, 5), wanted)
+	}
+}
+
+// Scenario: Shutter times are spread over the shutter interval
+// Given c ← camera(11, 11, π/2)
+// And c.shutter ← [2, 3]
+// When times ← shutter_times(c, 4)
+// Then times[i] is in [2 + i/4, 2 + (i+1)/4)
+func Test_Shutter_Times_are_Spread_over_the_Shutter_Interval(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	// And
+	c.SetShutter(2, 3)
+	// When
+	times := c.ShutterTimes(4, rand.New(rand.NewSource(42)))
+	// Then
+	for i, time := range times {
+		low := 2 + float64(i)/4
+		high := 2 + float64(i+1)/4
+		if time < low || time >= high {
+			t.Errorf("times[%d] = %9.6f, expected in [%9.6f, %9.6f)", i, time, low, high)
+		}
+	}
+}
+
+// Scenario: A closed shutter casts rays at the moment it opens
+// Given c ← camera(11, 11, π/2)
+// And c.shutter ← [0.25, 0.25]
+// When r ← ray_for_pixel(c, 5, 5)
+// Then r.time = 0.25
+func Test_a_Closed_Shutter_Casts_Rays_at_the_Moment_it_Opens(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	// And
+	c.SetShutter(0.25, 0.25)
+	// When
+	r := c.RayForPixel(5, 5)
+	// Then
+	if r.Time != 0.25 {
+		t.Errorf("RayForPixel(5, 5) has time %9.6f, expected %9.6f", r.Time, 0.25)
+	}
+}
+
+// Scenario: Rendering a moving sphere blurs it over the shutter interval
+// Given w ← default_world() with only the first sphere
+// And the sphere moves from translation(0, 0, 0) to translation(4, 0, 0)
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c.shutter ← [0, 1]
+// When image ← render(c, w)
+// Then pixel_at(image, 5, 5) is darker than the static render
+// And pixel_at(image, 5, 5) is not black
+func Test_Rendering_a_Moving_Sphere_Blurs_it_over_the_Shutter_Interval(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	w.Objects = w.Objects[:1]
+	// And
+	w.Objects[0].SetMotion(transformations.Translation(0, 0, 0), transformations.Translation(4, 0, 0))
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	static := c.Render(w).Get(5, 5)
+	// And
+	c.SetShutter(0, 1)
+	c.SamplesPerPixel = 16
+	// When
+	image := c.Render(w)
+	// Then
+	blurred := image.Get(5, 5)
+	if blurred.Green >= static.Green {
+		t.Errorf("Pixel (5, 5) = %v, expected darker than %v", blurred, static)
+	}
+	// And
+	if colors.Black().Equals(blurred) {
+		t.Errorf("Pixel (5, 5) = %v, expected not black", blurred)
+	}
+}
+
+// Scenario: Supersampling smooths the edge of a sphere
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c renders 16 jittered samples per pixel with a box filter
+// When image ← render(c, w)
+// Then a pixel on the edge of the sphere is between the background and the sphere color
+func Test_Supersampling_Smooths_the_Edge_of_a_Sphere(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	// And
+	c.SetSampling(16, sampling.NewJitteredSampler(), sampling.NewBoxFilter(0.5))
+	// When
+	image := c.Render(w)
+	// Then
+	edge := image.Get(4, 5)
+	inside := image.Get(5, 5)
+	if !(edge.Green > 0 && edge.Green < inside.Green) {
+		t.Errorf("Pixel (4, 5) = %v, expected between black and %v", edge, inside)
+	}
+}
+
+// Scenario: Adaptive sampling spends more samples on noisy pixels
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c starts with 4 jittered samples per pixel, adapting up to 64 samples
+// When image, counts ← render_with_sample_counts(c, w)
+// Then the background pixel (0, 0) took 4 samples
+// And the edge pixel (4, 5) took more samples than the background pixel
+func Test_Adaptive_Sampling_Spends_More_Samples_on_Noisy_Pixels(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	// And
+	c.SetSampling(4, sampling.NewJitteredSampler(), sampling.NewBoxFilter(0.5))
+	c.SetAdaptiveSampling(64, 0.01)
+	// When
+	_, counts := c.RenderWithSampleCounts(w)
+	// Expected
+	wantedBackground := colors.NewColor(4.0/64.0, 4.0/64.0, 4.0/64.0)
+	// Then
+	background := counts.Get(0, 0)
+	if !wantedBackground.Equals(background) {
+		t.Errorf("sample count of pixel (0, 0) = %v, expected %v", background, wantedBackground)
+	}
+	// And
+	edge := counts.Get(4, 5)
+	if edge.Red <= background.Red {
+		t.Errorf("sample count of pixel (4, 5) = %v, expected more than %v", edge, background)
+	}
+}
+
+// Scenario: Adaptive sampling refines pixels starting from a single sample
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c starts with 1 regular sample per pixel, adapting up to 64 samples
+// When image, counts ← render_with_sample_counts(c, w)
+// Then the background pixel (0, 0) took 2 samples, as one sample cannot show that a pixel is noise-free
+// And the edge pixel (4, 5) took more samples than the background pixel
+func Test_Adaptive_Sampling_Refines_Pixels_Starting_from_a_Single_Sample(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	// And
+	c.SetSampling(1, sampling.NewRegularSampler(), sampling.NewBoxFilter(0.5))
+	c.SetAdaptiveSampling(64, 0.01)
+	// When
+	_, counts := c.RenderWithSampleCounts(w)
+	// Expected
+	wantedBackground := colors.NewColor(2.0/64.0, 2.0/64.0, 2.0/64.0)
+	// Then
+	background := counts.Get(0, 0)
+	if !wantedBackground.Equals(background) {
+		t.Errorf("sample count of pixel (0, 0) = %v, expected %v", background, wantedBackground)
+	}
+	// And
+	edge := counts.Get(4, 5)
+	if edge.Red <= background.Red {
+		t.Errorf("sample count of pixel (4, 5) = %v, expected more than %v", edge, background)
+	}
+}
+
+// Scenario: Further batches of regular samples are placed at new positions
+// Given c ← camera(11, 11, π/2) with 4 regular samples per pixel
+// And rng ← random(1)
+// When first ← samples(refinement_sampler(c), 4, rng)
+// And second ← samples(refinement_sampler(c), 4, rng)
+// Then first ≠ second
+func Test_Further_Batches_of_Regular_Samples_are_Placed_at_New_Positions(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetSampling(4, sampling.NewRegularSampler(), sampling.NewBoxFilter(0.5))
+	// And
+	rng := rand.New(rand.NewSource(1))
+	// When
+	first := c.refinementSampler().Samples(4, rng)
+	// And
+	second := c.refinementSampler().Samples(4, rng)
+	// Then
+	same := true
+	for i := range first {
+		same = same && first[i] == second[i]
+	}
+	if same {
+		t.Errorf("refinement batches %v and %v are the same, expected new positions", first, second)
+	}
+}
+
+// Scenario: Looking at a point focuses on it
+// Given c ← camera(11, 11, π/2)
+// When look_at(c, point(0, 0, -5), point(0, 0, 0), vector(0, 1, 0))
+// Then c.transform = view_transform(point(0, 0, -5), point(0, 0, 0), vector(0, 1, 0))
+// And c.focal_distance = 5
+func Test_Looking_at_a_Point_Focuses_on_it(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	from := tuples.NewPoint(0, 0, -5)
+	to := tuples.NewPoint(0, 0, 0)
+	up := tuples.NewVector(0, 1, 0)
+	// When
+	c.LookAt(from, to, up)
+	// Expected
+	wantedTransform := transformations.NewViewTransform(from, to, up)
+	// Then
+	if !wantedTransform.Equals(c.Transform()) {
+		t.Errorf("%v has Transform %v, expected %v", c, c.Transform(), wantedTransform)
+	}
+	// And
+	if math.Abs(c.FocalDistance-5) > tuples.Epsilon {
+		t.Errorf("%v has FocalDistance %9.6f, expected %9.6f", c, c.FocalDistance, 5.0)
+	}
+}
+
+// Scenario: Rays through any point of the lens meet on the focal plane
+// Given c ← camera(201, 101, π/2)
+// And c.aperture ← 0.5
+// And c.focal_distance ← 4
+// When r1 ← ray_through_lens(c, 20.5, 30.5, sample(0, 0.5))
+// And r2 ← ray_through_lens(c, 20.5, 30.5, sample(0.9, 0.1))
+// Then r1.origin ≠ r2.origin
+// And r1 and r2 pass through the same point at z = -4
+func Test_Rays_through_Any_Point_of_the_Lens_Meet_on_the_Focal_Plane(t *testing.T) {
+	// Given
+	c := NewCamera(201, 101, math.Pi/2)
+	// And
+	c.SetDepthOfField(0.5, 4)
+	// When
+	r1 := c.RayThroughLens(20.5, 30.5, sampling.Sample{X: 0, Y: 0.5}, 0)
+	// And
+	r2 := c.RayThroughLens(20.5, 30.5, sampling.Sample{X: 0.9, Y: 0.1}, 0)
+	// Then
+	if r1.Origin.Equals(r2.Origin) {
+		t.Errorf("%v and %v start at the same point of the lens", r1, r2)
+	}
+	// And
+	p1 := r1.Position((-4 - r1.Origin.Z) / r1.Direction.Z)
+	p2 := r2.Position((-4 - r2.Origin.Z) / r2.Direction.Z)
+	if !p1.Equals(*p2) {
+		t.Errorf("%v and %v meet the focal plane at %v and %v, expected the same point", r1, r2, p1, p2)
+	}
+}
+
+// Scenario: A wide aperture blurs objects outside of the focal plane
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c.aperture ← 1.5 with a hexagonal aperture
+// And c.focal_distance ← 20
+// And c renders 32 jittered samples per pixel
+// When image ← render(c, w)
+// Then the edge pixel (3, 5) is lit while it is black without depth of field
+func Test_a_Wide_Aperture_Blurs_Objects_outside_of_the_Focal_Plane(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	sharp := c.Render(w).Get(3, 5)
+	// And
+	c.SetDepthOfField(1.5, 20)
+	c.ApertureBlades = 6
+	// And
+	c.SetSampling(32, sampling.NewJitteredSampler(), sampling.NewBoxFilter(0.5))
+	// When
+	image := c.Render(w)
+	// Then
+	if !colors.Black().Equals(sharp) {
+		t.Errorf("Pixel (3, 5) = %v without depth of field, expected %v", sharp, colors.Black())
+	}
+	if blurred := image.Get(3, 5); colors.Black().Equals(blurred) {
+		t.Errorf("Pixel (3, 5) = %v with depth of field, expected it to be lit", blurred)
+	}
+}
+
+// Scenario: A camera cannot look along its up vector
+// Given c ← camera(11, 11, π/2)
+// When err ← look_at(c, point(0, 0, 0), point(0, 5, 0), vector(0, 1, 0))
+// Then err is not nil
+// And c.transform = identity_matrix
+func Test_a_Camera_Cannot_Look_along_its_Up_Vector(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	// When
+	err := c.LookAt(tuples.NewPoint(0, 0, 0), tuples.NewPoint(0, 5, 0), tuples.NewVector(0, 1, 0))
+	// Then
+	if err == nil {
+		t.Errorf("LookAt along the up vector succeeded, expected an error")
+	}
+	// And
+	if !matrix.Identity(4).Equals(c.Transform()) {
+		t.Errorf("%v has Transform %v, expected %v", c, c.Transform(), matrix.Identity(4))
+	}
+}
+
+// Scenario: A camera rejects a transformation that is not 4x4
+// Given c ← camera(11, 11, π/2)
+// When err ← set_transform(c, identity_matrix of size 3)
+// Then err is ErrDimensionMismatch
+// And c.transform = identity_matrix
+func Test_a_Camera_Rejects_a_Transformation_that_is_not_4x4(t *testing.T) {
+	// Given
+	c := NewCamera(11, 11, math.Pi/2)
+	// When
+	err := c.SetTransform(*matrix.Identity(3))
+	// Then
+	if !errors.Is(err, matrix.ErrDimensionMismatch) {
+		t.Errorf("SetTransform(%v) returned error %v, expected %v", matrix.Identity(3), err, matrix.ErrDimensionMismatch)
+	}
+	// And
+	if !matrix.Identity(4).Equals(c.Transform()) {
+		t.Errorf("%v has Transform %v, expected %v", c, c.Transform(), matrix.Identity(4))
+	}
+}
+
+// Scenario: Rendering with a path tracer is repeatable with the same seed
+// Given w ← default_world()
+// And w.objects[1].transform ← translation(2.5, 0, 0), so the spheres light each other
+// And c ← camera(8, 8, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c.integrator ← path_tracer(4)
+// And c.samples_per_pixel ← 4, so more than a few pixels are noisy
+// When first ← render(c, w)
+// And second ← render(c, w)
+// And other ← render(c, w) with c.seed ← 1
+// Then first = second
+// But first ≠ other
+func Test_Rendering_with_a_Path_Tracer_is_Repeatable_with_the_Same_Seed(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	w.Objects[1].SetTransform(transformations.Translation(2.5, 0, 0))
+	// And
+	c := NewCamera(8, 8, math.Pi/2)
+	c.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// And
+	c.SetIntegrator(world.NewPathTracer(4))
+	// And
+	c.SamplesPerPixel = 4
+	// When
+	first := c.Render(w)
+	// And
+	second := c.Render(w)
+	// And
+	c.Seed = 1
+	other := c.Render(w)
+	// Then
+	same := true
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			if !first.Get(x, y).Equals(second.Get(x, y)) {
+				t.Errorf("Pixel (%d, %d) = %v and %v, expected the same color", x, y, first.Get(x, y), second.Get(x, y))
+			}
+			same = same && first.Get(x, y).Equals(other.Get(x, y))
+		}
+	}
+	// But
+	if same {
+		t.Errorf("Render() with seeds 0 and 1 gave the same image, expected different noise")
+	}
+}
+
+// Scenario: Neighbouring seeds do not share the random numbers of pixels
+// Given seeds 0 and 1 and the pixels with index 0 to 99
+// Then every pixel_seed(seed, index) is different
+func Test_Neighbouring_Seeds_do_not_Share_the_Random_Numbers_of_Pixels(t *testing.T) {
+	// Given
+	seen := map[int64]bool{}
+	for seed := int64(0); seed < 2; seed++ {
+		for index := 0; index < 100; index++ {
+			// Then
+			s := pixelSeed(seed, index)
+			if seen[s] {
+				t.Errorf("pixelSeed(%d, %d) = %d, expected a seed that no other pixel has", seed, index, s)
+			}
+			seen[s] = true
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/golden"
+	"github.com/bas-velthuizen/go-raytracer/sampling"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Scenario: Rendering the default world matches its reference image
+// Given w ← default_world()
+// And c ← camera(48, 32, π/3) looking from point(0, 1.5, -5) at point(0, 0, 0)
+// And c samples every pixel 4 times on a jittered grid with a tent filter
+// When image ← render(c, w)
+// Then image matches the golden image "default_world"
+func Test_Rendering_the_Default_World_Matches_its_Reference_Image(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(48, 32, math.Pi/3)
+	c.LookAt(tuples.NewPoint(0, 1.5, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// And
+	c.SetSampling(4, sampling.NewJitteredSampler(), sampling.NewTentFilter(1))
+	// When
+	image := c.Render(w)
+	// Then
+	golden.Assert(t, "default_world", image, golden.DefaultTolerance())
+}
@@ -0,0 +1,78 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Passes holds the auxiliary images of a render, taken from the primary hit of the ray through the center of each pixel
+// Every component of Depth holds the distance to the hit, +Inf where nothing is hit,
+// Normal holds the x, y and z of the world space normal, Albedo the base color of the material,
+// every component of ObjectID holds the index of the object in the world, -1 where nothing is hit,
+// and every component of Shadow the fraction of the light sources that are blocked
+type Passes struct {
+	Depth    *canvas.Canvas
+	Normal   *canvas.Canvas
+	Albedo   *canvas.Canvas
+	ObjectID *canvas.Canvas
+	Shadow   *canvas.Canvas
+}
+
+// RenderWithPasses renders an image of the world as seen by the camera, together with its auxiliary passes
+func (c Camera) RenderWithPasses(w world.World) (*canvas.Canvas, Passes) {
+	return c.Render(w), c.RenderPasses(w)
+}
+
+// RenderPasses renders the auxiliary passes of the world as seen by the camera
+func (c Camera) RenderPasses(w world.World) Passes {
+	passes := Passes{
+		Depth:    canvas.NewCanvas(c.HSize, c.VSize),
+		Normal:   canvas.NewCanvas(c.HSize, c.VSize),
+		Albedo:   canvas.NewCanvas(c.HSize, c.VSize),
+		ObjectID: canvas.NewCanvas(c.HSize, c.VSize),
+		Shadow:   canvas.NewCanvas(c.HSize, c.VSize),
+	}
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			surface := world.Surface{Distance: math.Inf(1), ObjectIndex: world.NoObject}
+			if ray := c.RayForPixel(x, y); ray != nil {
+				surface = w.SurfaceAt(*ray)
+			}
+			id := float64(surface.ObjectIndex)
+			passes.Depth.Set(x, y, colors.NewColor(surface.Distance, surface.Distance, surface.Distance))
+			passes.Normal.Set(x, y, colors.NewColor(surface.Normal.X, surface.Normal.Y, surface.Normal.Z))
+			passes.Albedo.Set(x, y, surface.Albedo)
+			passes.ObjectID.Set(x, y, colors.NewColor(id, id, id))
+			passes.Shadow.Set(x, y, colors.NewColor(surface.Shadow, surface.Shadow, surface.Shadow))
+		}
+	}
+	return passes
+}
+
+// Save writes every pass to its own Portable Float Map, which keeps the values unclamped,
+// named after the prefix: prefix_depth.pfm, prefix_normal.pfm, prefix_albedo.pfm,
+// prefix_object_id.pfm and prefix_shadow.pfm
+func (p Passes) Save(prefix string) error {
+	files := []struct {
+		suffix string
+		pass   *canvas.Canvas
+	}{
+		{"_depth.pfm", p.Depth},
+		{"_normal.pfm", p.Normal},
+		{"_albedo.pfm", p.Albedo},
+		{"_object_id.pfm", p.ObjectID},
+		{"_shadow.pfm", p.Shadow},
+	}
+	for _, file := range files {
+		if file.pass == nil {
+			continue
+		}
+		if err := file.pass.SavePFM(prefix + file.suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
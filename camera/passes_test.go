@@ -0,0 +1,94 @@
+package camera
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Scenario: Rendering the auxiliary passes of a world
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2)
+// And c.transform ← view_transform(point(0, 0, -5), point(0, 0, 0), vector(0, 1, 0))
+// When passes ← render_passes(c, w)
+// Then pixel_at(passes.depth, 5, 5) = color(4, 4, 4)
+// And pixel_at(passes.normal, 5, 5) = color(0, 0, -1)
+// And pixel_at(passes.albedo, 5, 5) = color(0.8, 1.0, 0.6)
+// And pixel_at(passes.object_id, 5, 5) = color(0, 0, 0)
+// And pixel_at(passes.object_id, 0, 0) = color(-1, -1, -1)
+// And pixel_at(passes.depth, 0, 0) = color(∞, ∞, ∞)
+func Test_Rendering_the_Auxiliary_Passes_of_a_World(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	// And
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	// When
+	passes := c.RenderPasses(w)
+	// Then
+	cases := []struct {
+		name   string
+		pass   *canvas.Canvas
+		x, y   int
+		wanted colors.Color
+	}{
+		{"depth", passes.Depth, 5, 5, colors.NewColor(4, 4, 4)},
+		{"normal", passes.Normal, 5, 5, colors.NewColor(0, 0, -1)},
+		{"albedo", passes.Albedo, 5, 5, colors.NewColor(0.8, 1.0, 0.6)},
+		{"object id", passes.ObjectID, 5, 5, colors.NewColor(0, 0, 0)},
+		{"object id", passes.ObjectID, 0, 0, colors.NewColor(-1, -1, -1)},
+		{"shadow", passes.Shadow, 5, 5, colors.NewColor(0, 0, 0)},
+	}
+	for _, tc := range cases {
+		if pixel := tc.pass.Get(tc.x, tc.y); !pixel.Equals(tc.wanted) {
+			t.Errorf("%s pass pixel (%d, %d) = %v, expected %v", tc.name, tc.x, tc.y, pixel, tc.wanted)
+		}
+	}
+	// And
+	if depth := passes.Depth.Get(0, 0); !math.IsInf(depth.Red, 1) {
+		t.Errorf("depth pass pixel (0, 0) = %v, expected infinity", depth)
+	}
+}
+
+// Scenario: Saving the auxiliary passes writes a file per pass
+// Given passes ← render_passes(camera(4, 3, π/2), default_world())
+// When save(passes, dir/"scene")
+// Then dir contains scene_depth.pfm, scene_normal.pfm, scene_albedo.pfm, scene_object_id.pfm and scene_shadow.pfm
+// And reading scene_object_id.pfm gives back the object ID pass
+func Test_Saving_the_Auxiliary_Passes_Writes_a_File_per_Pass(t *testing.T) {
+	// Given
+	c := NewCamera(4, 3, math.Pi/2)
+	c.SetTransform(transformations.NewViewTransform(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0)))
+	passes := c.RenderPasses(world.DefaultWorld())
+	dir := t.TempDir()
+	// When
+	if err := passes.Save(filepath.Join(dir, "scene")); err != nil {
+		t.Fatalf("Save() returned %v", err)
+	}
+	// Then
+	for _, name := range []string{"scene_depth.pfm", "scene_normal.pfm", "scene_albedo.pfm", "scene_object_id.pfm", "scene_shadow.pfm"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was not written: %v", name, err)
+		}
+	}
+	// And
+	ids, err := canvas.Load(filepath.Join(dir, "scene_object_id.pfm"))
+	if err != nil {
+		t.Fatalf("Load() returned %v", err)
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			if ids.Get(x, y) != passes.ObjectID.Get(x, y) {
+				t.Errorf("object id pixel (%d, %d) = %v, expected %v", x, y, ids.Get(x, y), passes.ObjectID.Get(x, y))
+			}
+		}
+	}
+}
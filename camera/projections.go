@@ -0,0 +1,151 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Projection maps positions on the canvas to rays in camera space, where the camera
+// looks along the negative z axis with the positive y axis up
+type Projection interface {
+	// CameraRay returns the origin and direction of the ray through a position on the canvas,
+	// with u running from the left (0) to the right (1) edge and v from the top (0) to the bottom (1) edge
+	// ok is false when the position lies outside of the projected image
+	CameraRay(u, v, aspect float64) (origin tuples.Point, direction tuples.Vector, ok bool)
+}
+
+// PerspectiveProjection is a pinhole projection with a field of view in radians along the longest side of the canvas
+type PerspectiveProjection struct {
+	FieldOfView float64
+}
+
+// OrthographicProjection casts parallel rays from a rectangle of the specified width in world units
+type OrthographicProjection struct {
+	Width float64
+}
+
+// FisheyeProjection is an equidistant fisheye projection onto a circle that fits the shortest side of the canvas
+// The field of view in radians is at most π; a larger FieldOfView is treated as π
+type FisheyeProjection struct {
+	FieldOfView float64
+}
+
+// EquirectangularProjection maps the full sphere around the camera onto the canvas,
+// longitude horizontally and latitude vertically, as used for 360° panoramas
+type EquirectangularProjection struct{}
+
+// NewPerspectiveProjection creates a new PerspectiveProjection
+func NewPerspectiveProjection(fieldOfView float64) PerspectiveProjection {
+	return PerspectiveProjection{fieldOfView}
+}
+
+// NewOrthographicProjection creates a new OrthographicProjection
+func NewOrthographicProjection(width float64) OrthographicProjection {
+	return OrthographicProjection{width}
+}
+
+// NewFisheyeProjection creates a new FisheyeProjection, limiting the field of view to π
+func NewFisheyeProjection(fieldOfView float64) FisheyeProjection {
+	return FisheyeProjection{math.Min(fieldOfView, math.Pi)}
+}
+
+// NewEquirectangularProjection creates a new EquirectangularProjection
+func NewEquirectangularProjection() EquirectangularProjection {
+	return EquirectangularProjection{}
+}
+
+// ProjectionByName creates a projection from its name, as used in scene descriptions
+// The parameter is the field of view in radians for "perspective" and "fisheye", where the fisheye field of view
+// is limited to π as by NewFisheyeProjection, the width for "orthographic" and is ignored for "equirectangular"
+func ProjectionByName(name string, parameter float64) (Projection, error) {
+	switch name {
+	case "perspective":
+		return NewPerspectiveProjection(parameter), nil
+	case "orthographic":
+		return NewOrthographicProjection(parameter), nil
+	case "fisheye":
+		return NewFisheyeProjection(parameter), nil
+	case "equirectangular":
+		return NewEquirectangularProjection(), nil
+	}
+	return nil, fmt.Errorf("unknown projection %q", name)
+}
+
+// halfExtent returns half of the width and height of a canvas with the specified aspect ratio
+// whose longest side has half size 1
+func halfExtent(aspect float64) (float64, float64) {
+	if aspect >= 1 {
+		return 1, 1 / aspect
+	}
+	return aspect, 1
+}
+
+// CameraRay returns the ray through a position on the canvas
+func (p PerspectiveProjection) CameraRay(u, v, aspect float64) (tuples.Point, tuples.Vector, bool) {
+	halfView := math.Tan(p.FieldOfView / 2)
+	halfWidth, halfHeight := halfExtent(aspect)
+	x := halfView * halfWidth * (1 - 2*u)
+	y := halfView * halfHeight * (1 - 2*v)
+	return tuples.NewPoint(0, 0, 0), tuples.NewVector(x, y, -1).Normalize(), true
+}
+
+// String formats the PerspectiveProjection as a string
+func (p PerspectiveProjection) String() string {
+	return fmt.Sprintf("PerspectiveProjection( %9.6f )", p.FieldOfView)
+}
+
+// CameraRay returns the ray through a position on the canvas
+func (p OrthographicProjection) CameraRay(u, v, aspect float64) (tuples.Point, tuples.Vector, bool) {
+	halfWidth, halfHeight := halfExtent(aspect)
+	x := p.Width / 2 * halfWidth * (1 - 2*u)
+	y := p.Width / 2 * halfHeight * (1 - 2*v)
+	return tuples.NewPoint(x, y, 0), tuples.NewVector(0, 0, -1), true
+}
+
+// String formats the OrthographicProjection as a string
+func (p OrthographicProjection) String() string {
+	return fmt.Sprintf("OrthographicProjection( %9.6f )", p.Width)
+}
+
+// CameraRay returns the ray through a position on the canvas, positions outside of the image circle are not ok
+func (p FisheyeProjection) CameraRay(u, v, aspect float64) (tuples.Point, tuples.Vector, bool) {
+	halfWidth, halfHeight := halfExtent(aspect)
+	x := (1 - 2*u) * halfWidth / math.Min(halfWidth, halfHeight)
+	y := (1 - 2*v) * halfHeight / math.Min(halfWidth, halfHeight)
+	r := math.Sqrt(x*x + y*y)
+	if r > 1 {
+		return tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, -1), false
+	}
+	theta := r * p.fieldOfView() / 2
+	phi := math.Atan2(y, x)
+	direction := tuples.NewVector(math.Sin(theta)*math.Cos(phi), math.Sin(theta)*math.Sin(phi), -math.Cos(theta))
+	return tuples.NewPoint(0, 0, 0), direction, true
+}
+
+// fieldOfView returns the FieldOfView, limited to π
+func (p FisheyeProjection) fieldOfView() float64 {
+	return math.Min(p.FieldOfView, math.Pi)
+}
+
+// String formats the FisheyeProjection as a string
+func (p FisheyeProjection) String() string {
+	return fmt.Sprintf("FisheyeProjection( %9.6f )", p.FieldOfView)
+}
+
+// CameraRay returns the ray through a position on the canvas
+func (p EquirectangularProjection) CameraRay(u, v, aspect float64) (tuples.Point, tuples.Vector, bool) {
+	longitude := (u - 0.5) * 2 * math.Pi
+	latitude := (0.5 - v) * math.Pi
+	direction := tuples.NewVector(
+		-math.Cos(latitude)*math.Sin(longitude),
+		math.Sin(latitude),
+		-math.Cos(latitude)*math.Cos(longitude))
+	return tuples.NewPoint(0, 0, 0), direction, true
+}
+
+// String formats the EquirectangularProjection as a string
+func (p EquirectangularProjection) String() string {
+	return "EquirectangularProjection()"
+}
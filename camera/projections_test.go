@@ -0,0 +1,172 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Scenario: An orthographic projection casts parallel rays
+// Given p ← orthographic_projection(4)
+// When (o1, d1) ← camera_ray(p, 0, 0, 2)
+// And (o2, d2) ← camera_ray(p, 1, 1, 2)
+// Then o1 = point(2, 1, 0)
+// And o2 = point(-2, -1, 0)
+// And d1 = d2 = vector(0, 0, -1)
+func Test_an_Orthographic_Projection_Casts_Parallel_Rays(t *testing.T) {
+	// Given
+	p := NewOrthographicProjection(4)
+	// When
+	o1, d1, _ := p.CameraRay(0, 0, 2)
+	// And
+	o2, d2, _ := p.CameraRay(1, 1, 2)
+	// Expected
+	wantedO1 := tuples.NewPoint(2, 1, 0)
+	wantedO2 := tuples.NewPoint(-2, -1, 0)
+	wantedD := tuples.NewVector(0, 0, -1)
+	// Then
+	if !wantedO1.Equals(o1) {
+		t.Errorf("%v.CameraRay(0, 0, 2) starts at %v, expected %v", p, o1, wantedO1)
+	}
+	// And
+	if !wantedO2.Equals(o2) {
+		t.Errorf("%v.CameraRay(1, 1, 2) starts at %v, expected %v", p, o2, wantedO2)
+	}
+	// And
+	if !wantedD.Equals(d1) || !wantedD.Equals(d2) {
+		t.Errorf("%v casts rays in directions %v and %v, expected %v", p, d1, d2, wantedD)
+	}
+}
+
+// Scenario: A 180° fisheye sees sideways at the edge of its image circle
+// Given p ← fisheye_projection(π)
+// When (o, d) ← camera_ray(p, 0, 0.5, 1)
+// Then d = vector(1, 0, 0)
+// And camera_ray(p, 0.5, 0.5, 1) has direction vector(0, 0, -1)
+// And camera_ray(p, 0, 0, 1) is outside of the image
+func Test_a_180_Degree_Fisheye_Sees_Sideways_at_the_Edge_of_its_Image_Circle(t *testing.T) {
+	// Given
+	p := NewFisheyeProjection(math.Pi)
+	// When
+	_, d, ok := p.CameraRay(0, 0.5, 1)
+	// Expected
+	wanted := tuples.NewVector(1, 0, 0)
+	// Then
+	if !ok || !wanted.Equals(d) {
+		t.Errorf("%v.CameraRay(0, 0.5, 1) = %v, %v, expected %v, true", p, d, ok, wanted)
+	}
+	// And
+	if _, d, _ := p.CameraRay(0.5, 0.5, 1); !tuples.NewVector(0, 0, -1).Equals(d) {
+		t.Errorf("%v.CameraRay(0.5, 0.5, 1) = %v, expected %v", p, d, tuples.NewVector(0, 0, -1))
+	}
+	// And
+	if _, _, ok := p.CameraRay(0, 0, 1); ok {
+		t.Errorf("%v.CameraRay(0, 0, 1) is inside of the image, expected outside", p)
+	}
+}
+
+// Scenario: A fisheye field of view is limited to 180°
+// Given p ← fisheye_projection(3π/2)
+// Then p.field_of_view = π
+func Test_a_Fisheye_Field_of_View_is_Limited_to_180_Degrees(t *testing.T) {
+	// Given
+	p := NewFisheyeProjection(3 * math.Pi / 2)
+	// Then
+	if p.FieldOfView != math.Pi {
+		t.Errorf("%v has FieldOfView %9.6f, expected %9.6f", p, p.FieldOfView, math.Pi)
+	}
+}
+
+// Scenario: A fisheye with a wider field of view than 180° sees no further than sideways
+// Given p ← a fisheye projection with field_of_view 4, not limited by its constructor
+// When (o, d) ← camera_ray(p, 0, 0.5, 1)
+// Then d = vector(1, 0, 0)
+func Test_a_Fisheye_with_a_Wider_Field_of_View_than_180_Degrees_Sees_no_Further_than_Sideways(t *testing.T) {
+	// Given
+	p := FisheyeProjection{FieldOfView: 4}
+	// When
+	_, d, ok := p.CameraRay(0, 0.5, 1)
+	// Expected
+	wanted := tuples.NewVector(1, 0, 0)
+	// Then
+	if !ok || !wanted.Equals(d) {
+		t.Errorf("%v.CameraRay(0, 0.5, 1) = %v, %v, expected %v, true", p, d, ok, wanted)
+	}
+}
+
+// Scenario: An equirectangular projection covers the full sphere
+// Given p ← equirectangular_projection()
+// Then camera_ray(p, 0.5, 0.5, 2) has direction vector(0, 0, -1)
+// And camera_ray(p, 0.75, 0.5, 2) has direction vector(-1, 0, 0)
+// And camera_ray(p, 0, 0.5, 2) has direction vector(0, 0, 1)
+// And camera_ray(p, 0.3, 0, 2) has direction vector(0, 1, 0)
+func Test_an_Equirectangular_Projection_Covers_the_Full_Sphere(t *testing.T) {
+	// Given
+	p := NewEquirectangularProjection()
+	cases := []struct {
+		u, v   float64
+		wanted tuples.Vector
+	}{
+		{0.5, 0.5, tuples.NewVector(0, 0, -1)},
+		{0.75, 0.5, tuples.NewVector(-1, 0, 0)},
+		{0, 0.5, tuples.NewVector(0, 0, 1)},
+		{0.3, 0, tuples.NewVector(0, 1, 0)},
+	}
+	// Then
+	for _, c := range cases {
+		if _, d, _ := p.CameraRay(c.u, c.v, 2); !c.wanted.Equals(d) {
+			t.Errorf("%v.CameraRay(%9.6f, %9.6f, 2) = %v, expected %v", p, c.u, c.v, d, c.wanted)
+		}
+	}
+}
+
+// Scenario: Selecting projections by name
+// Then projection_by_name("orthographic", 3) = orthographic_projection(3)
+// And projection_by_name("fisheye", 4) = fisheye_projection(π)
+// And projection_by_name("cylindrical", 1) fails
+func Test_Selecting_Projections_by_Name(t *testing.T) {
+	// Then
+	if p, err := ProjectionByName("orthographic", 3); err != nil || p != NewOrthographicProjection(3) {
+		t.Errorf("ProjectionByName(\"orthographic\", 3) = %v, %v, expected %v", p, err, NewOrthographicProjection(3))
+	}
+	// And
+	if p, err := ProjectionByName("fisheye", 4); err != nil || p != NewFisheyeProjection(math.Pi) {
+		t.Errorf("ProjectionByName(\"fisheye\", 4) = %v, %v, expected %v", p, err, NewFisheyeProjection(math.Pi))
+	}
+	// And
+	if p, err := ProjectionByName("cylindrical", 1); err == nil {
+		t.Errorf("ProjectionByName(\"cylindrical\", 1) = %v, expected an error", p)
+	}
+}
+
+// Scenario: Rendering with an orthographic projection
+// Given w ← default_world()
+// And c ← camera(11, 11, π/2) looking from point(0, 0, -5) to point(0, 0, 0)
+// And c.projection ← orthographic_projection(2.2)
+// When image ← render(c, w)
+// Then pixel_at(image, 5, 5) = color(0.38066, 0.47583, 0.2855)
+// And pixel_at(image, 0, 0) = color(0, 0, 0)
+func Test_Rendering_with_an_Orthographic_Projection(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	c := NewCamera(11, 11, math.Pi/2)
+	c.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// And
+	c.SetProjection(NewOrthographicProjection(2.2))
+	// When
+	image := c.Render(w)
+	// Expected
+	wanted := colors.NewColor(0.38066, 0.47583, 0.2855)
+	// Then
+	if !wanted.Equals(image.Get(5, 5)) {
+		t.Errorf("Pixel (5, 5) = %v, expected %v", image.Get(5, 5), wanted)
+	}
+	// And
+	if !colors.Black().Equals(image.Get(0, 0)) {
+		t.Errorf("Pixel (0, 0) = %v, expected %v", image.Get(0, 0), colors.Black())
+	}
+}
@@ -0,0 +1,97 @@
+package camera
+
+import (
+	"fmt"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// StereoRig is a pair of cameras, one for each eye, placed InterocularDistance apart
+// Both eyes turn inwards to look at the point at ConvergenceDistance in front of the rig
+type StereoRig struct {
+	Camera              Camera
+	InterocularDistance float64
+	ConvergenceDistance float64
+	From                tuples.Point
+	To                  tuples.Point
+	Up                  tuples.Vector
+}
+
+// NewStereoRig creates a new StereoRig from a camera that describes the settings of both eyes
+func NewStereoRig(c Camera, interocularDistance, convergenceDistance float64) *StereoRig {
+	return &StereoRig{
+		Camera:              c,
+		InterocularDistance: interocularDistance,
+		ConvergenceDistance: convergenceDistance,
+		From:                tuples.NewPoint(0, 0, 0),
+		To:                  tuples.NewPoint(0, 0, -1),
+		Up:                  tuples.NewVector(0, 1, 0),
+	}
+}
+
+// String formats the StereoRig as a string
+func (s StereoRig) String() string {
+	return fmt.Sprintf("StereoRig( %v, %9.6f, %9.6f, %v, %v, %v )", s.Camera, s.InterocularDistance, s.ConvergenceDistance, s.From, s.To, s.Up)
+}
+
+// LookAt points the rig from a point, between the eyes, towards another point
+// It fails when the up vector is parallel to the viewing direction
+func (s *StereoRig) LookAt(fromPoint, toPoint tuples.Point, upVector tuples.Vector) error {
+	if !transformations.NewViewTransform(fromPoint, toPoint, upVector).IsInvertible() {
+		return fmt.Errorf("stereo rig looking from %v to %v with up %v: %v", fromPoint, toPoint, upVector, matrix.ErrNotInvertible)
+	}
+	s.From = fromPoint
+	s.To = toPoint
+	s.Up = upVector
+	return nil
+}
+
+// Eyes returns the cameras of the left and right eye
+// It fails when the view of an eye cannot be inverted, as when the up vector is parallel to the viewing direction
+func (s StereoRig) Eyes() (Camera, Camera, error) {
+	forward := s.To.Subtract(s.From).Normalize()
+	left := forward.Cross(s.Up.Normalize()).Normalize()
+	convergence := s.From.Add(forward.Multiply(s.ConvergenceDistance))
+	offset := left.Multiply(s.InterocularDistance / 2)
+
+	leftEye := s.Camera
+	if err := leftEye.SetTransform(transformations.NewViewTransform(s.From.Add(offset), convergence, s.Up)); err != nil {
+		return Camera{}, Camera{}, fmt.Errorf("left eye: %w", err)
+	}
+	rightEye := s.Camera
+	if err := rightEye.SetTransform(transformations.NewViewTransform(s.From.SubtractVector(offset), convergence, s.Up)); err != nil {
+		return Camera{}, Camera{}, fmt.Errorf("right eye: %w", err)
+	}
+	return leftEye, rightEye, nil
+}
+
+// Render renders the images of the left and right eye
+func (s StereoRig) Render(w world.World) (*canvas.Canvas, *canvas.Canvas, error) {
+	leftEye, rightEye, err := s.Eyes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return leftEye.Render(w), rightEye.Render(w), nil
+}
+
+// RenderSideBySide renders the image of the left eye next to the image of the right eye
+func (s StereoRig) RenderSideBySide(w world.World) (*canvas.Canvas, error) {
+	left, right, err := s.Render(w)
+	if err != nil {
+		return nil, err
+	}
+	return canvas.SideBySide(left, right), nil
+}
+
+// RenderAnaglyph renders a red/cyan anaglyph, red for the left eye and cyan for the right eye
+func (s StereoRig) RenderAnaglyph(w world.World) (*canvas.Canvas, error) {
+	left, right, err := s.Render(w)
+	if err != nil {
+		return nil, err
+	}
+	return canvas.Anaglyph(left, right), nil
+}
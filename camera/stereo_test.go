@@ -0,0 +1,141 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+	"github.com/bas-velthuizen/go-raytracer/world"
+)
+
+// Scenario: The eyes of a stereo rig converge on a point
+// Given rig ← stereo_rig(camera(11, 11, π/2), 0.4, 5)
+// When look_at(rig, point(0, 0, -5), point(0, 0, 0), vector(0, 1, 0))
+// And left, right ← eyes(rig)
+// Then the center ray of left starts at point(-0.2, 0, -5)
+// And the center ray of right starts at point(0.2, 0, -5)
+// And both center rays pass through point(0, 0, 0)
+func Test_the_Eyes_of_a_Stereo_Rig_Converge_on_a_Point(t *testing.T) {
+	// Given
+	rig := NewStereoRig(*NewCamera(11, 11, math.Pi/2), 0.4, 5)
+	// When
+	rig.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// And
+	left, right, err := rig.Eyes()
+	if err != nil {
+		t.Fatalf("%v.Eyes() failed: %v", rig, err)
+	}
+	leftRay := left.RayForPixel(5, 5)
+	rightRay := right.RayForPixel(5, 5)
+	// Expected
+	wantedLeft := tuples.NewPoint(-0.2, 0, -5)
+	wantedRight := tuples.NewPoint(0.2, 0, -5)
+	convergence := tuples.NewPoint(0, 0, 0)
+	// Then
+	if !wantedLeft.Equals(leftRay.Origin) {
+		t.Errorf("left eye is at %v, expected %v", leftRay.Origin, wantedLeft)
+	}
+	// And
+	if !wantedRight.Equals(rightRay.Origin) {
+		t.Errorf("right eye is at %v, expected %v", rightRay.Origin, wantedRight)
+	}
+	// And
+	if p := leftRay.Position(convergence.Subtract(leftRay.Origin).Magnitude()); !convergence.Equals(*p) {
+		t.Errorf("%v misses the convergence point %v", leftRay, convergence)
+	}
+	if p := rightRay.Position(convergence.Subtract(rightRay.Origin).Magnitude()); !convergence.Equals(*p) {
+		t.Errorf("%v misses the convergence point %v", rightRay, convergence)
+	}
+}
+
+// Scenario: Rendering a stereo pair side by side
+// Given w ← default_world()
+// And rig ← stereo_rig(camera(11, 11, π/2), 0.4, 5) looking from point(0, 0, -5) to point(0, 0, 0)
+// When image ← render_side_by_side(rig, w)
+// Then image.width = 22
+// And image.height = 11
+// And pixel_at(image, 5, 5) = pixel_at(render(left eye), 5, 5)
+// And pixel_at(image, 16, 5) = pixel_at(render(right eye), 5, 5)
+func Test_Rendering_a_Stereo_Pair_Side_by_Side(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	rig := NewStereoRig(*NewCamera(11, 11, math.Pi/2), 0.4, 5)
+	rig.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// When
+	image, err := rig.RenderSideBySide(w)
+	if err != nil {
+		t.Fatalf("%v.RenderSideBySide(w) failed: %v", rig, err)
+	}
+	// Expected
+	left, right, _ := rig.Render(w)
+	// Then
+	if image.Width != 22 || image.Height != 11 {
+		t.Errorf("side by side image is %d x %d, expected %d x %d", image.Width, image.Height, 22, 11)
+	}
+	// And
+	if !left.Get(5, 5).Equals(image.Get(5, 5)) {
+		t.Errorf("Pixel (5, 5) = %v, expected %v", image.Get(5, 5), left.Get(5, 5))
+	}
+	// And
+	if !right.Get(5, 5).Equals(image.Get(16, 5)) {
+		t.Errorf("Pixel (16, 5) = %v, expected %v", image.Get(16, 5), right.Get(5, 5))
+	}
+}
+
+// Scenario: Rendering a stereo pair as an anaglyph
+// Given w ← default_world()
+// And rig ← stereo_rig(camera(11, 11, π/2), 0.4, 5) looking from point(0, 0, -5) to point(0, 0, 0)
+// When image ← render_anaglyph(rig, w)
+// Then the red channel of image comes from the left eye
+// And the green and blue channels of image come from the right eye
+func Test_Rendering_a_Stereo_Pair_as_an_Anaglyph(t *testing.T) {
+	// Given
+	w := world.DefaultWorld()
+	// And
+	rig := NewStereoRig(*NewCamera(11, 11, math.Pi/2), 0.4, 5)
+	rig.LookAt(tuples.NewPoint(0, 0, -5), tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// When
+	image, err := rig.RenderAnaglyph(w)
+	if err != nil {
+		t.Fatalf("%v.RenderAnaglyph(w) failed: %v", rig, err)
+	}
+	// Expected
+	left, right, _ := rig.Render(w)
+	// Then
+	for y := 0; y < 11; y++ {
+		for x := 0; x < 11; x++ {
+			c := image.Get(x, y)
+			if math.Abs(c.Red-left.Get(x, y).Red) > tuples.Epsilon {
+				t.Errorf("red of pixel (%d, %d) = %9.6f, expected %9.6f", x, y, c.Red, left.Get(x, y).Red)
+			}
+			// And
+			if math.Abs(c.Green-right.Get(x, y).Green) > tuples.Epsilon || math.Abs(c.Blue-right.Get(x, y).Blue) > tuples.Epsilon {
+				t.Errorf("pixel (%d, %d) = %v, expected green and blue of %v", x, y, c, right.Get(x, y))
+			}
+		}
+	}
+}
+
+// Scenario: The eyes of a stereo rig looking along its up vector cannot be placed
+// Given rig ← stereo_rig(camera(11, 11, π/2), 0.4, 5)
+// And rig.up ← vector(0, 0, -1)
+// When left, right, err ← eyes(rig)
+// Then err is not nil
+// And render_anaglyph(rig, default_world()) fails
+func Test_the_Eyes_of_a_Stereo_Rig_Looking_Along_its_Up_Vector_Cannot_be_Placed(t *testing.T) {
+	// Given
+	rig := NewStereoRig(*NewCamera(11, 11, math.Pi/2), 0.4, 5)
+	// And
+	rig.Up = tuples.NewVector(0, 0, -1)
+	// When
+	_, _, err := rig.Eyes()
+	// Then
+	if err == nil {
+		t.Errorf("%v.Eyes() succeeded, expected an error", rig)
+	}
+	// And
+	if _, err := rig.RenderAnaglyph(world.DefaultWorld()); err == nil {
+		t.Errorf("%v.RenderAnaglyph() succeeded, expected an error", rig)
+	}
+}
@@ -10,22 +10,22 @@ import (
 )
 
 type projectile struct {
-	position tuples.Tuple
-	speed    tuples.Tuple
+	position tuples.Point
+	speed    tuples.Vector
 }
 
 type world struct {
-	wind    tuples.Tuple
-	gravity tuples.Tuple
+	wind    tuples.Vector
+	gravity tuples.Vector
 }
 
 func main() {
-	start := tuples.Point(0,1,0)
-	velocity := tuples.Vector(1,1.8,0).Normalize().Multiply(11.25 )
+	start := tuples.NewPoint(0,1,0)
+	velocity := tuples.NewVector(1,1.8,0).Normalize().Multiply(11.25 )
 	p := projectile{start, velocity}
 
-	wind := tuples.Vector(-0.01, 0, 0)
-	gravity := tuples.Vector(0, -0.1, 0)
+	wind := tuples.NewVector(-0.01, 0, 0)
+	gravity := tuples.NewVector(0, -0.1, 0)
 	w := world{wind, gravity}
 
 	tick := 0
@@ -46,7 +46,7 @@ func main() {
 	}
 
 	fmt.Println("Curve finished, now saving")
-	err := c.ToPPM().ToFile("./curve.ppm")
+	err := c.SavePPM("./curve.ppm", canvas.PPMOptions{Format: canvas.P6})
 	if err != nil {
 		fmt.Println(err)
 	}
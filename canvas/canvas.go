@@ -1,12 +1,14 @@
 package canvas
 
 import (
-	"fmt"
-	"github.com/bas-velthuizen/go-raytracer/colors"
+	"bufio"
+	"bytes"
+	"io"
 	"log"
-	"math"
 	"os"
-	"strconv"
+	"strings"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
 )
 
 // Canvas implements a canvas on which bitmap images can be projected/drawn
@@ -16,6 +18,7 @@ type Canvas struct {
 	grid   []colors.Color
 }
 
+// PPM holds the lines of a plain PPM image
 type PPM struct {
 	Lines []string
 }
@@ -43,40 +46,18 @@ func (c Canvas) Set(x int, y int, color colors.Color) {
 	c.grid[y*c.Width+x] = color
 }
 
-// ToPPM creates a PPM data structure of the Canvas
+// ToPPM creates a PPM data structure of the Canvas. Use WritePPM to stream large images instead
 func (c Canvas) ToPPM() PPM {
-	lines := []string{"P3", fmt.Sprintf("%d %d", c.Width, c.Height), "255"}
-	for row := 0; row < c.Height; row++ {
-		log.Printf("Exporting row: %d of %d...", row+1, c.Height)
-		stringList := make([]string, c.Width*c.Height*3)
-		for col := 0; col < c.Width; col++ {
-			color := c.Get(col, row)
-			stringList = append(stringList, strconv.Itoa(toPPMColorComponent(color.Red)), strconv.Itoa(toPPMColorComponent(color.Green)), strconv.Itoa(toPPMColorComponent(color.Blue)))
-		}
-		line := ""
-		for i:=0; i < len(stringList); i++ {
-			if len(line + stringList[i]) >= 70 {
-				lines = append(lines, line)
-				line = ""
-			}
-			if len(line) > 0 {
-				line += " "
-			}
-			line += stringList[i]
-		}
-		lines = append(lines, line)
-	}
-	log.Print("Exporting done")
-	return PPM{lines}
+	var buffer bytes.Buffer
+	c.WritePPM(&buffer, PPMOptions{Format: P3})
+	return PPM{strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")}
 }
 
 // ToString writes the color pixmap to a string
 func (p PPM) ToString() string {
-	result := ""
-	for i := 0; i < len(p.Lines); i++ {
-		result += fmt.Sprintf("%s\n", p.Lines[i])
-	}
-	return result
+	var builder strings.Builder
+	p.write(&builder)
+	return builder.String()
 }
 
 // ToFile writes the color pixmap to a file
@@ -85,23 +66,26 @@ func (p PPM) ToFile(path string) error {
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	out := bufio.NewWriter(file)
+	if err := p.write(out); err != nil {
+		file.Close()
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
 
-	for i := 0; i < len(p.Lines); i++ {
-		_, writeErr := file.WriteString( fmt.Sprintf("%s\n", p.Lines[i]) )
-		if writeErr != nil {
+func (p PPM) write(w io.Writer) error {
+	for _, line := range p.Lines {
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
 			return err
 		}
 	}
 	return nil
 }
-
-func toPPMColorComponent(component float64) int {
-	value := int(math.Round(255 * component))
-	if value < 0 {
-		value = 0
-	} else if value > 255 {
-		value = 255
-	}
-	return value
-}
@@ -0,0 +1,31 @@
+package canvas
+
+import "github.com/bas-velthuizen/go-raytracer/colors"
+
+// SideBySide places two canvases of the same height next to each other on a new Canvas
+func SideBySide(left, right *Canvas) *Canvas {
+	result := NewCanvas(left.Width+right.Width, left.Height)
+	for y := 0; y < left.Height; y++ {
+		for x := 0; x < left.Width; x++ {
+			result.Set(x, y, left.Get(x, y))
+		}
+		for x := 0; x < right.Width; x++ {
+			result.Set(left.Width+x, y, right.Get(x, y))
+		}
+	}
+	return result
+}
+
+// Anaglyph combines the images of the left and right eye of the same size into a red/cyan anaglyph,
+// taking the red channel from the left image and the green and blue channels from the right image
+func Anaglyph(left, right *Canvas) *Canvas {
+	result := NewCanvas(left.Width, left.Height)
+	for y := 0; y < left.Height; y++ {
+		for x := 0; x < left.Width; x++ {
+			l := left.Get(x, y)
+			r := right.Get(x, y)
+			result.Set(x, y, colors.NewColor(l.Red, r.Green, r.Blue))
+		}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package canvas
+
+import (
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: Placing two canvases side by side
+// Given left ← canvas(2, 3) with pixel (1, 2) red
+// And right ← canvas(3, 3) with pixel (0, 1) blue
+// When c ← side_by_side(left, right)
+// Then c.width = 5
+// And c.height = 3
+// And pixel_at(c, 1, 2) = red
+// And pixel_at(c, 2, 1) = blue
+func Test_Placing_Two_Canvases_Side_by_Side(t *testing.T) {
+	// Given
+	red := colors.NewColor(1, 0, 0)
+	left := NewCanvas(2, 3)
+	left.Set(1, 2, red)
+	// And
+	blue := colors.NewColor(0, 0, 1)
+	right := NewCanvas(3, 3)
+	right.Set(0, 1, blue)
+	// When
+	c := SideBySide(left, right)
+	// Then
+	if c.Width != 5 || c.Height != 3 {
+		t.Errorf("SideBySide is %d x %d, want %d x %d", c.Width, c.Height, 5, 3)
+	}
+	// And
+	if !red.Equals(c.Get(1, 2)) {
+		t.Errorf("c.Pixel(1, 2) == %v, want %v", c.Get(1, 2), red)
+	}
+	// And
+	if !blue.Equals(c.Get(2, 1)) {
+		t.Errorf("c.Pixel(2, 1) == %v, want %v", c.Get(2, 1), blue)
+	}
+}
+
+// Scenario: Combining two canvases into an anaglyph
+// Given left ← canvas(1, 1) with pixel (0, 0) = color(0.2, 0.4, 0.6)
+// And right ← canvas(1, 1) with pixel (0, 0) = color(0.7, 0.8, 0.9)
+// When c ← anaglyph(left, right)
+// Then pixel_at(c, 0, 0) = color(0.2, 0.8, 0.9)
+func Test_Combining_Two_Canvases_into_an_Anaglyph(t *testing.T) {
+	// Given
+	left := NewCanvas(1, 1)
+	left.Set(0, 0, colors.NewColor(0.2, 0.4, 0.6))
+	// And
+	right := NewCanvas(1, 1)
+	right.Set(0, 0, colors.NewColor(0.7, 0.8, 0.9))
+	// When
+	c := Anaglyph(left, right)
+	// Expected
+	wanted := colors.NewColor(0.2, 0.8, 0.9)
+	// Then
+	if !wanted.Equals(c.Get(0, 0)) {
+		t.Errorf("c.Pixel(0, 0) == %v, want %v", c.Get(0, 0), wanted)
+	}
+}
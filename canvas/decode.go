@@ -0,0 +1,233 @@
+package canvas
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// ErrInvalidPPM is returned when PPM data cannot be parsed
+var ErrInvalidPPM = errors.New("invalid PPM data")
+
+// ErrUnknownImageFormat is returned when an image is not in one of the formats read by Decode
+var ErrUnknownImageFormat = errors.New("unknown image format")
+
+// MaxPixels is the largest number of pixels of an image read by Decode, which keeps a corrupt or
+// hostile header from allocating a canvas far larger than the data that follows it
+const MaxPixels = 1 << 26
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Load reads a PPM, PNG, Radiance HDR or PFM image from a file, detecting the format from its contents
+func Load(path string) (*Canvas, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Decode(file)
+}
+
+// Decode reads a PPM, PNG, Radiance HDR or PFM image, detecting the format from its first bytes
+// PPM images are read as linear colors by ReadPPM
+func Decode(r io.Reader) (*Canvas, error) {
+	in := bufio.NewReader(r)
+	magic, err := in.Peek(len(pngSignature))
+	if err != nil && len(magic) < 2 {
+		return nil, ErrUnknownImageFormat
+	}
+	switch {
+	case bytes.Equal(magic, pngSignature):
+		return ReadPNG(in)
+	case magic[0] == 'P' && (magic[1] == '3' || magic[1] == '6'):
+		return ReadPPM(in)
+	case magic[0] == 'P' && (magic[1] == 'F' || magic[1] == 'f'):
+		return ReadPFM(in)
+	case magic[0] == '#' && magic[1] == '?':
+		return ReadHDR(in)
+	}
+	return nil, ErrUnknownImageFormat
+}
+
+// ReadPPM reads a plain (P3) or raw (P6) PPM image. Samples are divided by the maxval,
+// so the colors are linear as written by WritePPM without sRGB encoding; see ReadPPMSRGB for sRGB encoded images
+func ReadPPM(r io.Reader) (*Canvas, error) {
+	return readPPM(r, func(component float64) float64 { return component })
+}
+
+// ReadPPMSRGB reads a plain (P3) or raw (P6) PPM image with sRGB encoded samples, as written by WritePPM
+// with sRGB encoding or by most other programs, converting the samples to linear colors as ReadPNG does
+func ReadPPMSRGB(r io.Reader) (*Canvas, error) {
+	return readPPM(r, colors.SRGBToLinear)
+}
+
+func readPPM(r io.Reader, decode func(float64) float64) (*Canvas, error) {
+	in := bufio.NewReader(r)
+	magic, err := readPPMToken(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+	if magic != "P3" && magic != "P6" {
+		return nil, fmt.Errorf("%w: magic number %q", ErrInvalidPPM, magic)
+	}
+	width, err := readPPMInt(in, "width")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+	height, err := readPPMInt(in, "height")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+	maxVal, err := readPPMInt(in, "maxval")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+	if !validSize(width, height) {
+		return nil, fmt.Errorf("%w: size %dx%d", ErrInvalidPPM, width, height)
+	}
+	if maxVal < 1 || maxVal > 65535 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, ErrInvalidMaxVal)
+	}
+
+	var next func() (int, error)
+	if magic == "P3" {
+		next = func() (int, error) { return readPPMInt(in, "sample") }
+	} else {
+		next = rawSampleReader(in, maxVal > 255)
+	}
+
+	c := NewCanvas(width, height)
+	scale := 1 / float64(maxVal)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var rgb [3]float64
+			for i := range rgb {
+				sample, err := next()
+				if err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+				}
+				if sample > maxVal {
+					return nil, fmt.Errorf("%w: sample %d exceeds maxval %d", ErrInvalidPPM, sample, maxVal)
+				}
+				rgb[i] = decode(float64(sample) * scale)
+			}
+			c.Set(x, y, colors.NewColor(rgb[0], rgb[1], rgb[2]))
+		}
+	}
+	return c, nil
+}
+
+// ReadPNG reads a PNG image, converting the sRGB encoded samples to linear colors. Alpha is ignored
+func ReadPNG(r io.Reader) (*Canvas, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return FromImage(img), nil
+}
+
+// ReadPNGData reads a PNG image that holds data rather than colors, such as a normal map,
+// keeping the stored values. Alpha is ignored
+func ReadPNGData(r io.Reader) (*Canvas, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return fromImage(img, func(component float64) float64 { return component }), nil
+}
+
+// FromImage creates a Canvas from an sRGB encoded image, converting the samples to linear colors
+func FromImage(img image.Image) *Canvas {
+	return fromImage(img, colors.SRGBToLinear)
+}
+
+func fromImage(img image.Image, decode func(float64) float64) *Canvas {
+	bounds := img.Bounds()
+	c := NewCanvas(bounds.Dx(), bounds.Dy())
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			pixel := color.NRGBA64Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA64)
+			c.Set(x, y, colors.NewColor(
+				decode(float64(pixel.R)/0xffff),
+				decode(float64(pixel.G)/0xffff),
+				decode(float64(pixel.B)/0xffff),
+			))
+		}
+	}
+	return c
+}
+
+// readPPMToken skips whitespace and comments, and returns the next whitespace separated token.
+// The single whitespace character ending the token is consumed
+func readPPMToken(in *bufio.Reader) (string, error) {
+	var token []byte
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(token) > 0 {
+				return string(token), nil
+			}
+			return "", io.ErrUnexpectedEOF
+		}
+		switch {
+		case b == '#' && len(token) == 0:
+			if _, err := in.ReadBytes('\n'); err != nil && err != io.EOF {
+				return "", err
+			}
+		case isPPMWhitespace(b):
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, b)
+		}
+	}
+}
+
+// readPPMInt reads a non-negative integer token; the errors are wrapped by the callers
+// with the error of the format they read
+func readPPMInt(in *bufio.Reader, name string) (int, error) {
+	token, err := readPPMToken(in)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	value, err := strconv.Atoi(token)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s %q", name, token)
+	}
+	return value, nil
+}
+
+func rawSampleReader(in *bufio.Reader, wide bool) func() (int, error) {
+	buffer := make([]byte, 2)
+	if !wide {
+		buffer = buffer[:1]
+	}
+	return func() (int, error) {
+		if _, err := io.ReadFull(in, buffer); err != nil {
+			return 0, fmt.Errorf("sample: %w", io.ErrUnexpectedEOF)
+		}
+		if wide {
+			return int(buffer[0])<<8 | int(buffer[1]), nil
+		}
+		return int(buffer[0]), nil
+	}
+}
+
+// validSize checks if an image of the specified size has at least one and at most MaxPixels pixels
+func validSize(width, height int) bool {
+	return width >= 1 && height >= 1 && width <= MaxPixels/height
+}
+
+func isPPMWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
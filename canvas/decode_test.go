@@ -0,0 +1,440 @@
+package canvas
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: Reading a file with the wrong magic number
+// Given ppm ← a file containing:
+// """
+// P32
+// 1 1
+// 255
+// 0 0 0
+// """
+// Then canvas_from_ppm(ppm) should fail
+func Test_Reading_a_File_with_the_Wrong_Magic_Number(t *testing.T) {
+	// Given
+	ppm := "P32\n1 1\n255\n0 0 0\n"
+	// When
+	_, err := ReadPPM(strings.NewReader(ppm))
+	// Then
+	if !errors.Is(err, ErrInvalidPPM) {
+		t.Errorf("ReadPPM() returned %v, expected %v", err, ErrInvalidPPM)
+	}
+}
+
+// Scenario: Reading a PPM returns a canvas of the right size
+// Given ppm ← a file containing:
+// """
+// P3
+// 10 2
+// 255
+// 0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+// 0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+// 0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+// 0 0 0  0 0 0  0 0 0  0 0 0  0 0 0
+// """
+// When canvas ← canvas_from_ppm(ppm)
+// Then canvas.width = 10
+// And canvas.height = 2
+func Test_Reading_a_PPM_Returns_a_Canvas_of_the_Right_Size(t *testing.T) {
+	// Given
+	ppm := "P3\n10 2\n255\n" + strings.Repeat("0 0 0  0 0 0  0 0 0  0 0 0  0 0 0\n", 4)
+	// When
+	c, err := ReadPPM(strings.NewReader(ppm))
+	// Then
+	if err != nil {
+		t.Fatalf("ReadPPM() returned %v", err)
+	}
+	if c.Width != 10 || c.Height != 2 {
+		t.Errorf("canvas is %dx%d, expected 10x2", c.Width, c.Height)
+	}
+}
+
+// Scenario: Reading pixel data from a PPM file
+// Given ppm ← a file containing:
+// """
+// P3
+// 4 3
+// 255
+// 255 127 0  0 127 255  127 255 0  255 255 255
+// 0 0 0  255 0 0  0 255 0  0 0 255
+// 255 255 0  0 255 255  255 0 255  127 127 127
+// """
+// When canvas ← canvas_from_ppm(ppm)
+// Then pixel_at(canvas, x, y) should be <color>
+func Test_Reading_Pixel_Data_from_a_PPM_File(t *testing.T) {
+	// Given
+	ppm := "P3\n4 3\n255\n" +
+		"255 127 0  0 127 255  127 255 0  255 255 255\n" +
+		"0 0 0  255 0 0  0 255 0  0 0 255\n" +
+		"255 255 0  0 255 255  255 0 255  127 127 127\n"
+	// When
+	c, err := ReadPPM(strings.NewReader(ppm))
+	if err != nil {
+		t.Fatalf("ReadPPM() returned %v", err)
+	}
+	// Expected
+	half := 127.0 / 255
+	cases := []struct {
+		x, y   int
+		wanted colors.Color
+	}{
+		{0, 0, colors.NewColor(1, half, 0)},
+		{1, 0, colors.NewColor(0, half, 1)},
+		{2, 0, colors.NewColor(half, 1, 0)},
+		{3, 0, colors.NewColor(1, 1, 1)},
+		{0, 1, colors.NewColor(0, 0, 0)},
+		{1, 1, colors.NewColor(1, 0, 0)},
+		{2, 1, colors.NewColor(0, 1, 0)},
+		{3, 1, colors.NewColor(0, 0, 1)},
+		{0, 2, colors.NewColor(1, 1, 0)},
+		{1, 2, colors.NewColor(0, 1, 1)},
+		{2, 2, colors.NewColor(1, 0, 1)},
+		{3, 2, colors.NewColor(half, half, half)},
+	}
+	// Then
+	for _, tc := range cases {
+		if pixel := c.Get(tc.x, tc.y); !pixel.Equals(tc.wanted) {
+			t.Errorf("pixel (%d, %d) = %v, expected %v", tc.x, tc.y, pixel, tc.wanted)
+		}
+	}
+}
+
+// Scenario: PPM parsing ignores comment lines
+// Given ppm ← a file containing:
+// """
+// P3
+// # this is a comment
+// 2 1
+// # this, too
+// 255
+// # another comment
+// 255 255 255
+// # oh, no, comments in the pixel data!
+// 255 0 255
+// """
+// When canvas ← canvas_from_ppm(ppm)
+// Then pixel_at(canvas, 0, 0) = color(1, 1, 1)
+// And pixel_at(canvas, 1, 0) = color(1, 0, 1)
+func Test_PPM_Parsing_Ignores_Comment_Lines(t *testing.T) {
+	// Given
+	ppm := "P3\n# this is a comment\n2 1\n# this, too\n255\n# another comment\n255 255 255\n" +
+		"# oh, no, comments in the pixel data!\n255 0 255\n"
+	// When
+	c, err := ReadPPM(strings.NewReader(ppm))
+	if err != nil {
+		t.Fatalf("ReadPPM() returned %v", err)
+	}
+	// Then
+	if pixel := c.Get(0, 0); !pixel.Equals(colors.NewColor(1, 1, 1)) {
+		t.Errorf("pixel (0, 0) = %v, expected %v", pixel, colors.NewColor(1, 1, 1))
+	}
+	// And
+	if pixel := c.Get(1, 0); !pixel.Equals(colors.NewColor(1, 0, 1)) {
+		t.Errorf("pixel (1, 0) = %v, expected %v", pixel, colors.NewColor(1, 0, 1))
+	}
+}
+
+// Scenario: PPM parsing allows an RGB triple to span lines
+// Given ppm ← a file containing:
+// """
+// P3
+// 1 1
+// 255
+// 51
+// 153
+//
+// 204
+// """
+// When canvas ← canvas_from_ppm(ppm)
+// Then pixel_at(canvas, 0, 0) = color(0.2, 0.6, 0.8)
+func Test_PPM_Parsing_Allows_an_RGB_Triple_to_Span_Lines(t *testing.T) {
+	// Given
+	ppm := "P3\n1 1\n255\n51\n153\n\n204\n"
+	// When
+	c, err := ReadPPM(strings.NewReader(ppm))
+	if err != nil {
+		t.Fatalf("ReadPPM() returned %v", err)
+	}
+	// Then
+	if pixel := c.Get(0, 0); !pixel.Equals(colors.NewColor(0.2, 0.6, 0.8)) {
+		t.Errorf("pixel (0, 0) = %v, expected %v", pixel, colors.NewColor(0.2, 0.6, 0.8))
+	}
+}
+
+// Scenario: PPM parsing respects the scale setting
+// Given ppm ← a file containing:
+// """
+// P3
+// 2 2
+// 100
+// 100 100 100  50 50 50
+// 75 50 25  0 0 0
+// """
+// When canvas ← canvas_from_ppm(ppm)
+// Then pixel_at(canvas, 0, 1) = color(0.75, 0.5, 0.25)
+func Test_PPM_Parsing_Respects_the_Scale_Setting(t *testing.T) {
+	// Given
+	ppm := "P3\n2 2\n100\n100 100 100  50 50 50\n75 50 25  0 0 0\n"
+	// When
+	c, err := ReadPPM(strings.NewReader(ppm))
+	if err != nil {
+		t.Fatalf("ReadPPM() returned %v", err)
+	}
+	// Then
+	if pixel := c.Get(0, 1); !pixel.Equals(colors.NewColor(0.75, 0.5, 0.25)) {
+		t.Errorf("pixel (0, 1) = %v, expected %v", pixel, colors.NewColor(0.75, 0.5, 0.25))
+	}
+}
+
+// Scenario: PPM parsing rejects samples above the maxval
+// Given ppm ← a file containing:
+// """
+// P3
+// 1 1
+// 100
+// 100 101 100
+// """
+// Then canvas_from_ppm(ppm) should fail
+func Test_PPM_Parsing_Rejects_Samples_Above_the_Maxval(t *testing.T) {
+	// Given
+	ppm := "P3\n1 1\n100\n100 101 100\n"
+	// When
+	_, err := ReadPPM(strings.NewReader(ppm))
+	// Then
+	if !errors.Is(err, ErrInvalidPPM) {
+		t.Errorf("ReadPPM() returned %v, expected %v", err, ErrInvalidPPM)
+	}
+}
+
+// Scenario: Reading a truncated PPM file fails
+// Given plain ← a P3 file of 2x1 pixels that ends after the fourth sample
+// And raw ← a P6 file of 2x1 pixels that ends after the fourth sample
+// And header ← a P3 file that ends after its width
+// Then canvas_from_ppm(plain) should fail with ErrInvalidPPM
+// And canvas_from_ppm(raw) should fail with ErrInvalidPPM
+// And canvas_from_ppm(header) should fail with ErrInvalidPPM
+func Test_Reading_a_Truncated_PPM_File_Fails(t *testing.T) {
+	// Given
+	plain := "P3\n2 1\n255\n1 2 3 4"
+	// And
+	raw := "P6\n2 1\n255\n\x01\x02\x03\x04"
+	// And
+	header := "P3\n2"
+	// Then
+	for _, ppm := range []string{plain, raw, header} {
+		if _, err := ReadPPM(strings.NewReader(ppm)); !errors.Is(err, ErrInvalidPPM) {
+			t.Errorf("ReadPPM(%q) returned %v, expected %v", ppm, err, ErrInvalidPPM)
+		}
+	}
+}
+
+// Scenario: Reading a truncated PFM file fails
+// Given header ← a PF file that ends after its size
+// And data ← a PF file of 1x1 pixels that ends after the first float
+// Then canvas_from_pfm(header) should fail with ErrInvalidPFM
+// And canvas_from_pfm(data) should fail with ErrInvalidPFM
+func Test_Reading_a_Truncated_PFM_File_Fails(t *testing.T) {
+	// Given
+	header := "PF\n1 1\n"
+	// And
+	data := "PF\n1 1\n-1.0\n\x00\x00\x80\x3f"
+	// Then
+	for _, pfm := range []string{header, data} {
+		if _, err := ReadPFM(strings.NewReader(pfm)); !errors.Is(err, ErrInvalidPFM) {
+			t.Errorf("ReadPFM(%q) returned %v, expected %v", pfm, err, ErrInvalidPFM)
+		}
+	}
+}
+
+// Scenario: Reading an image with a header that is too large fails
+// Given ppm ← a P6 file of 100000x100000 pixels with only a few bytes of data
+// And pfm ← a PF file of 100000x100000 pixels with only a few bytes of data
+// And hdr ← a Radiance HDR file of 100000x100000 pixels with only a few bytes of data
+// Then canvas_from_ppm(ppm) should fail
+// And canvas_from_pfm(pfm) should fail
+// And canvas_from_hdr(hdr) should fail
+func Test_Reading_an_Image_with_a_Header_that_is_too_Large_Fails(t *testing.T) {
+	// Given
+	ppm := "P6\n100000 100000\n255\n\x00\x00\x00"
+	// And
+	pfm := "PF\n100000 100000\n-1.0\n\x00\x00\x00\x00"
+	// And
+	hdr := "#?RADIANCE\n\n-Y 100000 +X 100000\n\x80\x80\x80\x81"
+	// Then
+	if _, err := ReadPPM(strings.NewReader(ppm)); !errors.Is(err, ErrInvalidPPM) {
+		t.Errorf("ReadPPM() returned %v, expected %v", err, ErrInvalidPPM)
+	}
+	// And
+	if _, err := ReadPFM(strings.NewReader(pfm)); !errors.Is(err, ErrInvalidPFM) {
+		t.Errorf("ReadPFM() returned %v, expected %v", err, ErrInvalidPFM)
+	}
+	// And
+	if _, err := ReadHDR(strings.NewReader(hdr)); !errors.Is(err, ErrInvalidHDR) {
+		t.Errorf("ReadHDR() returned %v, expected %v", err, ErrInvalidHDR)
+	}
+}
+
+// Scenario: Binary PPM files survive a round trip
+// Given c ← canvas(3, 2) with distinct pixels
+// When c is written as P6 with maxval <maxval>
+// And canvas ← canvas_from_ppm(the written data)
+// Then every pixel of canvas is within one sample of c
+func Test_Binary_PPM_Files_Survive_a_Round_Trip(t *testing.T) {
+	for _, maxVal := range []int{255, 65535} {
+		// Given
+		c := NewCanvas(3, 2)
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 3; x++ {
+				c.Set(x, y, colors.NewColor(float64(x)/2, float64(y), 0.3))
+			}
+		}
+		// When
+		var buffer bytes.Buffer
+		if err := c.WritePPM(&buffer, PPMOptions{Format: P6, MaxVal: maxVal}); err != nil {
+			t.Fatalf("WritePPM() returned %v", err)
+		}
+		// And
+		read, err := Decode(&buffer)
+		if err != nil {
+			t.Fatalf("Decode() returned %v", err)
+		}
+		// Then
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 3; x++ {
+				diff := read.Get(x, y).Subtract(c.Get(x, y))
+				limit := 1 / float64(maxVal)
+				if abs(diff.Red) > limit || abs(diff.Green) > limit || abs(diff.Blue) > limit {
+					t.Errorf("maxval %d: pixel (%d, %d) = %v, expected %v", maxVal, x, y, read.Get(x, y), c.Get(x, y))
+				}
+			}
+		}
+	}
+}
+
+// Scenario: Reading an sRGB encoded PPM converts it to linear colors
+// Given ppm ← a file containing:
+// """
+// P3
+// 2 1
+// 255
+// 255 0 188  0 0 0
+// """
+// When canvas ← canvas_from_srgb_ppm(ppm)
+// Then pixel_at(canvas, 0, 0) = color(1, 0, 0.5029), as for the same PNG
+// And pixel_at(canvas, 1, 0) = color(0, 0, 0)
+func Test_Reading_an_sRGB_Encoded_PPM_Converts_it_to_Linear_Colors(t *testing.T) {
+	// Given
+	ppm := "P3\n2 1\n255\n255 0 188  0 0 0\n"
+	// When
+	c, err := ReadPPMSRGB(strings.NewReader(ppm))
+	if err != nil {
+		t.Fatalf("ReadPPMSRGB() returned %v", err)
+	}
+	// Expected
+	wanted := colors.NewColor(1, 0, 0.50289)
+	// Then
+	if pixel := c.Get(0, 0); abs(pixel.Red-wanted.Red) > 1e-4 || pixel.Green != 0 || abs(pixel.Blue-wanted.Blue) > 1e-4 {
+		t.Errorf("pixel (0, 0) = %v, expected %v", pixel, wanted)
+	}
+	// And
+	if pixel := c.Get(1, 0); !pixel.Equals(colors.Black()) {
+		t.Errorf("pixel (1, 0) = %v, expected %v", pixel, colors.Black())
+	}
+}
+
+// Scenario: sRGB encoded PPM files survive a round trip
+// Given c ← canvas(3, 1) with pixels color(0.18, 0.18, 0.18), color(0.5, 0.05, 0.9) and color(1, 1, 1)
+// When c is written as P6 with maxval 65535 and sRGB encoding
+// And canvas ← canvas_from_srgb_ppm(the written data)
+// Then every pixel of canvas is within 0.0001 of c
+func Test_sRGB_Encoded_PPM_Files_Survive_a_Round_Trip(t *testing.T) {
+	// Given
+	c := NewCanvas(3, 1)
+	c.Set(0, 0, colors.NewColor(0.18, 0.18, 0.18))
+	c.Set(1, 0, colors.NewColor(0.5, 0.05, 0.9))
+	c.Set(2, 0, colors.NewColor(1, 1, 1))
+	// When
+	var buffer bytes.Buffer
+	options := PPMOptions{Format: P6, MaxVal: 65535, ToneMapping: colors.ToneMapping{SRGB: true}}
+	if err := c.WritePPM(&buffer, options); err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	// And
+	read, err := ReadPPMSRGB(&buffer)
+	if err != nil {
+		t.Fatalf("ReadPPMSRGB() returned %v", err)
+	}
+	// Then
+	for x := 0; x < 3; x++ {
+		diff := read.Get(x, 0).Subtract(c.Get(x, 0))
+		if abs(diff.Red) > 1e-4 || abs(diff.Green) > 1e-4 || abs(diff.Blue) > 1e-4 {
+			t.Errorf("pixel (%d, 0) = %v, expected %v", x, read.Get(x, 0), c.Get(x, 0))
+		}
+	}
+}
+
+// Scenario: Reading a PNG converts sRGB to linear colors
+// Given img ← a 2x1 PNG with pixels rgb(255, 0, 188) and rgb(0, 0, 0)
+// When canvas ← canvas_from_png(img)
+// Then pixel_at(canvas, 0, 0) = color(1, 0, 0.5029)
+// And pixel_at(canvas, 1, 0) = color(0, 0, 0)
+func Test_Reading_a_PNG_Converts_sRGB_to_Linear_Colors(t *testing.T) {
+	// Given
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, G: 0, B: 188, A: 255})
+	img.Set(1, 0, color.NRGBA{R: 0, G: 0, B: 0, A: 255})
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatalf("png.Encode() returned %v", err)
+	}
+	// When
+	c, err := Decode(&buffer)
+	if err != nil {
+		t.Fatalf("Decode() returned %v", err)
+	}
+	// Expected
+	wanted := colors.NewColor(1, 0, 0.50289)
+	// Then
+	if c.Width != 2 || c.Height != 1 {
+		t.Fatalf("canvas is %dx%d, expected 2x1", c.Width, c.Height)
+	}
+	if pixel := c.Get(0, 0); abs(pixel.Red-wanted.Red) > 1e-4 || pixel.Green != 0 || abs(pixel.Blue-wanted.Blue) > 1e-4 {
+		t.Errorf("pixel (0, 0) = %v, expected %v", pixel, wanted)
+	}
+	// And
+	if pixel := c.Get(1, 0); !pixel.Equals(colors.Black()) {
+		t.Errorf("pixel (1, 0) = %v, expected %v", pixel, colors.Black())
+	}
+}
+
+// Scenario: Decoding data in an unknown format fails
+// Given data ← "GIF89a"
+// Then decode(data) fails with an unknown image format
+func Test_Decoding_Data_in_an_Unknown_Format_Fails(t *testing.T) {
+	// Given
+	data := "GIF89a"
+	// When
+	_, err := Decode(strings.NewReader(data))
+	// Then
+	if err != ErrUnknownImageFormat {
+		t.Errorf("Decode() returned %v, expected %v", err, ErrUnknownImageFormat)
+	}
+}
+
+func abs(value float64) float64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// ErrSizeMismatch is returned when two canvases of different sizes are compared
+var ErrSizeMismatch = errors.New("canvas sizes do not match")
+
+// Difference describes how much two canvases differ
+// Image holds the absolute difference of every component, MaxError the largest of them,
+// RMSE the root mean square error over all components, and PSNR the peak signal to noise ratio
+// in decibels for a peak of 1, which is +Inf for identical canvases
+type Difference struct {
+	Image    *Canvas
+	MaxError float64
+	RMSE     float64
+	PSNR     float64
+}
+
+// Diff compares two canvases of the same size, pixel by pixel
+func Diff(a, b *Canvas) (*Difference, error) {
+	if a.Width != b.Width || a.Height != b.Height {
+		return nil, fmt.Errorf("%w: %dx%d and %dx%d", ErrSizeMismatch, a.Width, a.Height, b.Width, b.Height)
+	}
+	d := Difference{Image: NewCanvas(a.Width, a.Height)}
+	sumSq := 0.0
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			diff := a.Get(x, y).Subtract(b.Get(x, y))
+			abs := colors.NewColor(math.Abs(diff.Red), math.Abs(diff.Green), math.Abs(diff.Blue))
+			d.Image.Set(x, y, abs)
+			d.MaxError = math.Max(d.MaxError, math.Max(abs.Red, math.Max(abs.Green, abs.Blue)))
+			sumSq += abs.Red*abs.Red + abs.Green*abs.Green + abs.Blue*abs.Blue
+		}
+	}
+	if n := 3 * a.Width * a.Height; n > 0 {
+		d.RMSE = math.Sqrt(sumSq / float64(n))
+	}
+	d.PSNR = math.Inf(1)
+	if d.RMSE > 0 {
+		d.PSNR = -20 * math.Log10(d.RMSE)
+	}
+	return &d, nil
+}
+
+// String formats the Difference as a string
+func (d Difference) String() string {
+	return fmt.Sprintf("Difference( max %g, RMSE %g, PSNR %.2f dB )", d.MaxError, d.RMSE, d.PSNR)
+}
+
+// Heatmap shows the largest component difference of every pixel, running from black through red
+// and yellow to white at scale. A scale of 0 or less uses MaxError, so the worst pixel is white
+func (d Difference) Heatmap(scale float64) *Canvas {
+	if scale <= 0 {
+		scale = d.MaxError
+	}
+	result := NewCanvas(d.Image.Width, d.Image.Height)
+	if scale <= 0 {
+		return result
+	}
+	for y := 0; y < result.Height; y++ {
+		for x := 0; x < result.Width; x++ {
+			abs := d.Image.Get(x, y)
+			heat := 3 * math.Min(math.Max(abs.Red, math.Max(abs.Green, abs.Blue))/scale, 1)
+			result.Set(x, y, colors.NewColor(
+				math.Min(heat, 1),
+				math.Min(math.Max(heat-1, 0), 1),
+				math.Max(heat-2, 0),
+			))
+		}
+	}
+	return result
+}
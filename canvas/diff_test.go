@@ -0,0 +1,121 @@
+package canvas
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: Comparing two canvases
+// Given a ← canvas(2, 1)
+// And b ← canvas(2, 1)
+// And write_pixel(b, 1, 0, color(0.5, 0, -0.25))
+// When d ← diff(a, b)
+// Then pixel_at(d.image, 1, 0) = color(0.5, 0, 0.25)
+// And d.max_error = 0.5
+// And d.rmse = √(0.3125 / 6)
+// And d.psnr = -20 log10(d.rmse)
+func Test_Comparing_Two_Canvases(t *testing.T) {
+	// Given
+	a := NewCanvas(2, 1)
+	// And
+	b := NewCanvas(2, 1)
+	// And
+	b.Set(1, 0, colors.NewColor(0.5, 0, -0.25))
+	// When
+	d, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Diff() returned %v", err)
+	}
+	// Expected
+	wantedRMSE := math.Sqrt(0.3125 / 6)
+	// Then
+	if pixel := d.Image.Get(1, 0); !pixel.Equals(colors.NewColor(0.5, 0, 0.25)) {
+		t.Errorf("difference pixel (1, 0) = %v, expected %v", pixel, colors.NewColor(0.5, 0, 0.25))
+	}
+	// And
+	if d.MaxError != 0.5 {
+		t.Errorf("%v has max error %g, expected 0.5", d, d.MaxError)
+	}
+	// And
+	if math.Abs(d.RMSE-wantedRMSE) > 1e-12 {
+		t.Errorf("%v has RMSE %g, expected %g", d, d.RMSE, wantedRMSE)
+	}
+	// And
+	if wanted := -20 * math.Log10(wantedRMSE); math.Abs(d.PSNR-wanted) > 1e-9 {
+		t.Errorf("%v has PSNR %g, expected %g", d, d.PSNR, wanted)
+	}
+}
+
+// Scenario: Identical canvases have an infinite PSNR
+// Given a ← canvas(3, 3)
+// When d ← diff(a, a)
+// Then d.rmse = 0
+// And d.psnr = ∞
+func Test_Identical_Canvases_Have_an_Infinite_PSNR(t *testing.T) {
+	// Given
+	a := NewCanvas(3, 3)
+	// When
+	d, err := Diff(a, a)
+	if err != nil {
+		t.Fatalf("Diff() returned %v", err)
+	}
+	// Then
+	if d.RMSE != 0 {
+		t.Errorf("%v has RMSE %g, expected 0", d, d.RMSE)
+	}
+	// And
+	if !math.IsInf(d.PSNR, 1) {
+		t.Errorf("%v has PSNR %g, expected +Inf", d, d.PSNR)
+	}
+}
+
+// Scenario: Canvases of different sizes cannot be compared
+// Given a ← canvas(3, 3)
+// And b ← canvas(3, 2)
+// Then diff(a, b) fails
+func Test_Canvases_of_Different_Sizes_Cannot_be_Compared(t *testing.T) {
+	// Given
+	a := NewCanvas(3, 3)
+	// And
+	b := NewCanvas(3, 2)
+	// When
+	_, err := Diff(a, b)
+	// Then
+	if !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("Diff() returned %v, expected %v", err, ErrSizeMismatch)
+	}
+}
+
+// Scenario: The heatmap of a difference
+// Given a ← canvas(3, 1)
+// And b ← canvas(3, 1)
+// And write_pixel(b, 1, 0, color(0.2, 0, 0))
+// And write_pixel(b, 2, 0, color(0, 0, 0.6))
+// When h ← heatmap(diff(a, b), 0)
+// Then pixel_at(h, 0, 0) = color(0, 0, 0)
+// And pixel_at(h, 1, 0) = color(1, 0, 0)
+// And pixel_at(h, 2, 0) = color(1, 1, 1)
+func Test_the_Heatmap_of_a_Difference(t *testing.T) {
+	// Given
+	a := NewCanvas(3, 1)
+	// And
+	b := NewCanvas(3, 1)
+	// And
+	b.Set(1, 0, colors.NewColor(0.2, 0, 0))
+	b.Set(2, 0, colors.NewColor(0, 0, 0.6))
+	// When
+	d, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Diff() returned %v", err)
+	}
+	h := d.Heatmap(0)
+	// Then
+	for x, wanted := range []colors.Color{colors.NewColor(0, 0, 0), colors.NewColor(1, 0, 0), colors.NewColor(1, 1, 1)} {
+		if pixel := h.Get(x, 0); !pixel.Equals(wanted) {
+			t.Errorf("heatmap pixel (%d, 0) = %v, expected %v", x, pixel, wanted)
+		}
+	}
+}
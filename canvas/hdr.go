@@ -0,0 +1,179 @@
+package canvas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// ErrInvalidHDR is returned when Radiance HDR data cannot be parsed
+var ErrInvalidHDR = errors.New("invalid Radiance HDR data")
+
+const (
+	// minRLEWidth and maxRLEWidth bound the scanline widths that may use run length encoding
+	minRLEWidth = 8
+	maxRLEWidth = 0x7fff
+)
+
+// WriteHDR writes the Canvas to w as a Radiance RGBE image, keeping colors above 1.
+// Negative components are stored as 0
+func (c Canvas) WriteHDR(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(out, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %d +X %d\n", c.Height, c.Width); err != nil {
+		return err
+	}
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			rgbe := toRGBE(c.Get(x, y))
+			if _, err := out.Write(rgbe[:]); err != nil {
+				return err
+			}
+		}
+	}
+	return out.Flush()
+}
+
+// SaveHDR writes the Canvas to a Radiance RGBE file at the given path
+func (c Canvas) SaveHDR(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := c.WriteHDR(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadHDR reads a Radiance RGBE image with a -Y height +X width orientation,
+// stored either flat or with the run length encoding of newer Radiance versions
+func ReadHDR(r io.Reader) (*Canvas, error) {
+	in := bufio.NewReader(r)
+	width, height, err := readHDRHeader(in)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCanvas(width, height)
+	scanline := make([][4]byte, width)
+	for y := 0; y < height; y++ {
+		if err := readHDRScanline(in, scanline); err != nil {
+			return nil, err
+		}
+		for x, rgbe := range scanline {
+			c.Set(x, y, fromRGBE(rgbe))
+		}
+	}
+	return c, nil
+}
+
+func readHDRHeader(in *bufio.Reader) (int, int, error) {
+	first, err := in.ReadString('\n')
+	if err != nil || !strings.HasPrefix(first, "#?") {
+		return 0, 0, fmt.Errorf("%w: missing #? signature", ErrInvalidHDR)
+	}
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: unterminated header", ErrInvalidHDR)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return 0, 0, fmt.Errorf("%w: unsupported %s", ErrInvalidHDR, line)
+		}
+	}
+	resolution, err := in.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: missing resolution", ErrInvalidHDR)
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(resolution, "-Y %d +X %d", &height, &width); err != nil || !validSize(width, height) {
+		return 0, 0, fmt.Errorf("%w: unsupported resolution %q", ErrInvalidHDR, strings.TrimSpace(resolution))
+	}
+	return width, height, nil
+}
+
+func readHDRScanline(in *bufio.Reader, scanline [][4]byte) error {
+	width := len(scanline)
+	start, err := in.Peek(4)
+	if err != nil {
+		return fmt.Errorf("%w: unexpected end of data", ErrInvalidHDR)
+	}
+	if width < minRLEWidth || width > maxRLEWidth || start[0] != 2 || start[1] != 2 || start[2]&0x80 != 0 {
+		for x := range scanline {
+			if _, err := io.ReadFull(in, scanline[x][:]); err != nil {
+				return fmt.Errorf("%w: unexpected end of data", ErrInvalidHDR)
+			}
+		}
+		return nil
+	}
+	in.Discard(4)
+	if int(start[2])<<8|int(start[3]) != width {
+		return fmt.Errorf("%w: scanline width mismatch", ErrInvalidHDR)
+	}
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < width; {
+			count, err := in.ReadByte()
+			if err != nil {
+				return fmt.Errorf("%w: unexpected end of data", ErrInvalidHDR)
+			}
+			run := int(count)
+			isRun := run > 128
+			if isRun {
+				run -= 128
+			}
+			if run == 0 || x+run > width {
+				return fmt.Errorf("%w: bad run length", ErrInvalidHDR)
+			}
+			var value byte
+			if isRun {
+				if value, err = in.ReadByte(); err != nil {
+					return fmt.Errorf("%w: unexpected end of data", ErrInvalidHDR)
+				}
+			}
+			for ; run > 0; run-- {
+				if !isRun {
+					if value, err = in.ReadByte(); err != nil {
+						return fmt.Errorf("%w: unexpected end of data", ErrInvalidHDR)
+					}
+				}
+				scanline[x][channel] = value
+				x++
+			}
+		}
+	}
+	return nil
+}
+
+// toRGBE encodes a color as three mantissas sharing the exponent of the largest component
+func toRGBE(color colors.Color) [4]byte {
+	red, green, blue := math.Max(color.Red, 0), math.Max(color.Green, 0), math.Max(color.Blue, 0)
+	largest := math.Max(red, math.Max(green, blue))
+	if largest < 1e-32 {
+		return [4]byte{}
+	}
+	mantissa, exponent := math.Frexp(largest)
+	scale := mantissa * 256 / largest
+	return [4]byte{byte(red * scale), byte(green * scale), byte(blue * scale), byte(exponent + 128)}
+}
+
+func fromRGBE(rgbe [4]byte) colors.Color {
+	if rgbe[3] == 0 {
+		return colors.Black()
+	}
+	scale := math.Ldexp(1, int(rgbe[3])-(128+8))
+	return colors.NewColor(
+		float64(rgbe[0])*scale,
+		float64(rgbe[1])*scale,
+		float64(rgbe[2])*scale,
+	)
+}
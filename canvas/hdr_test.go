@@ -0,0 +1,110 @@
+package canvas
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: Radiance HDR files keep colors above 1
+// Given c ← canvas(2, 2)
+// And write_pixel(c, 0, 0, color(1, 0, 0))
+// And write_pixel(c, 1, 0, color(12.5, 3, 0.25))
+// And write_pixel(c, 0, 1, color(1000, 500, 0))
+// When c is written as Radiance HDR
+// And canvas ← canvas_from_hdr(the written data)
+// Then every pixel of canvas is within 1% of c
+// And the header starts with "#?RADIANCE"
+func Test_Radiance_HDR_Files_Keep_Colors_Above_1(t *testing.T) {
+	// Given
+	c := NewCanvas(2, 2)
+	// And
+	c.Set(0, 0, colors.NewColor(1, 0, 0))
+	c.Set(1, 0, colors.NewColor(12.5, 3, 0.25))
+	c.Set(0, 1, colors.NewColor(1000, 500, 0))
+	// When
+	var buffer bytes.Buffer
+	if err := c.WriteHDR(&buffer); err != nil {
+		t.Fatalf("WriteHDR() returned %v", err)
+	}
+	written := buffer.String()
+	// And
+	read, err := Decode(&buffer)
+	if err != nil {
+		t.Fatalf("Decode() returned %v", err)
+	}
+	// Then
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			wanted, pixel := c.Get(x, y), read.Get(x, y)
+			limit := 0.01 * wanted.Red
+			if wanted.Green > wanted.Red {
+				limit = 0.01 * wanted.Green
+			}
+			diff := pixel.Subtract(wanted)
+			if abs(diff.Red) > limit || abs(diff.Green) > limit || abs(diff.Blue) > limit {
+				t.Errorf("pixel (%d, %d) = %v, expected %v", x, y, pixel, wanted)
+			}
+		}
+	}
+	// And
+	if !strings.HasPrefix(written, "#?RADIANCE\n") {
+		t.Errorf("header %q does not start with #?RADIANCE", written[:12])
+	}
+}
+
+// Scenario: Reading a run length encoded Radiance HDR file
+// Given hdr ← a header for 8x1 pixels followed by the scanline start 2 2 0 8
+// And the red runs: literal 3 (128 64 32) and a run of 5 × 0
+// And the green runs: a run of 8 × 128
+// And the blue runs: literal 8 (0 1 2 3 4 5 6 7)
+// And the exponent runs: a run of 8 × 129
+// When canvas ← canvas_from_hdr(hdr)
+// Then pixel_at(canvas, 0, 0) = color(1, 1, 0)
+// And pixel_at(canvas, 2, 0) = color(0.25, 1, 2 / 128)
+// And pixel_at(canvas, 7, 0) = color(0, 1, 7 / 128)
+func Test_Reading_a_Run_Length_Encoded_Radiance_HDR_File(t *testing.T) {
+	// Given
+	hdr := []byte("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 8\n")
+	hdr = append(hdr, 2, 2, 0, 8)
+	hdr = append(hdr, 3, 128, 64, 32, 128+5, 0)
+	hdr = append(hdr, 128+8, 128)
+	hdr = append(hdr, 8, 0, 1, 2, 3, 4, 5, 6, 7)
+	hdr = append(hdr, 128+8, 129)
+	// When
+	c, err := ReadHDR(bytes.NewReader(hdr))
+	if err != nil {
+		t.Fatalf("ReadHDR() returned %v", err)
+	}
+	// Then
+	cases := []struct {
+		x      int
+		wanted colors.Color
+	}{
+		{0, colors.NewColor(1, 1, 0)},
+		{2, colors.NewColor(0.25, 1, 2.0/128)},
+		{7, colors.NewColor(0, 1, 7.0/128)},
+	}
+	for _, tc := range cases {
+		if pixel := c.Get(tc.x, 0); !pixel.Equals(tc.wanted) {
+			t.Errorf("pixel (%d, 0) = %v, expected %v", tc.x, pixel, tc.wanted)
+		}
+	}
+}
+
+// Scenario: Reading a Radiance HDR file with an unsupported orientation
+// Given hdr ← a header with the resolution "+Y 1 +X 1"
+// Then canvas_from_hdr(hdr) should fail
+func Test_Reading_a_Radiance_HDR_File_with_an_Unsupported_Orientation(t *testing.T) {
+	// Given
+	hdr := "#?RADIANCE\n\n+Y 1 +X 1\n\x80\x80\x80\x81"
+	// When
+	_, err := ReadHDR(strings.NewReader(hdr))
+	// Then
+	if !errors.Is(err, ErrInvalidHDR) {
+		t.Errorf("ReadHDR() returned %v, expected %v", err, ErrInvalidHDR)
+	}
+}
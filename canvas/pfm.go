@@ -0,0 +1,110 @@
+package canvas
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// ErrInvalidPFM is returned when Portable Float Map data cannot be parsed
+var ErrInvalidPFM = errors.New("invalid PFM data")
+
+// WritePFM writes the Canvas to w as a little-endian Portable Float Map, keeping the colors unclamped.
+// As the format requires, the rows are stored from the bottom of the image to the top
+func (c Canvas) WritePFM(w io.Writer) error {
+	out := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(out, "PF\n%d %d\n-1.0\n", c.Width, c.Height); err != nil {
+		return err
+	}
+	sample := make([]byte, 4)
+	for y := c.Height - 1; y >= 0; y-- {
+		for x := 0; x < c.Width; x++ {
+			color := c.Get(x, y)
+			for _, component := range [3]float64{color.Red, color.Green, color.Blue} {
+				binary.LittleEndian.PutUint32(sample, math.Float32bits(float32(component)))
+				if _, err := out.Write(sample); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return out.Flush()
+}
+
+// SavePFM writes the Canvas to a Portable Float Map file at the given path
+func (c Canvas) SavePFM(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := c.WritePFM(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadPFM reads a color (PF) or greyscale (Pf) Portable Float Map in either byte order
+func ReadPFM(r io.Reader) (*Canvas, error) {
+	in := bufio.NewReader(r)
+	magic, err := readPPMToken(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPFM, err)
+	}
+	var channels int
+	switch magic {
+	case "PF":
+		channels = 3
+	case "Pf":
+		channels = 1
+	default:
+		return nil, fmt.Errorf("%w: magic number %q", ErrInvalidPFM, magic)
+	}
+	width, err := readPPMInt(in, "width")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPFM, err)
+	}
+	height, err := readPPMInt(in, "height")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPFM, err)
+	}
+	if !validSize(width, height) {
+		return nil, fmt.Errorf("%w: size %dx%d", ErrInvalidPFM, width, height)
+	}
+	scaleToken, err := readPPMToken(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPFM, err)
+	}
+	scale, err := strconv.ParseFloat(scaleToken, 64)
+	if err != nil || scale == 0 {
+		return nil, fmt.Errorf("%w: scale %q", ErrInvalidPFM, scaleToken)
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		order = binary.LittleEndian
+	}
+
+	c := NewCanvas(width, height)
+	row := make([]byte, 4*channels*width)
+	for y := height - 1; y >= 0; y-- {
+		if _, err := io.ReadFull(in, row); err != nil {
+			return nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidPFM)
+		}
+		for x := 0; x < width; x++ {
+			var rgb [3]float64
+			for i := range rgb {
+				offset := 4 * (x*channels + i%channels)
+				rgb[i] = float64(math.Float32frombits(order.Uint32(row[offset:])))
+			}
+			c.Set(x, y, colors.NewColor(rgb[0], rgb[1], rgb[2]))
+		}
+	}
+	return c, nil
+}
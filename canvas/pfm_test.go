@@ -0,0 +1,103 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: PFM files keep unclamped colors exactly
+// Given c ← canvas(2, 3)
+// And write_pixel(c, 1, 0, color(-0.5, 17.25, 1e6))
+// And write_pixel(c, 0, 2, color(0.125, 0, 3))
+// When c is written as PFM
+// And canvas ← canvas_from_pfm(the written data)
+// Then canvas = c
+func Test_PFM_Files_Keep_Unclamped_Colors_Exactly(t *testing.T) {
+	// Given
+	c := NewCanvas(2, 3)
+	// And
+	c.Set(1, 0, colors.NewColor(-0.5, 17.25, 1e6))
+	c.Set(0, 2, colors.NewColor(0.125, 0, 3))
+	// When
+	var buffer bytes.Buffer
+	if err := c.WritePFM(&buffer); err != nil {
+		t.Fatalf("WritePFM() returned %v", err)
+	}
+	// And
+	read, err := Decode(&buffer)
+	if err != nil {
+		t.Fatalf("Decode() returned %v", err)
+	}
+	// Then
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 2; x++ {
+			if read.Get(x, y) != c.Get(x, y) {
+				t.Errorf("pixel (%d, %d) = %v, expected %v", x, y, read.Get(x, y), c.Get(x, y))
+			}
+		}
+	}
+}
+
+// Scenario: PFM rows are stored bottom to top
+// Given c ← canvas(1, 2)
+// And write_pixel(c, 0, 0, color(1, 2, 3))
+// When c is written as PFM
+// Then the header is "PF\n1 2\n-1.0\n"
+// And the first sample is 0
+// And the fourth sample is 1
+func Test_PFM_Rows_Are_Stored_Bottom_to_Top(t *testing.T) {
+	// Given
+	c := NewCanvas(1, 2)
+	// And
+	c.Set(0, 0, colors.NewColor(1, 2, 3))
+	// When
+	var buffer bytes.Buffer
+	if err := c.WritePFM(&buffer); err != nil {
+		t.Fatalf("WritePFM() returned %v", err)
+	}
+	data := buffer.Bytes()
+	// Expected
+	header := "PF\n1 2\n-1.0\n"
+	// Then
+	if string(data[:len(header)]) != header {
+		t.Fatalf("header = %q, expected %q", data[:len(header)], header)
+	}
+	samples := data[len(header):]
+	// And
+	if first := math.Float32frombits(binary.LittleEndian.Uint32(samples)); first != 0 {
+		t.Errorf("first sample = %g, expected 0", first)
+	}
+	// And
+	if fourth := math.Float32frombits(binary.LittleEndian.Uint32(samples[12:])); fourth != 1 {
+		t.Errorf("fourth sample = %g, expected 1", fourth)
+	}
+}
+
+// Scenario: Reading a big-endian greyscale PFM
+// Given pfm ← "Pf\n2 1\n1.0\n" followed by the big-endian floats 0.5 and 4
+// When canvas ← canvas_from_pfm(pfm)
+// Then pixel_at(canvas, 0, 0) = color(0.5, 0.5, 0.5)
+// And pixel_at(canvas, 1, 0) = color(4, 4, 4)
+func Test_Reading_a_Big_Endian_Greyscale_PFM(t *testing.T) {
+	// Given
+	pfm := []byte("Pf\n2 1\n1.0\n")
+	pfm = binary.BigEndian.AppendUint32(pfm, math.Float32bits(0.5))
+	pfm = binary.BigEndian.AppendUint32(pfm, math.Float32bits(4))
+	// When
+	c, err := ReadPFM(bytes.NewReader(pfm))
+	if err != nil {
+		t.Fatalf("ReadPFM() returned %v", err)
+	}
+	// Then
+	if pixel := c.Get(0, 0); !pixel.Equals(colors.NewColor(0.5, 0.5, 0.5)) {
+		t.Errorf("pixel (0, 0) = %v, expected %v", pixel, colors.NewColor(0.5, 0.5, 0.5))
+	}
+	// And
+	if pixel := c.Get(1, 0); !pixel.Equals(colors.NewColor(4, 4, 4)) {
+		t.Errorf("pixel (1, 0) = %v, expected %v", pixel, colors.NewColor(4, 4, 4))
+	}
+}
@@ -0,0 +1,163 @@
+package canvas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// PPMFormat selects the flavour of PPM written by the encoder
+type PPMFormat int
+
+const (
+	// P3 is the plain PPM format, with the samples written as ASCII decimals
+	P3 PPMFormat = iota
+	// P6 is the raw PPM format, with the samples written as binary bytes
+	P6
+)
+
+// DefaultMaxVal is the maximum sample value used when none is specified
+const DefaultMaxVal = 255
+
+// maxPlainLineLength is the maximum length of a line in a plain PPM file
+const maxPlainLineLength = 70
+
+// ErrInvalidMaxVal is returned when the maximum sample value is outside 1..65535
+var ErrInvalidMaxVal = errors.New("PPM maxval must be between 1 and 65535")
+
+// ErrInvalidPPMFormat is returned for an unknown PPM format
+var ErrInvalidPPMFormat = errors.New("unknown PPM format")
+
+// PPMOptions configures how a Canvas is encoded as PPM
+type PPMOptions struct {
+	Format PPMFormat
+	// MaxVal is the maximum sample value; values above 255 use 16-bit samples in P6. Zero means DefaultMaxVal
+	MaxVal int
+	// ToneMapping converts the linear colors to display values. The zero value only clamps them
+	ToneMapping colors.ToneMapping
+}
+
+// String returns the magic number of the format
+func (f PPMFormat) String() string {
+	switch f {
+	case P3:
+		return "P3"
+	case P6:
+		return "P6"
+	}
+	return fmt.Sprintf("PPMFormat(%d)", int(f))
+}
+
+func (o PPMOptions) maxVal() (int, error) {
+	if o.MaxVal == 0 {
+		return DefaultMaxVal, nil
+	}
+	if o.MaxVal < 1 || o.MaxVal > math.MaxUint16 {
+		return 0, ErrInvalidMaxVal
+	}
+	return o.MaxVal, nil
+}
+
+// WritePPM streams the Canvas to w as a PPM image, without building the whole file in memory
+func (c Canvas) WritePPM(w io.Writer, options PPMOptions) error {
+	maxVal, err := options.maxVal()
+	if err != nil {
+		return err
+	}
+	if options.Format != P3 && options.Format != P6 {
+		return ErrInvalidPPMFormat
+	}
+
+	out := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(out, "%v\n%d %d\n%d\n", options.Format, c.Width, c.Height, maxVal); err != nil {
+		return err
+	}
+	if options.Format == P3 {
+		err = c.writePlainPixels(out, options.ToneMapping, maxVal)
+	} else {
+		err = c.writeRawPixels(out, options.ToneMapping, maxVal)
+	}
+	if err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// SavePPM writes the Canvas to a PPM file at the given path
+func (c Canvas) SavePPM(path string, options PPMOptions) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := c.WritePPM(file, options); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// writePlainPixels writes the samples as ASCII, starting a new line for every row
+// and whenever the next sample would make a line longer than 70 characters
+func (c Canvas) writePlainPixels(out *bufio.Writer, toneMapping colors.ToneMapping, maxVal int) error {
+	sample := make([]byte, 0, 8)
+	for row := 0; row < c.Height; row++ {
+		lineLength := 0
+		for col := 0; col < c.Width; col++ {
+			color := toneMapping.Apply(c.Get(col, row))
+			for _, component := range [3]float64{color.Red, color.Green, color.Blue} {
+				sample = strconv.AppendInt(sample[:0], int64(toPPMSample(component, maxVal)), 10)
+				if lineLength > 0 {
+					if lineLength+1+len(sample) > maxPlainLineLength {
+						out.WriteByte('\n')
+						lineLength = 0
+					} else {
+						out.WriteByte(' ')
+						lineLength++
+					}
+				}
+				out.Write(sample)
+				lineLength += len(sample)
+			}
+		}
+		if err := out.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRawPixels writes the samples as bytes, using two big-endian bytes per sample when maxVal exceeds 255
+func (c Canvas) writeRawPixels(out *bufio.Writer, toneMapping colors.ToneMapping, maxVal int) error {
+	wide := maxVal > 255
+	for row := 0; row < c.Height; row++ {
+		for col := 0; col < c.Width; col++ {
+			color := toneMapping.Apply(c.Get(col, row))
+			for _, component := range [3]float64{color.Red, color.Green, color.Blue} {
+				value := toPPMSample(component, maxVal)
+				if wide {
+					out.WriteByte(byte(value >> 8))
+				}
+				if err := out.WriteByte(byte(value)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func toPPMSample(component float64, maxVal int) int {
+	value := int(math.Round(float64(maxVal) * component))
+	if value < 0 {
+		value = 0
+	} else if value > maxVal {
+		value = maxVal
+	}
+	return value
+}
@@ -0,0 +1,162 @@
+package canvas
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: Streaming a plain PPM splits long lines
+// Given c ← canvas(10, 2)
+// When every pixel of c is set to color(1, 0.8, 0.6)
+// And c is written as P3 to a buffer
+// Then the buffer contains the header and lines no longer than 70 characters
+func Test_Streaming_a_Plain_PPM_Splits_Long_Lines(t *testing.T) {
+	// Given
+	c := NewCanvas(10, 2)
+	// When
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 10; x++ {
+			c.Set(x, y, colors.NewColor(1, 0.8, 0.6))
+		}
+	}
+	// And
+	var buffer bytes.Buffer
+	err := c.WritePPM(&buffer, PPMOptions{Format: P3})
+	// Expected
+	wanted := "P3\n10 2\n255\n" +
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153\n" +
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153\n"
+	// Then
+	if err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	if buffer.String() != wanted {
+		t.Errorf("WritePPM() wrote\n%s\nexpected\n%s", buffer.String(), wanted)
+	}
+}
+
+// Scenario: Writing a binary PPM
+// Given c ← canvas(2, 1)
+// And write_pixel(c, 0, 0, color(1.5, 0, 0.5))
+// And write_pixel(c, 1, 0, color(0, 1, -0.5))
+// When c is written as P6 to a buffer
+// Then the buffer contains "P6\n2 1\n255\n" followed by the bytes 255 0 128 0 255 0
+func Test_Writing_a_Binary_PPM(t *testing.T) {
+	// Given
+	c := NewCanvas(2, 1)
+	// And
+	c.Set(0, 0, colors.NewColor(1.5, 0, 0.5))
+	c.Set(1, 0, colors.NewColor(0, 1, -0.5))
+	// When
+	var buffer bytes.Buffer
+	err := c.WritePPM(&buffer, PPMOptions{Format: P6})
+	// Expected
+	wanted := append([]byte("P6\n2 1\n255\n"), 255, 0, 128, 0, 255, 0)
+	// Then
+	if err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	if !bytes.Equal(buffer.Bytes(), wanted) {
+		t.Errorf("WritePPM() wrote % x, expected % x", buffer.Bytes(), wanted)
+	}
+}
+
+// Scenario: Writing a 16-bit binary PPM
+// Given c ← canvas(1, 1)
+// And write_pixel(c, 0, 0, color(1, 0.5, 0))
+// When c is written as P6 with maxval 65535 to a buffer
+// Then every sample is written as two big-endian bytes
+func Test_Writing_a_16_Bit_Binary_PPM(t *testing.T) {
+	// Given
+	c := NewCanvas(1, 1)
+	// And
+	c.Set(0, 0, colors.NewColor(1, 0.5, 0))
+	// When
+	var buffer bytes.Buffer
+	err := c.WritePPM(&buffer, PPMOptions{Format: P6, MaxVal: 65535})
+	// Expected
+	wanted := append([]byte("P6\n1 1\n65535\n"), 0xff, 0xff, 0x80, 0x00, 0x00, 0x00)
+	// Then
+	if err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	if !bytes.Equal(buffer.Bytes(), wanted) {
+		t.Errorf("WritePPM() wrote % x, expected % x", buffer.Bytes(), wanted)
+	}
+}
+
+// Scenario: Writing a plain PPM with a custom maxval
+// Given c ← canvas(1, 1)
+// And write_pixel(c, 0, 0, color(1, 0.5, 0))
+// When c is written as P3 with maxval 1023 to a buffer
+// Then the buffer contains "P3\n1 1\n1023\n1023 512 0\n"
+func Test_Writing_a_Plain_PPM_with_a_Custom_MaxVal(t *testing.T) {
+	// Given
+	c := NewCanvas(1, 1)
+	// And
+	c.Set(0, 0, colors.NewColor(1, 0.5, 0))
+	// When
+	var buffer bytes.Buffer
+	err := c.WritePPM(&buffer, PPMOptions{Format: P3, MaxVal: 1023})
+	// Expected
+	wanted := "P3\n1 1\n1023\n1023 512 0\n"
+	// Then
+	if err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	if buffer.String() != wanted {
+		t.Errorf("WritePPM() wrote %q, expected %q", buffer.String(), wanted)
+	}
+}
+
+// Scenario: An invalid maxval is rejected
+// Given c ← canvas(1, 1)
+// When c is written with maxval 65536
+// Then the error is ErrInvalidMaxVal
+// And nothing is written
+func Test_an_Invalid_MaxVal_Is_Rejected(t *testing.T) {
+	// Given
+	c := NewCanvas(1, 1)
+	// When
+	var buffer bytes.Buffer
+	err := c.WritePPM(&buffer, PPMOptions{Format: P6, MaxVal: 65536})
+	// Then
+	if err != ErrInvalidMaxVal {
+		t.Errorf("WritePPM() returned %v, expected %v", err, ErrInvalidMaxVal)
+	}
+	// And
+	if buffer.Len() != 0 {
+		t.Errorf("WritePPM() wrote %d bytes, expected none", buffer.Len())
+	}
+}
+
+// Scenario: Writing a PPM with tone mapping
+// Given c ← canvas(2, 1)
+// And write_pixel(c, 0, 0, color(0.18, 0.18, 0.18))
+// And write_pixel(c, 1, 0, color(3, 3, 3))
+// When c is written as P3 with Reinhard tone mapping and sRGB encoding
+// Then the pixel data is "109 109 109 225 225 225"
+func Test_Writing_a_PPM_with_Tone_Mapping(t *testing.T) {
+	// Given
+	c := NewCanvas(2, 1)
+	// And
+	c.Set(0, 0, colors.NewColor(0.18, 0.18, 0.18))
+	c.Set(1, 0, colors.NewColor(3, 3, 3))
+	// When
+	var buffer bytes.Buffer
+	toneMapping := colors.NewToneMapping(0, colors.NewReinhardOperator())
+	err := c.WritePPM(&buffer, PPMOptions{Format: P3, ToneMapping: toneMapping})
+	// Expected
+	wanted := "P3\n2 1\n255\n109 109 109 225 225 225\n"
+	// Then
+	if err != nil {
+		t.Fatalf("WritePPM() returned %v", err)
+	}
+	if buffer.String() != wanted {
+		t.Errorf("WritePPM() wrote %q, expected %q", buffer.String(), wanted)
+	}
+}
@@ -58,6 +58,11 @@ func (c Color) Blend(other Color) Color {
 	return Color{c.Red * other.Red, c.Green * other.Green, c.Blue * other.Blue}
 }
 
+// Luminance calculates the perceived brightness of a Color, using the Rec. 709 weights
+func (c Color) Luminance() float64 {
+	return 0.2126*c.Red + 0.7152*c.Green + 0.0722*c.Blue
+}
+
 // String formats Color to readable string
 func (c Color) String() string {
 	return fmt.Sprintf("Color( %9.5f, %9.5f, %9.5f )", c.Red, c.Green, c.Blue)
@@ -1,6 +1,9 @@
 package colors
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 //Scenario: Colors are (red, green, blue) tuples
 //Given c ← color(-0.5, 0.4, 1.7)
@@ -87,3 +90,21 @@ func Test_Blend_Colors(t *testing.T) {
 		t.Errorf("%v - %v = %v, want %v", c1, c2, r, wanted)
 	}
 }
+
+//Scenario: The luminance of a color
+//Given c ← color(0.5, 1, 0.25)
+//Then luminance(c) = 0.2126 * 0.5 + 0.7152 * 1 + 0.0722 * 0.25
+func Test_the_Luminance_of_a_Color(t *testing.T) {
+	// Given
+	c := Color{0.5, 1, 0.25}
+	// Expected
+	wanted := 0.2126*0.5 + 0.7152*1 + 0.0722*0.25
+	// Then
+	if l := c.Luminance(); math.Abs(l-wanted) > epsilon {
+		t.Errorf("luminance(%v) = %9.6f, want %9.6f", c, l, wanted)
+	}
+	// And
+	if l := White().Luminance(); math.Abs(l-1) > epsilon {
+		t.Errorf("luminance(%v) = %9.6f, want %9.6f", White(), l, 1.0)
+	}
+}
@@ -0,0 +1,19 @@
+package colors
+
+import "math"
+
+// SRGBToLinear converts a component encoded with the sRGB transfer function to linear light
+func SRGBToLinear(component float64) float64 {
+	if component <= 0.04045 {
+		return component / 12.92
+	}
+	return math.Pow((component+0.055)/1.055, 2.4)
+}
+
+// LinearToSRGB encodes a linear component with the sRGB transfer function
+func LinearToSRGB(component float64) float64 {
+	if component <= 0.0031308 {
+		return 12.92 * component
+	}
+	return 1.055*math.Pow(component, 1/2.4) - 0.055
+}
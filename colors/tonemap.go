@@ -0,0 +1,122 @@
+package colors
+
+import "math"
+
+// ToneOperator compresses linear radiance into the displayable range of 0 to 1
+type ToneOperator interface {
+	// Map returns the display value of a linear Color
+	Map(c Color) Color
+}
+
+// ClampOperator cuts every component off at 0 and 1, blowing out highlights
+type ClampOperator struct{}
+
+// ReinhardOperator scales the luminance L to L / (1 + L), which approaches but never reaches white
+type ReinhardOperator struct{}
+
+// ExtendedReinhardOperator is the Reinhard operator that maps the luminance WhitePoint, and anything above it, to white
+// A WhitePoint of 0 or less sets no white point, which makes it a plain ReinhardOperator
+type ExtendedReinhardOperator struct {
+	WhitePoint float64
+}
+
+// ACESFilmicOperator applies Narkowicz' fit of the ACES filmic curve to every component
+type ACESFilmicOperator struct{}
+
+// ToneMapping describes the conversion of linear colors to display values:
+// exposure, then a tone operator, then optionally the sRGB transfer function.
+// The zero value clamps linear colors, as the book does
+type ToneMapping struct {
+	// Exposure in stops, every stop doubles the brightness
+	Exposure float64
+	// Operator compresses the exposed colors, nil means a ClampOperator
+	Operator ToneOperator
+	// SRGB enables the sRGB transfer encoding after tone mapping
+	SRGB bool
+}
+
+// NewClampOperator creates a new ClampOperator
+func NewClampOperator() ClampOperator {
+	return ClampOperator{}
+}
+
+// NewReinhardOperator creates a new ReinhardOperator
+func NewReinhardOperator() ReinhardOperator {
+	return ReinhardOperator{}
+}
+
+// NewExtendedReinhardOperator creates a new ExtendedReinhardOperator with the luminance that is mapped to white
+func NewExtendedReinhardOperator(whitePoint float64) ExtendedReinhardOperator {
+	return ExtendedReinhardOperator{whitePoint}
+}
+
+// NewACESFilmicOperator creates a new ACESFilmicOperator
+func NewACESFilmicOperator() ACESFilmicOperator {
+	return ACESFilmicOperator{}
+}
+
+// NewToneMapping creates a new ToneMapping with sRGB encoding
+func NewToneMapping(exposure float64, operator ToneOperator) ToneMapping {
+	return ToneMapping{exposure, operator, true}
+}
+
+// Map clamps every component of c to the range 0 to 1
+func (o ClampOperator) Map(c Color) Color {
+	return Color{clamp(c.Red), clamp(c.Green), clamp(c.Blue)}
+}
+
+// Map compresses the luminance of c, keeping its hue
+func (o ReinhardOperator) Map(c Color) Color {
+	return scaleLuminance(c, func(l float64) float64 {
+		return l / (1 + l)
+	})
+}
+
+// Map compresses the luminance of c so WhitePoint becomes 1, keeping its hue
+func (o ExtendedReinhardOperator) Map(c Color) Color {
+	if o.WhitePoint <= 0 {
+		return ReinhardOperator{}.Map(c)
+	}
+	white2 := o.WhitePoint * o.WhitePoint
+	return scaleLuminance(c, func(l float64) float64 {
+		return math.Min(l*(1+l/white2)/(1+l), 1)
+	})
+}
+
+// Map applies the filmic curve to every component of c
+func (o ACESFilmicOperator) Map(c Color) Color {
+	curve := func(x float64) float64 {
+		x = math.Max(x, 0)
+		return clamp(x * (2.51*x + 0.03) / (x*(2.43*x+0.59) + 0.14))
+	}
+	return Color{curve(c.Red), curve(c.Green), curve(c.Blue)}
+}
+
+// Apply converts a linear Color to a display Color with components between 0 and 1
+func (t ToneMapping) Apply(c Color) Color {
+	if t.Exposure != 0 {
+		c = c.Multiply(math.Exp2(t.Exposure))
+	}
+	operator := t.Operator
+	if operator == nil {
+		operator = ClampOperator{}
+	}
+	c = ClampOperator{}.Map(operator.Map(c))
+	if t.SRGB {
+		c = Color{LinearToSRGB(c.Red), LinearToSRGB(c.Green), LinearToSRGB(c.Blue)}
+	}
+	return c
+}
+
+// scaleLuminance scales c so its luminance l becomes mapped(l)
+func scaleLuminance(c Color, mapped func(l float64) float64) Color {
+	l := c.Luminance()
+	if l <= 0 {
+		return Black()
+	}
+	return c.Multiply(mapped(l) / l)
+}
+
+func clamp(component float64) float64 {
+	return math.Min(math.Max(component, 0), 1)
+}
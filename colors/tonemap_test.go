@@ -0,0 +1,104 @@
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+// Scenario: The sRGB transfer function round trips
+// Given the linear components 0, 0.001, 0.18, 0.5 and 1
+// Then srgb_to_linear(linear_to_srgb(component)) = component
+// And linear_to_srgb(0.18) = 0.46135
+func Test_the_sRGB_Transfer_Function_Round_Trips(t *testing.T) {
+	for _, component := range []float64{0, 0.001, 0.18, 0.5, 1} {
+		// Then
+		if result := SRGBToLinear(LinearToSRGB(component)); math.Abs(result-component) > epsilon {
+			t.Errorf("SRGBToLinear(LinearToSRGB(%g)) = %g, expected %g", component, result, component)
+		}
+	}
+	// And
+	if encoded := LinearToSRGB(0.18); math.Abs(encoded-0.46135) > epsilon {
+		t.Errorf("LinearToSRGB(0.18) = %g, expected %g", encoded, 0.46135)
+	}
+}
+
+// Scenario: Tone operators map linear colors into the displayable range
+// Given c ← color(<red>, <green>, <blue>)
+// Then map(<operator>, c) = <result>
+// Examples:
+// | operator                  | red | green | blue | result                        |
+// | clamp                     | 1.5 | 0.5   | -1   | color(1, 0.5, 0)              |
+// | reinhard                  | 1   | 1     | 1    | color(0.5, 0.5, 0.5)          |
+// | reinhard                  | 0   | 0     | 0    | color(0, 0, 0)                |
+// | extended_reinhard(4)      | 4   | 4     | 4    | color(1, 1, 1)                |
+// | extended_reinhard(4)      | 1   | 1     | 1    | color(0.53125, ...)           |
+// | extended_reinhard(0)      | 1   | 1     | 1    | color(0.5, 0.5, 0.5)          |
+// | aces_filmic               | 1   | 0     | 100  | color(0.8038, 0, 1)           |
+func Test_Tone_Operators_Map_Linear_Colors_into_the_Displayable_Range(t *testing.T) {
+	cases := []struct {
+		operator ToneOperator
+		c        Color
+		wanted   Color
+	}{
+		{NewClampOperator(), NewColor(1.5, 0.5, -1), NewColor(1, 0.5, 0)},
+		{NewReinhardOperator(), NewColor(1, 1, 1), NewColor(0.5, 0.5, 0.5)},
+		{NewReinhardOperator(), NewColor(0, 0, 0), NewColor(0, 0, 0)},
+		{NewExtendedReinhardOperator(4), NewColor(4, 4, 4), NewColor(1, 1, 1)},
+		{NewExtendedReinhardOperator(4), NewColor(1, 1, 1), NewColor(0.53125, 0.53125, 0.53125)},
+		{NewExtendedReinhardOperator(0), NewColor(1, 1, 1), NewColor(0.5, 0.5, 0.5)},
+		{NewACESFilmicOperator(), NewColor(1, 0, 100), NewColor(0.8038, 0, 1)},
+	}
+	for _, c := range cases {
+		// Then
+		if result := c.operator.Map(c.c); !result.Equals(c.wanted) {
+			t.Errorf("%T.Map(%v) = %v, expected %v", c.operator, c.c, result, c.wanted)
+		}
+	}
+}
+
+// Scenario: Reinhard keeps the hue of a bright color
+// Given c ← color(8, 4, 2)
+// When result ← map(reinhard, c)
+// Then result.red / result.green = 2
+// And result.green / result.blue = 2
+// And luminance(result) < 1
+func Test_Reinhard_Keeps_the_Hue_of_a_Bright_Color(t *testing.T) {
+	// Given
+	c := NewColor(8, 4, 2)
+	// When
+	result := NewReinhardOperator().Map(c)
+	// Then
+	if ratio := result.Red / result.Green; math.Abs(ratio-2) > epsilon {
+		t.Errorf("red / green = %g, expected 2", ratio)
+	}
+	// And
+	if ratio := result.Green / result.Blue; math.Abs(ratio-2) > epsilon {
+		t.Errorf("green / blue = %g, expected 2", ratio)
+	}
+	// And
+	if l := result.Luminance(); l >= 1 {
+		t.Errorf("luminance = %g, expected less than 1", l)
+	}
+}
+
+// Scenario: Tone mapping applies exposure, the operator and sRGB encoding
+// Given tm ← tone_mapping(exposure: -1, operator: clamp, srgb: true)
+// When result ← apply(tm, color(0.36, 4, -1))
+// Then result = color(0.46135, 1, 0)
+// And apply(tone_mapping(), color(0.36, 4, -1)) = color(0.36, 1, 0)
+func Test_Tone_Mapping_Applies_Exposure_the_Operator_and_sRGB_Encoding(t *testing.T) {
+	// Given
+	tm := NewToneMapping(-1, NewClampOperator())
+	// When
+	result := tm.Apply(NewColor(0.36, 4, -1))
+	// Expected
+	wanted := NewColor(0.46135, 1, 0)
+	// Then
+	if !result.Equals(wanted) {
+		t.Errorf("%v.Apply() = %v, expected %v", tm, result, wanted)
+	}
+	// And
+	if result := (ToneMapping{}).Apply(NewColor(0.36, 4, -1)); !result.Equals(NewColor(0.36, 1, 0)) {
+		t.Errorf("ToneMapping{}.Apply() = %v, expected %v", result, NewColor(0.36, 1, 0))
+	}
+}
@@ -0,0 +1,80 @@
+// Package golden compares rendered images against reference images checked in with the tests
+// The references are Portable Float Maps in the testdata/golden directory of the package under test.
+// Run the tests with -golden.update to (re)write them from the current renders
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+)
+
+// Dir is the directory, relative to the package under test, that holds the reference images
+const Dir = "testdata/golden"
+
+var update = flag.Bool("golden.update", false, "rewrite the golden reference images from the current renders")
+
+// Tolerance bounds how far a render may differ from its reference
+// A bound of 0 is not checked
+type Tolerance struct {
+	MaxRMSE  float64
+	MaxError float64
+}
+
+// DefaultTolerance allows for the rounding differences between platforms, but not for visible changes
+func DefaultTolerance() Tolerance {
+	return Tolerance{MaxRMSE: 1e-4, MaxError: 1e-2}
+}
+
+// Path returns the path of the reference image with the given name
+func Path(name string) string {
+	return filepath.Join(Dir, name+".pfm")
+}
+
+// Assert compares a render with the reference image with the given name
+// When they differ more than the tolerance, the test fails, and the render and a heatmap
+// of the difference are written to a temporary directory that is reported in the failure
+func Assert(t testing.TB, name string, actual *canvas.Canvas, tolerance Tolerance) {
+	t.Helper()
+	path := Path(name)
+	if *update {
+		if err := os.MkdirAll(Dir, 0755); err != nil {
+			t.Fatalf("creating %s: %v", Dir, err)
+		}
+		if err := actual.SavePFM(path); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+		t.Logf("updated %s", path)
+		return
+	}
+
+	expected, err := canvas.Load(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v (run the tests with -golden.update to create it)", path, err)
+	}
+	d, err := canvas.Diff(actual, expected)
+	if err != nil {
+		t.Fatalf("comparing with %s: %v", path, err)
+	}
+	if (tolerance.MaxRMSE <= 0 || d.RMSE <= tolerance.MaxRMSE) &&
+		(tolerance.MaxError <= 0 || d.MaxError <= tolerance.MaxError) {
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "golden-"+name+"-")
+	if err != nil {
+		t.Fatalf("%s differs from the render: %v, and creating a directory for the diff failed: %v", path, d, err)
+	}
+	actualPath := filepath.Join(dir, name+".actual.pfm")
+	diffPath := filepath.Join(dir, name+".diff.ppm")
+	if err := actual.SavePFM(actualPath); err != nil {
+		t.Errorf("writing %s: %v", actualPath, err)
+	}
+	if err := d.Heatmap(0).SavePPM(diffPath, canvas.PPMOptions{Format: canvas.P6}); err != nil {
+		t.Errorf("writing %s: %v", diffPath, err)
+	}
+	t.Errorf("%s differs from the render: %v, tolerance %+v\nrender: %s\ndiff:   %s", path, d, tolerance, actualPath, diffPath)
+}
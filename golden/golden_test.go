@@ -0,0 +1,127 @@
+package golden
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// recorder is a testing.TB that records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// errFatal stops Assert after a fatal failure, like FailNow stops a test
+var errFatal = fmt.Errorf("fatal failure")
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	panic(errFatal)
+}
+
+// assert runs Assert, recording its failures
+func (r *recorder) assert(name string, actual *canvas.Canvas, tolerance Tolerance) {
+	defer func() {
+		if p := recover(); p != nil && p != errFatal {
+			panic(p)
+		}
+	}()
+	Assert(r, name, actual, tolerance)
+}
+
+func (r *recorder) Logf(format string, args ...interface{}) {}
+
+// Scenario: A render within the tolerance of its reference passes
+// Given the reference "gradient" is a 4x2 gradient
+// And actual ← the gradient with one component off by 0.001
+// When assert(actual matches "gradient" within rmse 0.001)
+// Then no failure is reported
+func Test_a_Render_within_the_Tolerance_of_its_Reference_Passes(t *testing.T) {
+	// Given
+	t.Chdir(t.TempDir())
+	writeReference(t, "gradient", gradient())
+	// And
+	actual := gradient()
+	actual.Set(1, 1, actual.Get(1, 1).Add(colors.NewColor(0.001, 0, 0)))
+	// When
+	r := &recorder{TB: t}
+	r.assert("gradient", actual, Tolerance{MaxRMSE: 1e-3})
+	// Then
+	if len(r.failures) != 0 {
+		t.Errorf("Assert() reported %v, expected no failures", r.failures)
+	}
+}
+
+// Scenario: A render that differs from its reference fails and writes the diff
+// Given the reference "gradient" is a 4x2 gradient
+// And actual ← the gradient with a white pixel
+// When assert(actual matches "gradient" within the default tolerance)
+// Then a failure is reported
+// And the render and the diff heatmap named in the failure exist
+func Test_a_Render_that_Differs_from_its_Reference_Fails_and_Writes_the_Diff(t *testing.T) {
+	// Given
+	t.Chdir(t.TempDir())
+	t.Setenv("TMPDIR", t.TempDir())
+	writeReference(t, "gradient", gradient())
+	// And
+	actual := gradient()
+	actual.Set(2, 0, colors.White())
+	// When
+	r := &recorder{TB: t}
+	r.assert("gradient", actual, DefaultTolerance())
+	// Then
+	if len(r.failures) != 1 {
+		t.Fatalf("Assert() reported %v, expected one failure", r.failures)
+	}
+	// And
+	written, _ := filepath.Glob(filepath.Join(os.TempDir(), "golden-gradient-*", "gradient.*"))
+	if len(written) != 2 {
+		t.Errorf("Assert() wrote %v, expected the render and the diff", written)
+	}
+}
+
+// Scenario: A missing reference fails
+// Given there is no reference "missing"
+// When assert(canvas(1, 1) matches "missing")
+// Then a failure is reported
+func Test_a_Missing_Reference_Fails(t *testing.T) {
+	// Given
+	t.Chdir(t.TempDir())
+	// When
+	r := &recorder{TB: t}
+	r.assert("missing", canvas.NewCanvas(1, 1), DefaultTolerance())
+	// Then
+	if len(r.failures) != 1 {
+		t.Errorf("Assert() reported %v, expected one failure", r.failures)
+	}
+}
+
+func gradient() *canvas.Canvas {
+	c := canvas.NewCanvas(4, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c.Set(x, y, colors.NewColor(float64(x)/4, float64(y)/2, 0.5))
+		}
+	}
+	return c
+}
+
+func writeReference(t *testing.T, name string, c *canvas.Canvas) {
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SavePFM(Path(name)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,12 +9,12 @@ import (
 
 // PointLight defines a light source from a single point with a certain intensity and color
 type PointLight struct {
-	Position  tuples.Tuple
+	Position  tuples.Point
 	Intensity colors.Color
 }
 
 // NewPointLight constructs a new Point Light from a Point and a Color
-func NewPointLight(position tuples.Tuple, intensity colors.Color) PointLight {
+func NewPointLight(position tuples.Point, intensity colors.Color) PointLight {
 	return PointLight{position, intensity}
 }
 
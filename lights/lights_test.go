@@ -17,7 +17,7 @@ func Test_a_Point_Light_has_a_Position_and_Intensity(t *testing.T) {
 	// Given
 	intensity := colors.White()
 	// And
-	position := tuples.Point(0, 0, 0)
+	position := tuples.NewPoint(0, 0, 0)
 	// When
 	light := NewPointLight(position, intensity)
 	// Then
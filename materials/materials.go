@@ -3,53 +3,170 @@ package materials
 import (
 	"fmt"
 	"math"
+	"reflect"
 
 	"github.com/bas-velthuizen/go-raytracer/lights"
 
 	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/textures"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
 // Material defines the properties of a material
+// A Texture, when set, replaces the Color with a color that varies over the surface,
+// and a Bump, such as a bump or normal map, changes the normal used for shading it
+// The Model selects how the material reflects light: Phong uses Diffuse, Specular and Shininess,
+// CookTorrance uses the Color as base color with Roughness and Metallic. Both use Ambient
+// An emissive material glows with its Emission color times its EmissionStrength, whatever light falls on it
+// Reflective is the fraction of light mirrored by the surface, and Transparency the fraction passed through it,
+// bent by the RefractiveIndex of the material, such as 1.5 for glass
 type Material struct {
 	Color     colors.Color
 	Ambient   float64
 	Diffuse   float64
 	Specular  float64
 	Shininess float64
+	Texture   textures.Pattern
+	Bump      textures.NormalModifier
+	Model     Model
+	Roughness float64
+	Metallic  float64
+
+	Emission         colors.Color
+	EmissionStrength float64
+
+	Reflective      float64
+	Transparency    float64
+	RefractiveIndex float64
 }
 
 // DefaultMaterial constructs the default material
 func DefaultMaterial() Material {
 	return Material{
-		colors.White(),
-		0.1,
-		0.9,
-		0.9,
-		200.0,
+		Color:     colors.White(),
+		Ambient:   0.1,
+		Diffuse:   0.9,
+		Specular:  0.9,
+		Shininess: 200.0,
+		Model:     Phong,
+		Roughness: 0.5,
+
+		RefractiveIndex: 1.0,
 	}
 }
 
+// NewPBRMaterial constructs a CookTorrance material with a base color, a roughness from 0 (polished)
+// to 1 (matte), and a metallic from 0 (dielectric) to 1 (metal)
+func NewPBRMaterial(baseColor colors.Color, roughness, metallic float64) Material {
+	m := DefaultMaterial()
+	m.Model = CookTorrance
+	m.Color = baseColor
+	m.Roughness = roughness
+	m.Metallic = metallic
+	return m
+}
+
+// NewEmissiveMaterial constructs a material that only glows, such as a light panel or a neon tube,
+// with an emission color and a strength that scales it
+func NewEmissiveMaterial(emission colors.Color, strength float64) Material {
+	m := DefaultMaterial()
+	m.Color = colors.Black()
+	m.Ambient = 0
+	m.Diffuse = 0
+	m.Specular = 0
+	m.Emission = emission
+	m.EmissionStrength = strength
+	return m
+}
+
+// NewGlassMaterial constructs a clear material that passes light through and reflects it at grazing angles,
+// with a refractive index such as 1.5 for glass
+func NewGlassMaterial(refractiveIndex float64) Material {
+	m := DefaultMaterial()
+	m.Color = colors.Black()
+	m.Ambient = 0
+	m.Diffuse = 0
+	m.Reflective = 1
+	m.Transparency = 1
+	m.RefractiveIndex = refractiveIndex
+	return m
+}
+
 // Equals checks if this material is the same as another
 func (m Material) Equals(other Material) bool {
 	return m.Color.Equals(other.Color) &&
 		math.Abs(m.Ambient-other.Ambient) <= tuples.Epsilon &&
 		math.Abs(m.Diffuse-other.Diffuse) <= tuples.Epsilon &&
 		math.Abs(m.Specular-other.Specular) <= tuples.Epsilon &&
-		math.Abs(m.Shininess-other.Shininess) <= tuples.Epsilon
+		math.Abs(m.Shininess-other.Shininess) <= tuples.Epsilon &&
+		sameValue(m.Texture, other.Texture) &&
+		sameValue(m.Bump, other.Bump) &&
+		m.Model == other.Model &&
+		math.Abs(m.Roughness-other.Roughness) <= tuples.Epsilon &&
+		math.Abs(m.Metallic-other.Metallic) <= tuples.Epsilon &&
+		m.Emission.Equals(other.Emission) &&
+		math.Abs(m.EmissionStrength-other.EmissionStrength) <= tuples.Epsilon &&
+		math.Abs(m.Reflective-other.Reflective) <= tuples.Epsilon &&
+		math.Abs(m.Transparency-other.Transparency) <= tuples.Epsilon &&
+		math.Abs(m.RefractiveIndex-other.RefractiveIndex) <= tuples.Epsilon
+}
+
+// sameValue checks if two textures or normal modifiers are the same
+// Values that == cannot compare without panicking, such as patterns holding a slice, are compared deeply instead
+func sameValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !reflect.ValueOf(a).Comparable() || !reflect.ValueOf(b).Comparable() {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
+
+// Emitted returns the light the material emits by itself, the Emission scaled by the EmissionStrength
+func (m Material) Emitted() colors.Color {
+	return m.Emission.Multiply(m.EmissionStrength)
+}
+
+// IsSpecular checks if the material mirrors or passes through any light, so it can focus light into caustics
+func (m Material) IsSpecular() bool {
+	return m.Reflective > 0 || m.Transparency > 0
+}
+
+// IsEmissive checks if the material emits any light
+func (m Material) IsEmissive() bool {
+	e := m.Emitted()
+	return e.Red > 0 || e.Green > 0 || e.Blue > 0
+}
+
+// SurfaceAt returns the material at a point on the surface, in the space of the shape,
+// with the Color taken from the Texture
+func (m Material) SurfaceAt(shapePoint tuples.Point) Material {
+	if m.Texture != nil {
+		m.Color = m.Texture.ColorAt(shapePoint)
+	}
+	return m
 }
 
 func (m Material) String() string {
+	if m.Model == CookTorrance {
+		return fmt.Sprintf("Material( %v, %v, %9.6f, %9.6f, %9.6f )", m.Model, m.Color, m.Ambient, m.Roughness, m.Metallic)
+	}
 	return fmt.Sprintf("Material( %v, %9.6f, %9.6f, %9.6f, %9.6f )", m.Color, m.Ambient, m.Diffuse, m.Specular, m.Shininess)
 }
 
 // Lighting calculates the effective color of a pixel with reflections of light
+// A position in shadow is only lit by the ambient light
 func (m Material) Lighting(
 	light lights.PointLight,
-	position tuples.Tuple,
-	eyeV tuples.Tuple,
-	normalV tuples.Tuple,
+	position tuples.Point,
+	eyeV tuples.Vector,
+	normalV tuples.Normal,
+	inShadow bool,
 ) colors.Color {
+	if m.Model == CookTorrance {
+		return m.cookTorranceLighting(light, position, eyeV, normalV, inShadow)
+	}
 	diff := colors.Black()
 	spec := colors.Black()
 
@@ -57,15 +174,18 @@ func (m Material) Lighting(
 	lightV := light.Position.Subtract(position).Normalize()
 
 	ambient := effectiveColor.Multiply(m.Ambient)
+	if inShadow {
+		return ambient
+	}
 
-	lightDotNormal := lightV.Dot(normalV)
+	lightDotNormal := normalV.Dot(lightV)
 
 	if lightDotNormal < 0 {
 		diff = colors.Black()
 		spec = colors.Black()
 	} else {
 		diff = effectiveColor.Multiply(m.Diffuse * lightDotNormal)
-		reflectV := normalV.Reflect(lightV.Negate())
+		reflectV := lightV.Negate().Reflect(normalV)
 		reflectDotEye := math.Pow(reflectV.Dot(eyeV), m.Shininess)
 		if reflectDotEye <= 0 {
 			spec = colors.Black()
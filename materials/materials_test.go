@@ -6,12 +6,13 @@ import (
 
 	"github.com/bas-velthuizen/go-raytracer/colors"
 	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/textures"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
 // setup returns the default values for material and position
-func setup() (Material, tuples.Tuple) {
-	return DefaultMaterial(), tuples.Point(0, 0, 0)
+func setup() (Material, tuples.Point) {
+	return DefaultMaterial(), tuples.NewPoint(0, 0, 0)
 }
 
 // Scenario: The default material
@@ -62,13 +63,13 @@ func Test_Lighting_With_the_Eye_Between_the_Light_and_the_Surface(t *testing.T)
 	// Setup
 	m, position := setup()
 	// Given
-	eyev := tuples.Vector(0, 0, -1)
+	eyev := tuples.NewVector(0, 0, -1)
 	// And
-	normalv := tuples.Vector(0, 0, -1)
+	normalv := tuples.NewNormal(0, 0, -1)
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 0, -10), colors.NewColor(1, 1, 1))
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.NewColor(1, 1, 1))
 	// When
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(light, position, eyev, normalv, false)
 	// Expected
 	wanted := colors.NewColor(1.9, 1.9, 1.9)
 	// Then
@@ -87,13 +88,13 @@ func Test_Lighting_With_the_Eye_Between_Light_and_Surface__Eye_Offset_45(t *test
 	// Setup
 	m, position := setup()
 	// Given
-	eyev := tuples.Vector(0, math.Sqrt2/2.0, -math.Sqrt2/2)
+	eyev := tuples.NewVector(0, math.Sqrt2/2.0, -math.Sqrt2/2)
 	// And
-	normalv := tuples.Vector(0, 0, -1)
+	normalv := tuples.NewNormal(0, 0, -1)
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 0, -10), colors.NewColor(1, 1, 1))
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.NewColor(1, 1, 1))
 	// When
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(light, position, eyev, normalv, false)
 	// Expected
 	wanted := colors.White()
 	// Then
@@ -112,13 +113,13 @@ func Test_Lighting_With_Eye_Opposite_Surface__Light_Offset_45(t *testing.T) {
 	// Setup
 	m, position := setup()
 	// Given
-	eyev := tuples.Vector(0, 0, -1)
+	eyev := tuples.NewVector(0, 0, -1)
 	// And
-	normalv := tuples.Vector(0, 0, -1)
+	normalv := tuples.NewNormal(0, 0, -1)
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 10, -10), colors.NewColor(1, 1, 1))
+	light := lights.NewPointLight(tuples.NewPoint(0, 10, -10), colors.NewColor(1, 1, 1))
 	// When
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(light, position, eyev, normalv, false)
 	// Expected
 	wanted := colors.NewColor(0.7364, 0.7364, 0.7364)
 	// Then
@@ -137,13 +138,13 @@ func Test_Lighting_With_Eye_in_the_Path_of_the_Reflection_Vector(t *testing.T) {
 	// Setup
 	m, position := setup()
 	// Given
-	eyev := tuples.Vector(0, -math.Sqrt2/2.0, -math.Sqrt2/2.0)
+	eyev := tuples.NewVector(0, -math.Sqrt2/2.0, -math.Sqrt2/2.0)
 	// And
-	normalv := tuples.Vector(0, 0, -1)
+	normalv := tuples.NewNormal(0, 0, -1)
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 10, -10), colors.NewColor(1, 1, 1))
+	light := lights.NewPointLight(tuples.NewPoint(0, 10, -10), colors.NewColor(1, 1, 1))
 	// When
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(light, position, eyev, normalv, false)
 	// Expected
 	wanted := colors.NewColor(1.6364, 1.6364, 1.6364)
 	// Then
@@ -162,13 +163,13 @@ func Test_Lighting_with_the_Light_Behind_the_Surface(t *testing.T) {
 	// Setup
 	m, position := setup()
 	// Given
-	eyev := tuples.Vector(0, 0, -1)
+	eyev := tuples.NewVector(0, 0, -1)
 	// And
-	normalv := tuples.Vector(0, 0, -1)
+	normalv := tuples.NewNormal(0, 0, -1)
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 0, 10), colors.NewColor(1, 1, 1))
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, 10), colors.NewColor(1, 1, 1))
 	// When
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(light, position, eyev, normalv, false)
 	// Expected
 	wanted := colors.NewColor(0.1, 0.1, 0.1)
 	// Then
@@ -176,3 +177,182 @@ func Test_Lighting_with_the_Light_Behind_the_Surface(t *testing.T) {
 		t.Errorf("Lighting( %v, %v, %v, %v ) = %v, Expected %v", m, light, eyev, normalv, result, wanted)
 	}
 }
+
+// Scenario: Lighting with the surface in shadow
+// Given eyev ← vector(0, 0, -1)
+// And normalv ← vector(0, 0, -1)
+// And light ← point_light(point(0, 0, -10), color(1, 1, 1))
+// And in_shadow ← true
+// When result ← lighting(m, light, position, eyev, normalv, in_shadow)
+// Then result = color(0.1, 0.1, 0.1)
+func Test_Lighting_with_the_Surface_in_Shadow(t *testing.T) {
+	// Setup
+	m, position := setup()
+	// Given
+	eyev := tuples.NewVector(0, 0, -1)
+	// And
+	normalv := tuples.NewNormal(0, 0, -1)
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.NewColor(1, 1, 1))
+	// When
+	result := m.Lighting(light, position, eyev, normalv, true)
+	// Expected
+	wanted := colors.NewColor(0.1, 0.1, 0.1)
+	// Then
+	if !wanted.Equals(result) {
+		t.Errorf("Lighting( %v, %v, %v, %v, true ) = %v, Expected %v", m, light, eyev, normalv, result, wanted)
+	}
+}
+
+// Scenario: A texture determines the color of the surface
+// Given m ← material()
+// And m.texture ← texture_map(uv_checkers(2, 1, black, white), spherical_map)
+// When s1 ← surface_at(m, point(0, 0, -1))
+// And s2 ← surface_at(m, point(0, 0, 1))
+// Then s1.color = black
+// And s2.color = white
+// And s1.diffuse = m.diffuse
+func Test_a_Texture_Determines_the_Color_of_the_Surface(t *testing.T) {
+	// Given
+	m := DefaultMaterial()
+	// And
+	m.Texture = textures.NewTextureMap(textures.NewUVCheckers(2, 1, colors.Black(), colors.White()), textures.NewSphericalMapping())
+	// When
+	s1 := m.SurfaceAt(tuples.NewPoint(0, 0, -1))
+	// And
+	s2 := m.SurfaceAt(tuples.NewPoint(0, 0, 1))
+	// Then
+	if !s1.Color.Equals(colors.Black()) {
+		t.Errorf("SurfaceAt( %v ) has color %v, expected %v", tuples.NewPoint(0, 0, -1), s1.Color, colors.Black())
+	}
+	// And
+	if !s2.Color.Equals(colors.White()) {
+		t.Errorf("SurfaceAt( %v ) has color %v, expected %v", tuples.NewPoint(0, 0, 1), s2.Color, colors.White())
+	}
+	// And
+	if s1.Diffuse != m.Diffuse {
+		t.Errorf("SurfaceAt( %v ) has diffuse %g, expected %g", tuples.NewPoint(0, 0, -1), s1.Diffuse, m.Diffuse)
+	}
+}
+
+// Scenario: The default material emits no light
+// Given m ← material()
+// Then emitted(m) = color(0, 0, 0)
+// And m is not emissive
+func Test_the_Default_Material_Emits_no_Light(t *testing.T) {
+	// Given
+	m := DefaultMaterial()
+	// Then
+	if !colors.Black().Equals(m.Emitted()) {
+		t.Errorf("Emitted() = %v, expected %v", m.Emitted(), colors.Black())
+	}
+	// And
+	if m.IsEmissive() {
+		t.Errorf("IsEmissive() = true for %v, expected false", m)
+	}
+}
+
+// Scenario: An emissive material emits its emission color times its strength
+// Given m ← emissive_material(color(1, 0.5, 0.25), 4)
+// Then emitted(m) = color(4, 2, 1)
+// And m is emissive
+// And m reflects no light
+func Test_an_Emissive_Material_Emits_its_Emission_Color_times_its_Strength(t *testing.T) {
+	// Given
+	m := NewEmissiveMaterial(colors.NewColor(1, 0.5, 0.25), 4)
+	// Expected
+	wanted := colors.NewColor(4, 2, 1)
+	// Then
+	if !wanted.Equals(m.Emitted()) {
+		t.Errorf("Emitted() = %v, expected %v", m.Emitted(), wanted)
+	}
+	// And
+	if !m.IsEmissive() {
+		t.Errorf("IsEmissive() = false for %v, expected true", m)
+	}
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.White())
+	result := m.Lighting(light, tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, -1), tuples.NewNormal(0, 0, -1), false)
+	if !colors.Black().Equals(result) {
+		t.Errorf("Lighting() = %v, expected %v", result, colors.Black())
+	}
+}
+
+// Scenario: Reflectivity, transparency and refractive index for the default material
+// Given m ← material()
+// Then m.reflective = 0.0
+// And m.transparency = 0.0
+// And m.refractive_index = 1.0
+func Test_Reflectivity_Transparency_and_Refractive_Index_for_the_Default_Material(t *testing.T) {
+	// Given
+	m := DefaultMaterial()
+	// Then
+	if m.Reflective != 0 {
+		t.Errorf("Reflective = %v, expected %v", m.Reflective, 0.0)
+	}
+	// And
+	if m.Transparency != 0 {
+		t.Errorf("Transparency = %v, expected %v", m.Transparency, 0.0)
+	}
+	// And
+	if m.RefractiveIndex != 1 {
+		t.Errorf("RefractiveIndex = %v, expected %v", m.RefractiveIndex, 1.0)
+	}
+	// And
+	if m.IsSpecular() {
+		t.Errorf("IsSpecular() = true for %v, expected false", m)
+	}
+}
+
+// Scenario: A glass material passes and reflects light
+// Given m ← glass_material(1.5)
+// Then m.reflective = 1.0
+// And m.transparency = 1.0
+// And m.refractive_index = 1.5
+// And m is specular
+func Test_a_Glass_Material_Passes_and_Reflects_Light(t *testing.T) {
+	// Given
+	m := NewGlassMaterial(1.5)
+	// Then
+	if m.Reflective != 1 || m.Transparency != 1 || m.RefractiveIndex != 1.5 {
+		t.Errorf("NewGlassMaterial( 1.5 ) has reflective %v, transparency %v and refractive index %v, expected 1, 1 and 1.5",
+			m.Reflective, m.Transparency, m.RefractiveIndex)
+	}
+	// And
+	if !m.IsSpecular() {
+		t.Errorf("IsSpecular() = false for %v, expected true", m)
+	}
+}
+
+// palette is a pattern that cannot be compared with ==, as it holds a slice
+type palette []colors.Color
+
+func (p palette) ColorAt(point tuples.Point) colors.Color {
+	return p[int(math.Floor(point.X))%len(p)]
+}
+
+// Scenario: Comparing materials with textures that cannot be compared with ==
+// Given m1 ← material() with a palette texture of black and white
+// And m2 ← material() with a palette texture of black and white
+// And m3 ← material() with a palette texture of white and black
+// Then m1 = m2
+// And m1 != m3
+func Test_Comparing_Materials_with_Textures_that_Cannot_be_Compared(t *testing.T) {
+	// Given
+	m1 := DefaultMaterial()
+	m1.Texture = palette{colors.Black(), colors.White()}
+	// And
+	m2 := DefaultMaterial()
+	m2.Texture = palette{colors.Black(), colors.White()}
+	// And
+	m3 := DefaultMaterial()
+	m3.Texture = palette{colors.White(), colors.Black()}
+	// Then
+	if !m1.Equals(m2) {
+		t.Errorf("%v does not equal %v, expected it to", m1, m2)
+	}
+	// And
+	if m1.Equals(m3) {
+		t.Errorf("%v equals %v, expected it not to", m1, m3)
+	}
+}
@@ -0,0 +1,114 @@
+package materials
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Model selects how a Material reflects light
+type Model int
+
+const (
+	// Phong is the classic model of the book, with ambient, diffuse and specular reflection
+	Phong Model = iota
+	// CookTorrance is a physically based model: a GGX microfacet specular lobe with Schlick's Fresnel
+	// and Smith's shadowing, over an energy-conserving Lambertian diffuse
+	CookTorrance
+)
+
+const (
+	// dielectricReflectance is the reflectance at normal incidence of common non-metals
+	dielectricReflectance = 0.04
+	// minRoughness keeps the highlight of a perfectly polished surface finite
+	minRoughness = 0.02
+)
+
+// String returns the name of the Model
+func (m Model) String() string {
+	switch m {
+	case Phong:
+		return "Phong"
+	case CookTorrance:
+		return "CookTorrance"
+	}
+	return fmt.Sprintf("Model(%d)", int(m))
+}
+
+// BRDF calculates how much of the light arriving from lightV is reflected towards eyeV by a CookTorrance material
+// All vectors point away from the surface. The result is zero when either direction is below the surface
+func (m Material) BRDF(normalV tuples.Normal, eyeV, lightV tuples.Vector) colors.Color {
+	nDotL := normalV.Dot(lightV)
+	nDotV := normalV.Dot(eyeV)
+	if nDotL <= 0 || nDotV <= 0 {
+		return colors.Black()
+	}
+	halfV := lightV.Add(eyeV).Normalize()
+	nDotH := math.Max(normalV.Dot(halfV), 0)
+	vDotH := math.Max(eyeV.Dot(halfV), 0)
+
+	roughness := math.Max(m.Roughness, minRoughness)
+	alpha := roughness * roughness
+	metallic := math.Min(math.Max(m.Metallic, 0), 1)
+
+	f0 := colors.NewColor(dielectricReflectance, dielectricReflectance, dielectricReflectance).
+		Multiply(1 - metallic).
+		Add(m.Color.Multiply(metallic))
+	fresnel := schlick(f0, vDotH)
+	distribution := ggx(nDotH, alpha)
+	// Smith's shadowing with Schlick's approximation, k = alpha / 2
+	k := alpha / 2
+	shadowing := smithG1(nDotL, k) * smithG1(nDotV, k)
+
+	specular := fresnel.Multiply(distribution * shadowing / (4 * nDotL * nDotV))
+	// the Lambertian diffuse (1 - F)(1 - metallic) base / π: light that is not reflected enters the surface,
+	// and is scattered back by dielectrics only; F is taken towards the eye, which keeps grazing views from
+	// reflecting more light than arrives
+	kd := colors.White().Subtract(schlick(f0, nDotV)).Multiply((1 - metallic) / math.Pi)
+	diffuse := kd.Blend(m.Color)
+	return diffuse.Add(specular)
+}
+
+// cookTorranceLighting calculates the effective color of a pixel with the CookTorrance model
+// Lights are scaled as in the Phong model, where a white surface facing a light of intensity 1 is about white:
+// the BRDF of such a Lambertian surface is 1 / π, so the reflected light is the BRDF times π
+func (m Material) cookTorranceLighting(
+	light lights.PointLight,
+	position tuples.Point,
+	eyeV tuples.Vector,
+	normalV tuples.Normal,
+	inShadow bool,
+) colors.Color {
+	ambient := m.Color.Blend(light.Intensity).Multiply(m.Ambient)
+	if inShadow {
+		return ambient
+	}
+	lightV := light.Position.Subtract(position).Normalize()
+	nDotL := normalV.Dot(lightV)
+	if nDotL <= 0 {
+		return ambient
+	}
+	reflected := m.BRDF(normalV, eyeV, lightV).Blend(light.Intensity).Multiply(math.Pi * nDotL)
+	return ambient.Add(reflected)
+}
+
+// ggx is the Trowbridge-Reitz normal distribution, the density of microfacets facing the half vector
+func ggx(nDotH, alpha float64) float64 {
+	alpha2 := alpha * alpha
+	d := nDotH*nDotH*(alpha2-1) + 1
+	return alpha2 / (math.Pi * d * d)
+}
+
+// smithG1 is the fraction of microfacets that is not hidden when seen from a direction
+func smithG1(nDotX, k float64) float64 {
+	return nDotX / (nDotX*(1-k) + k)
+}
+
+// schlick approximates the Fresnel reflectance for a reflectance f0 at normal incidence
+func schlick(f0 colors.Color, cosTheta float64) colors.Color {
+	weight := math.Pow(1-cosTheta, 5)
+	return f0.Add(colors.White().Subtract(f0).Multiply(weight))
+}
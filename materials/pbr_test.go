@@ -0,0 +1,162 @@
+package materials
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The default material uses the Phong model
+// Given m ← material()
+// Then m.model = Phong
+func Test_the_Default_Material_Uses_the_Phong_Model(t *testing.T) {
+	// Given
+	m := DefaultMaterial()
+	// Then
+	if m.Model != Phong {
+		t.Errorf("%v has model %v, expected %v", m, m.Model, Phong)
+	}
+}
+
+// Scenario: A rough dielectric lit head-on is nearly Lambertian
+// Given m ← pbr_material(color(1, 1, 1), roughness: 1, metallic: 0)
+// And m.ambient ← 0
+// And light ← point_light(point(0, 0, -10), color(1, 1, 1))
+// When result ← lighting(m, light, point(0, 0, 0), vector(0, 0, -1), normal(0, 0, -1), false)
+// Then result = color(0.97, 0.97, 0.97)
+func Test_a_Rough_Dielectric_Lit_Head_on_is_Nearly_Lambertian(t *testing.T) {
+	// Given
+	m := NewPBRMaterial(colors.White(), 1, 0)
+	// And
+	m.Ambient = 0
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.White())
+	// When
+	result := m.Lighting(light, tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, -1), tuples.NewNormal(0, 0, -1), false)
+	// Expected
+	wanted := colors.NewColor(0.97, 0.97, 0.97)
+	// Then
+	if !wanted.Equals(result) {
+		t.Errorf("Lighting() = %v, expected %v", result, wanted)
+	}
+}
+
+// Scenario: A metal has no diffuse reflection
+// Given m ← pbr_material(color(1, 0, 0), roughness: 0.3, metallic: 1)
+// When the eye looks at the surface away from the mirror direction of the light
+// Then the reflected light is nearly black
+// But a dielectric of the same color reflects red light diffusely
+func Test_a_Metal_has_no_Diffuse_Reflection(t *testing.T) {
+	// Given
+	metal := NewPBRMaterial(colors.NewColor(1, 0, 0), 0.3, 1)
+	dielectric := NewPBRMaterial(colors.NewColor(1, 0, 0), 0.3, 0)
+	normalV := tuples.NewNormal(0, 1, 0)
+	lightV := tuples.NewVector(1, 1, 0).Normalize()
+	eyeV := tuples.NewVector(1, 1, 0).Normalize()
+	// When
+	fromMetal := metal.BRDF(normalV, eyeV, lightV)
+	fromDielectric := dielectric.BRDF(normalV, eyeV, lightV)
+	// Then
+	if fromMetal.Luminance() > 1e-2 {
+		t.Errorf("BRDF of %v = %v, expected nearly black", metal, fromMetal)
+	}
+	// But
+	if fromDielectric.Red < 0.25 || fromDielectric.Green > 0.01 {
+		t.Errorf("BRDF of %v = %v, expected a diffuse red", dielectric, fromDielectric)
+	}
+}
+
+// Scenario: Fresnel makes a dielectric more reflective at grazing angles
+// Given m ← pbr_material(color(0, 0, 0), roughness: 0.2, metallic: 0)
+// When head_on ← brdf(m) for the light and eye along the normal
+// And grazing ← brdf(m) for the light and eye mirrored at 80° from the normal
+// Then grazing is brighter than head_on
+func Test_Fresnel_Makes_a_Dielectric_More_Reflective_at_Grazing_Angles(t *testing.T) {
+	// Given
+	m := NewPBRMaterial(colors.Black(), 0.2, 0)
+	normalV := tuples.NewNormal(0, 1, 0)
+	angle := 80 * math.Pi / 180
+	// When
+	headOn := m.BRDF(normalV, tuples.NewVector(0, 1, 0), tuples.NewVector(0, 1, 0))
+	cosHeadOn := 1.0
+	// And
+	grazing := m.BRDF(normalV, tuples.NewVector(math.Sin(angle), math.Cos(angle), 0), tuples.NewVector(-math.Sin(angle), math.Cos(angle), 0))
+	cosGrazing := math.Cos(angle)
+	// Then
+	if grazing.Luminance()*cosGrazing <= headOn.Luminance()*cosHeadOn {
+		t.Errorf("grazing reflection %v is not brighter than head-on reflection %v", grazing, headOn)
+	}
+}
+
+// Scenario Outline: The CookTorrance model does not create energy
+// Given m ← pbr_material(color(1, 1, 1), <roughness>, <metallic>)
+// And the eye at <angle> from the normal
+// When albedo ← the integral of brdf(m) * cos over the hemisphere of light directions
+// Then albedo ≤ 1
+// And albedo > 0.25
+func Test_the_CookTorrance_Model_does_not_Create_Energy(t *testing.T) {
+	normalV := tuples.NewNormal(0, 1, 0)
+	for _, roughness := range []float64{0.3, 0.6, 1} {
+		for _, metallic := range []float64{0, 1} {
+			for _, angle := range []float64{0, 45, 75} {
+				// Given
+				m := NewPBRMaterial(colors.White(), roughness, metallic)
+				// And
+				a := angle * math.Pi / 180
+				eyeV := tuples.NewVector(math.Sin(a), math.Cos(a), 0)
+				// When
+				albedo := hemisphereAlbedo(m, normalV, eyeV)
+				// Then
+				if albedo > 1+1e-3 {
+					t.Errorf("roughness %g, metallic %g at %g°: albedo = %g, expected at most 1", roughness, metallic, angle, albedo)
+				}
+				// And
+				if albedo < 0.25 {
+					t.Errorf("roughness %g, metallic %g at %g°: albedo = %g, expected more than 0.25", roughness, metallic, angle, albedo)
+				}
+			}
+		}
+	}
+}
+
+// hemisphereAlbedo integrates the luminance of the BRDF times the cosine over all light directions,
+// with the midpoint rule in theta and phi
+func hemisphereAlbedo(m Material, normalV tuples.Normal, eyeV tuples.Vector) float64 {
+	const steps = 400
+	dTheta := math.Pi / 2 / steps
+	dPhi := 2 * math.Pi / steps
+	sum := 0.0
+	for i := 0; i < steps; i++ {
+		theta := (float64(i) + 0.5) * dTheta
+		cosTheta, sinTheta := math.Cos(theta), math.Sin(theta)
+		for j := 0; j < steps; j++ {
+			phi := (float64(j) + 0.5) * dPhi
+			lightV := tuples.NewVector(sinTheta*math.Cos(phi), cosTheta, sinTheta*math.Sin(phi))
+			sum += m.BRDF(normalV, eyeV, lightV).Luminance() * cosTheta * sinTheta
+		}
+	}
+	return sum * dTheta * dPhi
+}
+
+// Scenario: A CookTorrance surface in shadow is only lit by the ambient light
+// Given m ← pbr_material(color(0.5, 0.5, 0.5), roughness: 0.5, metallic: 0)
+// And light ← point_light(point(0, 0, -10), color(1, 1, 1))
+// When result ← lighting(m, light, point(0, 0, 0), vector(0, 0, -1), normal(0, 0, -1), true)
+// Then result = color(0.05, 0.05, 0.05)
+func Test_a_CookTorrance_Surface_in_Shadow_is_only_Lit_by_the_Ambient_Light(t *testing.T) {
+	// Given
+	m := NewPBRMaterial(colors.NewColor(0.5, 0.5, 0.5), 0.5, 0)
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.White())
+	// When
+	result := m.Lighting(light, tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, -1), tuples.NewNormal(0, 0, -1), true)
+	// Expected
+	wanted := colors.NewColor(0.05, 0.05, 0.05)
+	// Then
+	if !wanted.Equals(result) {
+		t.Errorf("Lighting() = %v, expected %v", result, wanted)
+	}
+}
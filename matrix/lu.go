@@ -0,0 +1,144 @@
+package matrix
+
+import "math"
+
+// LU is the LU decomposition with partial pivoting of a square matrix A, such that P * A = L * U
+// L is lower triangular with ones on its diagonal and U is upper triangular,
+// both are stored in lu: L below the diagonal and U on and above it
+// P is the permutation that moves row pivot[i] of A to row i
+// The lengths of the rows of A are kept to tell whether A is singular
+type LU struct {
+	lu         *Matrix
+	pivot      []int
+	sign       float64
+	rowLengths []float64
+}
+
+// LU calculates the LU decomposition of a square Matrix using Gaussian elimination with partial pivoting,
+// or returns ErrNotSquare if the Matrix is not square
+// A singular matrix still has a decomposition, with a zero on the diagonal of U
+func (m Matrix) LU() (*LU, error) {
+	if !m.IsSquare() {
+		return nil, ErrNotSquare
+	}
+	n := m.rows
+	lu := Zero(n, n)
+	copy(lu.data, m.data)
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	for k := 0; k < n; k++ {
+		// use the row with the largest value in column k as pivot, to limit rounding errors
+		p := k
+		for row := k + 1; row < n; row++ {
+			if math.Abs(lu.Get(row, k)) > math.Abs(lu.Get(p, k)) {
+				p = row
+			}
+		}
+		if p != k {
+			for col := 0; col < n; col++ {
+				v := lu.Get(p, col)
+				lu.Set(p, col, lu.Get(k, col))
+				lu.Set(k, col, v)
+			}
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+		if lu.Get(k, k) == 0 {
+			continue
+		}
+		for row := k + 1; row < n; row++ {
+			factor := lu.Get(row, k) / lu.Get(k, k)
+			lu.Set(row, k, factor)
+			for col := k + 1; col < n; col++ {
+				lu.Set(row, col, lu.Get(row, col)-factor*lu.Get(k, col))
+			}
+		}
+	}
+	return &LU{lu: lu, pivot: pivot, sign: sign, rowLengths: m.rowLengths()}, nil
+}
+
+// L returns the lower triangular factor of the decomposition
+func (d LU) L() *Matrix {
+	n := d.lu.rows
+	l := Identity(n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < row; col++ {
+			l.Set(row, col, d.lu.Get(row, col))
+		}
+	}
+	return l
+}
+
+// U returns the upper triangular factor of the decomposition
+func (d LU) U() *Matrix {
+	n := d.lu.rows
+	u := Zero(n, n)
+	for row := 0; row < n; row++ {
+		for col := row; col < n; col++ {
+			u.Set(row, col, d.lu.Get(row, col))
+		}
+	}
+	return u
+}
+
+// P returns the permutation matrix of the decomposition
+func (d LU) P() *Matrix {
+	n := d.lu.rows
+	p := Zero(n, n)
+	for row, source := range d.pivot {
+		p.Set(row, source, 1)
+	}
+	return p
+}
+
+// Determinant calculates the determinant of the decomposed matrix, the signed product of the diagonal of U
+func (d LU) Determinant() float64 {
+	det := d.sign
+	for i := 0; i < d.lu.rows; i++ {
+		det *= d.lu.Get(i, i)
+	}
+	return det
+}
+
+// Solve solves A * x = b for x by forward and back substitution, for every column of b,
+// or returns ErrDimensionMismatch if b does not have as many rows as A
+// Solving a singular system results in infinite or NaN elements
+func (d LU) Solve(b Matrix) (*Matrix, error) {
+	n := d.lu.rows
+	if b.rows != n {
+		return nil, ErrDimensionMismatch
+	}
+	x := Zero(n, b.cols)
+	for col := 0; col < b.cols; col++ {
+		// L * y = P * b
+		for row := 0; row < n; row++ {
+			sum := b.Get(d.pivot[row], col)
+			for k := 0; k < row; k++ {
+				sum -= d.lu.Get(row, k) * x.Get(k, col)
+			}
+			x.Set(row, col, sum)
+		}
+		// U * x = y
+		for row := n - 1; row >= 0; row-- {
+			sum := x.Get(row, col)
+			for k := row + 1; k < n; k++ {
+				sum -= d.lu.Get(row, k) * x.Get(k, col)
+			}
+			x.Set(row, col, sum/d.lu.Get(row, row))
+		}
+	}
+	return x, nil
+}
+
+// Inverse calculates the inverse of the decomposed matrix by solving A * X = I,
+// or returns ErrNotInvertible if A is singular within the SingularityTolerance
+func (d LU) Inverse() (*Matrix, error) {
+	if isSingular(d.Determinant(), d.rowLengths) {
+		return nil, ErrNotInvertible
+	}
+	return d.Solve(*Identity(d.lu.rows))
+}
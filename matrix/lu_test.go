@@ -0,0 +1,249 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+// Scenario: The LU decomposition reconstructs the permuted matrix
+// Given the following 3x3 matrix A:
+// | 1 |  2 |  3 |
+// | 4 |  5 |  6 |
+// | 7 |  8 | 10 |
+// When D ← lu(A)
+// Then U is upper triangular
+// And L is lower triangular with ones on the diagonal
+// And P * A = L * U
+func Test_LU_Decomposition_Reconstructs_the_Permuted_Matrix(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+	})
+	// When
+	d, err := a.LU()
+	// Then
+	if err != nil {
+		t.Fatalf("LU( %v ) returned error %v", a, err)
+	}
+	l, u, p := d.L(), d.U(), d.P()
+	for row := 0; row < 3; row++ {
+		if l.Get(row, row) != 1 {
+			t.Errorf("L[%d, %d] = %9.6f, wanted 1", row, row, l.Get(row, row))
+		}
+		for col := row + 1; col < 3; col++ {
+			if l.Get(row, col) != 0 || u.Get(col, row) != 0 {
+				t.Errorf("L = %v and U = %v are not triangular", l, u)
+			}
+		}
+	}
+	// And
+	pa := p.Multiply(*a)
+	lu := l.Multiply(*u)
+	if !pa.Equals(*lu) {
+		t.Errorf("P * A = %v, L * U = %v, should be equal", pa, lu)
+	}
+}
+
+// Scenario: Pivoting avoids a zero on the diagonal
+// Given the following 2x2 matrix A:
+// | 0 | 1 |
+// | 1 | 1 |
+// When D ← lu(A)
+// Then P * A = L * U
+// And determinant(D) = -1
+func Test_LU_Decomposition_Pivots_Around_Zero(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{0, 1},
+		{1, 1},
+	})
+	// When
+	d, _ := a.LU()
+	// Then
+	pa := d.P().Multiply(*a)
+	lu := d.L().Multiply(*d.U())
+	if !pa.Equals(*lu) {
+		t.Errorf("P * A = %v, L * U = %v, should be equal", pa, lu)
+	}
+	// And
+	if det := d.Determinant(); math.Abs(det+1) > 1e-9 {
+		t.Errorf("Determinant of LU( %v ) = %9.6f, wanted -1", a, det)
+	}
+}
+
+// Scenario: A rectangular matrix has no LU decomposition
+// Given the following 2x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// When D, err ← lu(A)
+// Then err = ErrNotSquare
+func Test_Rectangular_Matrix_has_no_LU_Decomposition(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	// When
+	_, err := a.LU()
+	// Then
+	if err != ErrNotSquare {
+		t.Errorf("LU( %v ) returned error %v, wanted %v", a, err, ErrNotSquare)
+	}
+}
+
+// Scenario: Calculating the determinant of a 5x5 matrix
+// Given the following 5x5 matrix A:
+// |  2 | -1 |  0 |  3 |  1 |
+// |  4 |  1 | -2 |  0 |  5 |
+// | -3 |  2 |  6 |  1 |  0 |
+// |  1 |  0 | -1 |  2 | -4 |
+// |  0 |  3 |  2 | -5 |  1 |
+// Then determinant(A) = -796
+// And A * inverse(A) = identity_matrix
+func Test_Calculate_Determinant_and_Inverse_of_5x5_Matrix(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{2, -1, 0, 3, 1},
+		{4, 1, -2, 0, 5},
+		{-3, 2, 6, 1, 0},
+		{1, 0, -1, 2, -4},
+		{0, 3, 2, -5, 1},
+	})
+	// Then
+	if d := a.Determinant(); math.Abs(d+796) > 1e-9 {
+		t.Errorf("Determinant( %v ) = %9.6f, wanted %9.6f", a, d, -796.0)
+	}
+	// And
+	if b := a.Multiply(*a.Inverse()); !b.Equals(*Identity(5)) {
+		t.Errorf("a * inverse(a) = %v, wanted %v", b, Identity(5))
+	}
+}
+
+// Scenario: Inverting a singular 5x5 matrix fails
+// Given A ← a 5x5 matrix whose last row is the sum of the first two rows
+// When B, err ← checked_inverse(A)
+// Then err = ErrNotInvertible
+// And inverse(lu(A)) fails with ErrNotInvertible
+// And inverse(A) panics with ErrNotInvertible
+func Test_Inverting_a_Singular_5x5_Matrix_Fails(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{2, -1, 0, 3, 1},
+		{4, 1, -2, 0, 5},
+		{-3, 2, 6, 1, 0},
+		{1, 0, -1, 2, -4},
+		{6, 0, -2, 3, 6},
+	})
+	// When
+	b, err := a.CheckedInverse()
+	// Then
+	if err != ErrNotInvertible {
+		t.Errorf("CheckedInverse( %v ) = %v, %v, expected error %v", a, b, err, ErrNotInvertible)
+	}
+	// And
+	d, _ := a.LU()
+	if _, err := d.Inverse(); err != ErrNotInvertible {
+		t.Errorf("Inverse of LU( %v ) returned %v, expected error %v", a, err, ErrNotInvertible)
+	}
+	// And
+	defer func() {
+		if p := recover(); p != ErrNotInvertible {
+			t.Errorf("Inverse( %v ) panicked with %v, expected %v", a, p, ErrNotInvertible)
+		}
+	}()
+	a.Inverse()
+}
+
+// Scenario: Solving a system of linear equations
+// Given the following 3x3 matrix A:
+// |  2 |  1 | -1 |
+// | -3 | -1 |  2 |
+// | -2 |  1 |  2 |
+// And the following 3x1 matrix B:
+// |   8 |
+// | -11 |
+// |  -3 |
+// When X ← solve(A, B)
+// Then X is the following 3x1 matrix:
+// |  2 |
+// |  3 |
+// | -1 |
+func Test_Solving_a_System_of_Linear_Equations(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{2, 1, -1},
+		{-3, -1, 2},
+		{-2, 1, 2},
+	})
+	b := NewMatrix([][]float64{
+		{8},
+		{-11},
+		{-3},
+	})
+	// Expected
+	wanted := NewMatrix([][]float64{
+		{2},
+		{3},
+		{-1},
+	})
+	// When
+	x, err := a.Solve(*b)
+	// Then
+	if err != nil {
+		t.Fatalf("Solve( %v, %v ) returned error %v", a, b, err)
+	}
+	if !wanted.Equals(*x) {
+		t.Errorf("Solve( %v, %v ) = %v, wanted %v", a, b, x, wanted)
+	}
+}
+
+// Scenario: Solving a singular system of linear equations
+// Given the following 2x2 matrix A:
+// | 1 | 2 |
+// | 2 | 4 |
+// And the following 2x1 matrix B:
+// | 1 |
+// | 2 |
+// When X, err ← solve(A, B)
+// Then err = ErrNotInvertible
+func Test_Solving_a_Singular_System_of_Linear_Equations(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2},
+		{2, 4},
+	})
+	b := NewMatrix([][]float64{
+		{1},
+		{2},
+	})
+	// When
+	_, err := a.Solve(*b)
+	// Then
+	if err != ErrNotInvertible {
+		t.Errorf("Solve( %v, %v ) returned error %v, wanted %v", a, b, err, ErrNotInvertible)
+	}
+}
+
+// Scenario: Solving with a right-hand side of the wrong size
+// Given A ← identity_matrix of size 3
+// And the following 2x1 matrix B:
+// | 1 |
+// | 2 |
+// When X, err ← solve(A, B)
+// Then err = ErrDimensionMismatch
+func Test_Solving_with_Mismatching_Dimensions(t *testing.T) {
+	// Given
+	a := Identity(3)
+	b := NewMatrix([][]float64{
+		{1},
+		{2},
+	})
+	// When
+	_, err := a.Solve(*b)
+	// Then
+	if err != ErrDimensionMismatch {
+		t.Errorf("Solve( %v, %v ) returned error %v, wanted %v", a, b, err, ErrDimensionMismatch)
+	}
+}
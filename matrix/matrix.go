@@ -1,69 +1,98 @@
 package matrix
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
-// Matrix is a two dimensional array
+// ErrNotInvertible is returned when inverting a singular matrix
+var ErrNotInvertible = errors.New("matrix is not invertible")
+
+// ErrDimensionMismatch is returned when the dimensions of matrices do not fit the operation
+var ErrDimensionMismatch = errors.New("matrix dimensions do not match")
+
+// ErrRaggedRows is returned when the rows provided for a matrix differ in length
+var ErrRaggedRows = errors.New("matrix rows differ in length")
+
+// ErrNotSquare is returned when an operation that needs a square matrix gets a rectangular one
+var ErrNotSquare = errors.New("matrix is not square")
+
+// SingularityTolerance is the smallest ratio between the determinant of an invertible matrix
+// and the product of the lengths of its rows, which is the largest determinant rows of those lengths can have
+const SingularityTolerance = 1e-10
+
+// cofactorSize is the size up to which determinants and inverses are calculated from cofactors
+const cofactorSize = 4
+
+// Matrix is a two dimensional array of rows by columns
 type Matrix struct {
-	size int
+	rows int
+	cols int
 	data []float64
 }
 
-// NewMatrix constructs a new square Matrix from the provided data
+// NewMatrix constructs a new Matrix from the provided data, one slice per row
+// It panics when the rows differ in length, see CheckedNewMatrix
 func NewMatrix(data [][]float64) *Matrix {
-	size := len(data)
-	if size == 0 {
-		return &Matrix{size: 0, data: []float64{}}
+	m, err := CheckedNewMatrix(data)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// CheckedNewMatrix constructs a new Matrix from the provided data, one slice per row,
+// or returns ErrRaggedRows if the rows differ in length
+func CheckedNewMatrix(data [][]float64) (*Matrix, error) {
+	rows := len(data)
+	if rows == 0 {
+		return &Matrix{rows: 0, cols: 0, data: []float64{}}, nil
 	}
-	result := Matrix{size: size, data: make([]float64, size*size)}
+	cols := len(data[0])
+	result := Zero(rows, cols)
 
-	for row := 0; row < size; row++ {
-		for col := 0; col < size; col++ {
+	for row := 0; row < rows; row++ {
+		if len(data[row]) != cols {
+			return nil, ErrRaggedRows
+		}
+		for col := 0; col < cols; col++ {
 			result.Set(row, col, data[row][col])
-			// log.Printf("(%d, %d) = %f", row, col, data[row][col])
 		}
 	}
 
-	return &result
+	return result, nil
+}
+
+// Zero creates a matrix of the specified number of rows and columns filled with zeroes
+func Zero(rows int, cols int) *Matrix {
+	return &Matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
 }
 
 // Identity creates an identity matrix of the specified size
 func Identity(size int) *Matrix {
-	if size == 0 {
-		return &Matrix{size: 0, data: []float64{}}
+	i := Zero(size, size)
+	for d := 0; d < size; d++ {
+		i.Set(d, d, 1.0)
 	}
-	i := Matrix{size: size, data: make([]float64, size*size)}
-
-	for row := 0; row < size; row++ {
-		for col := 0; col < size; col++ {
-			v := 0.0
-			if row == col {
-				v = 1.0
-			}
-			i.Set(row, col, v)
-		}
-	}
-
-	return &i
+	return i
 }
 
 // String formats the Matrix as a string
 func (m Matrix) String() string {
 	r := "{ "
-	for row := 0; row < m.size; row++ {
+	for row := 0; row < m.rows; row++ {
 		r += "{"
-		for col := 0; col < m.size; col++ {
+		for col := 0; col < m.cols; col++ {
 			if col != 0 {
 				r += ", "
 			}
 			r += fmt.Sprintf(" %9.5f", m.Get(row, col))
 		}
 		r += " }"
-		if row != m.size-1 {
+		if row != m.rows-1 {
 			r += ","
 		}
 		r += "\n"
@@ -72,80 +101,151 @@ func (m Matrix) String() string {
 	return r
 }
 
+// Rows returns the number of rows of the Matrix
+func (m Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns of the Matrix
+func (m Matrix) Cols() int {
+	return m.cols
+}
+
+// IsSquare checks if the Matrix has as many rows as columns
+func (m Matrix) IsSquare() bool {
+	return m.rows == m.cols
+}
+
 // Get gets the value on the specified position
 func (m Matrix) Get(row int, col int) float64 {
-	return m.data[row*m.size+col]
+	return m.data[row*m.cols+col]
 }
 
 // Set sets the value on the specified position
 func (m Matrix) Set(row int, col int, v float64) {
-	m.data[row*m.size+col] = v
+	m.data[row*m.cols+col] = v
+}
+
+// Copy creates a new Matrix with the same values, that does not share its data with the Matrix
+func (m Matrix) Copy() *Matrix {
+	data := make([]float64, len(m.data))
+	copy(data, m.data)
+	return &Matrix{rows: m.rows, cols: m.cols, data: data}
 }
 
 // Equals checks if the Matrix is equal to another Matrix
 func (m Matrix) Equals(other Matrix) bool {
-	if m.size != other.size {
+	if m.rows != other.rows || m.cols != other.cols {
 		return false
 	}
-	for row := 0; row < m.size; row++ {
-		for col := 0; col < m.size; col++ {
-			if math.Abs(m.data[row*m.size+col]-other.data[row*m.size+col]) > tuples.Epsilon {
-				return false
-			}
+	for i := range m.data {
+		if math.Abs(m.data[i]-other.data[i]) > tuples.Epsilon {
+			return false
 		}
 	}
 	return true
 }
 
 // Multiply calculates the product of two matrices
+// It panics when the number of columns of m differs from the number of rows of other, see CheckedMultiply
 func (m Matrix) Multiply(other Matrix) *Matrix {
-	p := NewMatrix([][]float64{})
-	p.size = m.size
-	p.data = make([]float64, m.size*m.size)
-	for row := 0; row < p.size; row++ {
-		for col := 0; col < p.size; col++ {
-			p.Set(row, col, m.rowToTuple(row).Dot(other.columnToTuple(col)))
-		}
+	p, err := m.CheckedMultiply(other)
+	if err != nil {
+		panic(err)
 	}
 	return p
 }
 
-// MultiplyTuple calculates the product of two matrices
+// CheckedMultiply calculates the product of two matrices,
+// or returns ErrDimensionMismatch if the number of columns of m differs from the number of rows of other
+func (m Matrix) CheckedMultiply(other Matrix) (*Matrix, error) {
+	if m.cols != other.rows {
+		return nil, ErrDimensionMismatch
+	}
+	p := Zero(m.rows, other.cols)
+	for row := 0; row < p.rows; row++ {
+		for col := 0; col < p.cols; col++ {
+			sum := 0.0
+			for k := 0; k < m.cols; k++ {
+				sum += m.Get(row, k) * other.Get(k, col)
+			}
+			p.Set(row, col, sum)
+		}
+	}
+	return p, nil
+}
+
+// MultiplyTuple calculates the product of a 4x4 Matrix and a Tuple
+// It panics when the matrix is not 4x4, see CheckedMultiplyTuple
 func (m Matrix) MultiplyTuple(t tuples.Tuple) *tuples.Tuple {
+	p, err := m.CheckedMultiplyTuple(t)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// CheckedMultiplyTuple calculates the product of a 4x4 Matrix and a Tuple,
+// or returns ErrDimensionMismatch if the matrix is not 4x4
+func (m Matrix) CheckedMultiplyTuple(t tuples.Tuple) (*tuples.Tuple, error) {
+	if m.rows != 4 || m.cols != 4 {
+		return nil, ErrDimensionMismatch
+	}
 	p := &tuples.Tuple{}
 	p.X = m.rowToTuple(0).Dot(t)
 	p.Y = m.rowToTuple(1).Dot(t)
 	p.Z = m.rowToTuple(2).Dot(t)
 	p.W = m.rowToTuple(3).Dot(t)
-	return p
+	return p, nil
+}
+
+// MultiplyPoint transforms a Point by a 4x4 Matrix
+func (m Matrix) MultiplyPoint(p tuples.Point) *tuples.Point {
+	t := m.MultiplyTuple(p.Tuple())
+	return &tuples.Point{X: t.X, Y: t.Y, Z: t.Z}
+}
+
+// MultiplyVector transforms a Vector by a 4x4 Matrix, which ignores the translation
+func (m Matrix) MultiplyVector(v tuples.Vector) *tuples.Vector {
+	t := m.MultiplyTuple(v.Tuple())
+	return &tuples.Vector{X: t.X, Y: t.Y, Z: t.Z}
 }
 
 // Transpose transposes a Matrix, ie. mirrors it along the r=c diagonal
 func (m Matrix) Transpose() *Matrix {
-	t := NewMatrix([][]float64{})
-	t.size = m.size
-	t.data = make([]float64, len(m.data))
-	for row := 0; row < t.size; row++ {
-		for col := 0; col < t.size; col++ {
+	t := Zero(m.cols, m.rows)
+	for row := 0; row < t.rows; row++ {
+		for col := 0; col < t.cols; col++ {
 			t.Set(row, col, m.Get(col, row))
 		}
 	}
 	return t
 }
 
-// Determinant calculates the Determinant of a matrix
+// Determinant calculates the Determinant of a square matrix
+// Matrices up to cofactorSize are expanded in cofactors, which is exact for integer elements,
+// larger ones are calculated in O(n³) from their LU decomposition
+// The determinant of a matrix that is not square is NaN, that of the empty 0x0 matrix is 1
 func (m Matrix) Determinant() float64 {
-	if m.size == 1 {
+	if !m.IsSquare() {
+		return math.NaN()
+	}
+	if m.rows <= cofactorSize {
+		return m.cofactorDeterminant()
+	}
+	lu, _ := m.LU()
+	return lu.Determinant()
+}
+
+func (m Matrix) cofactorDeterminant() float64 {
+	if m.rows == 0 {
+		return 1
+	}
+	if m.rows == 1 {
 		return m.Get(0, 0)
 	}
-	// if m.size == 2 {
-	// 	d := m.Get(0, 0)*m.Get(1, 1) - m.Get(0, 1)*m.Get(1, 0)
-	// 	return d
-	// }
-	//fmt.Printf("m.size: %d \n", m.size)
 	d := 0.0
-	for col := 0; col < m.size; col++ {
-		// fmt.Printf("m(0, %d) = %f , cov(m, 1, %d) = %f\n", col, m.Get(0, col), col, m.Cofactor(1, col))
+	for col := 0; col < m.cols; col++ {
 		d += m.Get(0, col) * m.Cofactor(0, col)
 	}
 	return d
@@ -154,15 +254,13 @@ func (m Matrix) Determinant() float64 {
 // Submatrix returns the specified submatrix of a Matrix
 // it removes the specified row an column
 func (m Matrix) Submatrix(row int, col int) *Matrix {
-	sub := NewMatrix([][]float64{})
-	sub.size = m.size - 1
-	sub.data = make([]float64, sub.size*sub.size)
-	for srow := 0; srow < m.size; srow++ {
+	sub := Zero(m.rows-1, m.cols-1)
+	for srow := 0; srow < m.rows; srow++ {
 		trow := srow
 		if trow > row {
 			trow--
 		}
-		for scol := 0; scol < m.size; scol++ {
+		for scol := 0; scol < m.cols; scol++ {
 			tcol := scol
 			if tcol > col {
 				tcol--
@@ -192,32 +290,88 @@ func (m Matrix) Cofactor(row int, col int) float64 {
 	return sub.Determinant()
 }
 
-// IsInvertible checks if a Matrix is invertible, ie the determinant is not 0
+// IsInvertible checks if a Matrix is invertible, ie the determinant is not 0 within the SingularityTolerance
 func (m Matrix) IsInvertible() bool {
-	return m.Determinant() != 0.0
+	return !isSingular(m.Determinant(), m.rowLengths())
 }
 
-// Inverse calculates the inverse of a Matrix
-// Create a matrix of the cofactors of m
-// Transpose matrix
-// Divide matrix through Det(m)
+// CheckedInverse calculates the inverse of a Matrix, or returns ErrNotInvertible if it is singular
+func (m Matrix) CheckedInverse() (*Matrix, error) {
+	if m.IsSquare() && m.rows > cofactorSize {
+		lu, err := m.LU()
+		if err != nil {
+			return nil, err
+		}
+		return lu.Inverse()
+	}
+	if !m.IsInvertible() {
+		return nil, ErrNotInvertible
+	}
+	return m.Inverse(), nil
+}
+
+// Inverse calculates the inverse of a square Matrix
+// Matrices up to cofactorSize are inverted by dividing the transposed matrix of cofactors by the determinant,
+// larger ones in O(n³) from their LU decomposition
+// The result of inverting a singular matrix up to cofactorSize has infinite or NaN elements, see CheckedInverse;
+// a larger singular matrix panics with ErrNotInvertible, as rounding in its decomposition would give finite elements
+// It panics when the Matrix is not square
 func (m Matrix) Inverse() *Matrix {
+	if !m.IsSquare() {
+		panic(ErrNotSquare)
+	}
+	if m.rows > cofactorSize {
+		inverse, err := m.CheckedInverse()
+		if err != nil {
+			panic(err)
+		}
+		return inverse
+	}
 	detM := m.Determinant()
-	i := NewMatrix([][]float64{})
-	i.size = m.size
-	i.data = make([]float64, i.size*i.size)
-	for row := 0; row < m.size; row++ {
-		for col := 0; col < m.size; col++ {
+	i := Zero(m.rows, m.cols)
+	for row := 0; row < m.rows; row++ {
+		for col := 0; col < m.cols; col++ {
 			i.Set(col, row, m.Cofactor(row, col)/detM)
 		}
 	}
 	return i
 }
 
-func (m Matrix) rowToTuple(row int) tuples.Tuple {
-	return tuples.Tuple{X: m.Get(row, 0), Y: m.Get(row, 1), Z: m.Get(row, 2), W: m.Get(row, 3)}
+// Solve solves the linear system m * x = b for x, where b has a column for every right-hand side
+func (m Matrix) Solve(b Matrix) (*Matrix, error) {
+	lu, err := m.LU()
+	if err != nil {
+		return nil, err
+	}
+	if isSingular(lu.Determinant(), lu.rowLengths) {
+		return nil, ErrNotInvertible
+	}
+	return lu.Solve(b)
 }
 
-func (m Matrix) columnToTuple(col int) tuples.Tuple {
-	return tuples.Tuple{X: m.Get(0, col), Y: m.Get(1, col), Z: m.Get(2, col), W: m.Get(3, col)}
+func (m Matrix) rowLengths() []float64 {
+	lengths := make([]float64, m.rows)
+	for row := 0; row < m.rows; row++ {
+		sum := 0.0
+		for col := 0; col < m.cols; col++ {
+			sum += m.Get(row, col) * m.Get(row, col)
+		}
+		lengths[row] = math.Sqrt(sum)
+	}
+	return lengths
+}
+
+// isSingular compares the determinant to the largest possible determinant of rows with the same lengths,
+// which makes the check independent of the scale of the matrix
+func isSingular(determinant float64, rowLengths []float64) bool {
+	bound := 1.0
+	for _, length := range rowLengths {
+		bound *= length
+	}
+	return bound == 0 || math.IsNaN(determinant) || math.Abs(determinant) <= SingularityTolerance*bound
+}
+
+// rowToTuple returns a row of a matrix with 4 columns as a Tuple
+func (m Matrix) rowToTuple(row int) tuples.Tuple {
+	return tuples.Tuple{X: m.Get(row, 0), Y: m.Get(row, 1), Z: m.Get(row, 2), W: m.Get(row, 3)}
 }
@@ -0,0 +1,210 @@
+package matrix
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Matrix4 is a 4x4 matrix stored by value, for fast transformations of Tuples
+type Matrix4 [4][4]float64
+
+// Identity4 creates a 4x4 identity matrix
+func Identity4() Matrix4 {
+	return Matrix4{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// ToMatrix4 converts a 4x4 Matrix to a Matrix4
+// It panics when the matrix is not 4x4, see CheckedToMatrix4
+func (m Matrix) ToMatrix4() Matrix4 {
+	result, err := m.CheckedToMatrix4()
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// CheckedToMatrix4 converts a 4x4 Matrix to a Matrix4,
+// or returns ErrDimensionMismatch if the matrix is not 4x4
+func (m Matrix) CheckedToMatrix4() (Matrix4, error) {
+	if m.rows != 4 || m.cols != 4 {
+		return Matrix4{}, ErrDimensionMismatch
+	}
+	var result Matrix4
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			result[row][col] = m.Get(row, col)
+		}
+	}
+	return result, nil
+}
+
+// ToMatrix converts a Matrix4 to a Matrix
+func (m Matrix4) ToMatrix() *Matrix {
+	return NewMatrix([][]float64{m[0][:], m[1][:], m[2][:], m[3][:]})
+}
+
+// String formats the Matrix4 as a string
+func (m Matrix4) String() string {
+	return m.ToMatrix().String()
+}
+
+// Equals checks if the Matrix4 is equal to another Matrix4
+func (m Matrix4) Equals(other Matrix4) bool {
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			if math.Abs(m[row][col]-other[row][col]) > tuples.Epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Multiply calculates the product of two matrices
+func (m Matrix4) Multiply(other Matrix4) Matrix4 {
+	var p Matrix4
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			p[row][col] = m[row][0]*other[0][col] +
+				m[row][1]*other[1][col] +
+				m[row][2]*other[2][col] +
+				m[row][3]*other[3][col]
+		}
+	}
+	return p
+}
+
+// MultiplyTuple calculates the product of the matrix and a Tuple
+func (m Matrix4) MultiplyTuple(t tuples.Tuple) tuples.Tuple {
+	return tuples.Tuple{
+		X: m[0][0]*t.X + m[0][1]*t.Y + m[0][2]*t.Z + m[0][3]*t.W,
+		Y: m[1][0]*t.X + m[1][1]*t.Y + m[1][2]*t.Z + m[1][3]*t.W,
+		Z: m[2][0]*t.X + m[2][1]*t.Y + m[2][2]*t.Z + m[2][3]*t.W,
+		W: m[3][0]*t.X + m[3][1]*t.Y + m[3][2]*t.Z + m[3][3]*t.W,
+	}
+}
+
+// MultiplyPoint transforms a Point by the matrix
+func (m Matrix4) MultiplyPoint(p tuples.Point) tuples.Point {
+	return tuples.Point{
+		X: m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z + m[0][3],
+		Y: m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z + m[1][3],
+		Z: m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z + m[2][3],
+	}
+}
+
+// MultiplyVector transforms a Vector by the matrix, which ignores the translation
+func (m Matrix4) MultiplyVector(v tuples.Vector) tuples.Vector {
+	return tuples.Vector{
+		X: m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		Y: m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		Z: m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// TransformNormal transforms a Normal by the transformation of which the matrix is the inverse,
+// by multiplying it with the transpose of the matrix
+// Normals have no w component, so the translation in the inverse cannot leak into the result
+// The result is not normalized
+func (m Matrix4) TransformNormal(n tuples.Normal) tuples.Normal {
+	return tuples.Normal{
+		X: m[0][0]*n.X + m[1][0]*n.Y + m[2][0]*n.Z,
+		Y: m[0][1]*n.X + m[1][1]*n.Y + m[2][1]*n.Z,
+		Z: m[0][2]*n.X + m[1][2]*n.Y + m[2][2]*n.Z,
+	}
+}
+
+// Transpose mirrors the matrix along the r=c diagonal
+func (m Matrix4) Transpose() Matrix4 {
+	var t Matrix4
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			t[row][col] = m[col][row]
+		}
+	}
+	return t
+}
+
+// subDeterminants calculates the determinants of the 2x2 submatrices of the top two rows (s)
+// and of the bottom two rows (c), from which both the determinant and the inverse are built
+func (m Matrix4) subDeterminants() ([6]float64, [6]float64) {
+	s := [6]float64{
+		m[0][0]*m[1][1] - m[1][0]*m[0][1],
+		m[0][0]*m[1][2] - m[1][0]*m[0][2],
+		m[0][0]*m[1][3] - m[1][0]*m[0][3],
+		m[0][1]*m[1][2] - m[1][1]*m[0][2],
+		m[0][1]*m[1][3] - m[1][1]*m[0][3],
+		m[0][2]*m[1][3] - m[1][2]*m[0][3],
+	}
+	c := [6]float64{
+		m[2][0]*m[3][1] - m[3][0]*m[2][1],
+		m[2][0]*m[3][2] - m[3][0]*m[2][2],
+		m[2][0]*m[3][3] - m[3][0]*m[2][3],
+		m[2][1]*m[3][2] - m[3][1]*m[2][2],
+		m[2][1]*m[3][3] - m[3][1]*m[2][3],
+		m[2][2]*m[3][3] - m[3][2]*m[2][3],
+	}
+	return s, c
+}
+
+// Determinant calculates the determinant of the matrix in closed form
+func (m Matrix4) Determinant() float64 {
+	s, c := m.subDeterminants()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// IsInvertible checks if the matrix is invertible, ie the determinant is not 0 within the SingularityTolerance
+func (m Matrix4) IsInvertible() bool {
+	var lengths [4]float64
+	for row := 0; row < 4; row++ {
+		lengths[row] = math.Sqrt(m[row][0]*m[row][0] + m[row][1]*m[row][1] + m[row][2]*m[row][2] + m[row][3]*m[row][3])
+	}
+	return !isSingular(m.Determinant(), lengths[:])
+}
+
+// CheckedInverse calculates the inverse of the matrix, or returns ErrNotInvertible if it is singular
+func (m Matrix4) CheckedInverse() (Matrix4, error) {
+	if !m.IsInvertible() {
+		return Matrix4{}, ErrNotInvertible
+	}
+	return m.Inverse(), nil
+}
+
+// Inverse calculates the inverse of the matrix in closed form
+func (m Matrix4) Inverse() Matrix4 {
+	s, c := m.subDeterminants()
+	invDet := 1 / (s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0])
+
+	return Matrix4{
+		{
+			(m[1][1]*c[5] - m[1][2]*c[4] + m[1][3]*c[3]) * invDet,
+			(-m[0][1]*c[5] + m[0][2]*c[4] - m[0][3]*c[3]) * invDet,
+			(m[3][1]*s[5] - m[3][2]*s[4] + m[3][3]*s[3]) * invDet,
+			(-m[2][1]*s[5] + m[2][2]*s[4] - m[2][3]*s[3]) * invDet,
+		},
+		{
+			(-m[1][0]*c[5] + m[1][2]*c[2] - m[1][3]*c[1]) * invDet,
+			(m[0][0]*c[5] - m[0][2]*c[2] + m[0][3]*c[1]) * invDet,
+			(-m[3][0]*s[5] + m[3][2]*s[2] - m[3][3]*s[1]) * invDet,
+			(m[2][0]*s[5] - m[2][2]*s[2] + m[2][3]*s[1]) * invDet,
+		},
+		{
+			(m[1][0]*c[4] - m[1][1]*c[2] + m[1][3]*c[0]) * invDet,
+			(-m[0][0]*c[4] + m[0][1]*c[2] - m[0][3]*c[0]) * invDet,
+			(m[3][0]*s[4] - m[3][1]*s[2] + m[3][3]*s[0]) * invDet,
+			(-m[2][0]*s[4] + m[2][1]*s[2] - m[2][3]*s[0]) * invDet,
+		},
+		{
+			(-m[1][0]*c[3] + m[1][1]*c[1] - m[1][2]*c[0]) * invDet,
+			(m[0][0]*c[3] - m[0][1]*c[1] + m[0][2]*c[0]) * invDet,
+			(-m[3][0]*s[3] + m[3][1]*s[1] - m[3][2]*s[0]) * invDet,
+			(m[2][0]*s[3] - m[2][1]*s[1] + m[2][2]*s[0]) * invDet,
+		},
+	}
+}
@@ -0,0 +1,232 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: Converting a Matrix to a Matrix4 and back
+// Given A ← the 4x4 matrix with rows (1, 2, 3, 4), (5, 6, 7, 8), (9, 8, 7, 6), (5, 4, 3, 2)
+// When B ← to_matrix4(A)
+// Then B[1][2] = 7
+// And to_matrix(B) = A
+func Test_Converting_a_Matrix_to_a_Matrix4_and_Back(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 8, 7, 6},
+		{5, 4, 3, 2},
+	})
+	// When
+	b := a.ToMatrix4()
+	// Then
+	if b[1][2] != 7 {
+		t.Errorf("B[1][2] = %9.6f, expected %9.6f", b[1][2], 7.0)
+	}
+	// And
+	if !a.Equals(*b.ToMatrix()) {
+		t.Errorf("ToMatrix(%v) = %v, expected %v", b, b.ToMatrix(), a)
+	}
+}
+
+// Scenario: Only a 4x4 matrix can be converted to a Matrix4
+// Given A ← identity_matrix of size 3
+// When _, err ← checked_to_matrix4(A)
+// Then err = ErrDimensionMismatch
+func Test_Only_a_4x4_Matrix_can_be_Converted_to_a_Matrix4(t *testing.T) {
+	// Given
+	a := Identity(3)
+	// When
+	_, err := a.CheckedToMatrix4()
+	// Then
+	if err != ErrDimensionMismatch {
+		t.Errorf("CheckedToMatrix4(%v) returned error %v, expected %v", a, err, ErrDimensionMismatch)
+	}
+}
+
+// Scenario: Multiplying two 4x4 matrices by value
+// Given A and B as in the matrix multiplication scenario
+// Then A * B as Matrix4 = A * B as Matrix
+func Test_Multiplying_Two_4x4_Matrices_by_Value(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 8, 7, 6},
+		{5, 4, 3, 2},
+	})
+	b := NewMatrix([][]float64{
+		{-2, 1, 2, 3},
+		{3, 2, 1, -1},
+		{4, 3, 6, 5},
+		{1, 2, 7, 8},
+	})
+	// Expected
+	wanted := a.Multiply(*b).ToMatrix4()
+	// When
+	p := a.ToMatrix4().Multiply(b.ToMatrix4())
+	// Then
+	if !wanted.Equals(p) {
+		t.Errorf("%v * %v = %v, expected %v", a, b, p, wanted)
+	}
+}
+
+// Scenario: Multiplying a Matrix4 by a tuple
+// Given A ← the 4x4 matrix with rows (1, 2, 3, 4), (2, 4, 4, 2), (8, 6, 4, 1), (0, 0, 0, 1)
+// And b ← tuple(1, 2, 3, 1)
+// Then A * b = tuple(18, 24, 33, 1)
+func Test_Multiplying_a_Matrix4_by_a_Tuple(t *testing.T) {
+	// Given
+	a := Matrix4{
+		{1, 2, 3, 4},
+		{2, 4, 4, 2},
+		{8, 6, 4, 1},
+		{0, 0, 0, 1},
+	}
+	// And
+	b := tuples.Tuple{X: 1, Y: 2, Z: 3, W: 1}
+	// Expected
+	wanted := tuples.Tuple{X: 18, Y: 24, Z: 33, W: 1}
+	// When
+	p := a.MultiplyTuple(b)
+	// Then
+	if !wanted.Equals(p) {
+		t.Errorf("%v * %v = %v, expected %v", a, b, p, wanted)
+	}
+}
+
+// Scenario: Calculating the determinant of a Matrix4 in closed form
+// Given A ← the 4x4 matrix with rows (-2, -8, 3, 5), (-3, 1, 7, 3), (1, 2, -9, 6), (-6, 7, 7, -9)
+// Then determinant(A) = -4071
+func Test_Calculating_the_Determinant_of_a_Matrix4_in_Closed_Form(t *testing.T) {
+	// Given
+	a := Matrix4{
+		{-2, -8, 3, 5},
+		{-3, 1, 7, 3},
+		{1, 2, -9, 6},
+		{-6, 7, 7, -9},
+	}
+	// Then
+	if d := a.Determinant(); d != -4071 {
+		t.Errorf("det( %v ) = %9.6f, expected %9.6f", a, d, -4071.0)
+	}
+}
+
+// Scenario: Calculating the inverse of a Matrix4 in closed form
+// Given A ← the 4x4 matrices of the inverse scenarios
+// Then inverse(A) as Matrix4 = inverse(A) as Matrix
+// And A * inverse(A) = identity_matrix
+func Test_Calculating_the_Inverse_of_a_Matrix4_in_Closed_Form(t *testing.T) {
+	// Given
+	matrices := []*Matrix{
+		NewMatrix([][]float64{
+			{-5, 2, 6, -8},
+			{1, -5, 1, 8},
+			{7, 7, -6, -7},
+			{1, -3, 7, 4},
+		}),
+		NewMatrix([][]float64{
+			{8, -5, 9, 2},
+			{7, 5, 6, 1},
+			{-6, 0, 9, 6},
+			{-3, 0, -9, -4},
+		}),
+		NewMatrix([][]float64{
+			{9, 3, 0, 9},
+			{-5, -2, -6, -3},
+			{-4, 9, 6, 4},
+			{-7, 6, 6, 2},
+		}),
+	}
+	for _, a := range matrices {
+		// Expected
+		wanted := a.Inverse().ToMatrix4()
+		// When
+		inverse := a.ToMatrix4().Inverse()
+		// Then
+		if !wanted.Equals(inverse) {
+			t.Errorf("Inverse( %v ) = %v, expected %v", a, inverse, wanted)
+		}
+		// And
+		if p := a.ToMatrix4().Multiply(inverse); !Identity4().Equals(p) {
+			t.Errorf("%v * Inverse( %v ) = %v, expected %v", a, a, p, Identity4())
+		}
+	}
+}
+
+// Scenario: Multiplying a Matrix4 by a point and a vector
+// Given A ← translation(5, -3, 2) as Matrix4
+// Then A * point(-3, 4, 5) = point(2, 1, 7)
+// And A * vector(-3, 4, 5) = vector(-3, 4, 5)
+func Test_Multiplying_a_Matrix4_by_a_Point_and_a_Vector(t *testing.T) {
+	// Given
+	a := Matrix4{
+		{1, 0, 0, 5},
+		{0, 1, 0, -3},
+		{0, 0, 1, 2},
+		{0, 0, 0, 1},
+	}
+	// Expected
+	wantedP := tuples.NewPoint(2, 1, 7)
+	wantedV := tuples.NewVector(-3, 4, 5)
+	// Then
+	if p := a.MultiplyPoint(tuples.NewPoint(-3, 4, 5)); !wantedP.Equals(p) {
+		t.Errorf("%v * point(-3, 4, 5) = %v, expected %v", a, p, wantedP)
+	}
+	// And
+	if v := a.MultiplyVector(wantedV); !wantedV.Equals(v) {
+		t.Errorf("%v * %v = %v, expected %v", a, wantedV, v, wantedV)
+	}
+}
+
+// Scenario: A normal is transformed by the inverse transpose
+// Given M ← translation(5, 5, 5) * scaling(1, 2, 1) as Matrix4
+// And n ← normal(1, 1, 0)
+// When N ← transform_normal(inverse(M), n)
+// Then N = normal(1, 0.5, 0)
+func Test_a_Normal_is_Transformed_by_the_Inverse_Transpose(t *testing.T) {
+	// Given
+	m := Matrix4{
+		{1, 0, 0, 5},
+		{0, 2, 0, 5},
+		{0, 0, 1, 5},
+		{0, 0, 0, 1},
+	}
+	n := tuples.NewNormal(1, 1, 0)
+	// Expected
+	wanted := tuples.NewNormal(1, 0.5, 0)
+	// When
+	r := m.Inverse().TransformNormal(n)
+	// Then
+	if !wanted.Equals(r) {
+		t.Errorf("TransformNormal( inverse(%v), %v ) = %v, expected %v", m, n, r, wanted)
+	}
+}
+
+// BenchmarkMatrixInverse inverts a 4x4 Matrix by cofactor expansion
+func BenchmarkMatrixInverse(b *testing.B) {
+	a := NewMatrix([][]float64{
+		{-5, 2, 6, -8},
+		{1, -5, 1, 8},
+		{7, 7, -6, -7},
+		{1, -3, 7, 4},
+	})
+	for i := 0; i < b.N; i++ {
+		a.Inverse()
+	}
+}
+
+// BenchmarkMatrix4Inverse inverts a Matrix4 in closed form
+func BenchmarkMatrix4Inverse(b *testing.B) {
+	a := Matrix4{
+		{-5, 2, 6, -8},
+		{1, -5, 1, 8},
+		{7, 7, -6, -7},
+		{1, -3, 7, 4},
+	}
+	for i := 0; i < b.N; i++ {
+		a.Inverse()
+	}
+}
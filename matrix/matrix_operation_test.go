@@ -553,4 +553,246 @@ func Test_Transposing_and_Inverting_a_Matrix(t *testing.T) {
 	if !c.Equals(*b) {
 		t.Errorf("inverse(transpose(a)) = %v , transpose(inverse(a)) = %v, should be equal", b, c)
 	}
-}
\ No newline at end of file
+}
+// Scenario: Inverting a singular matrix fails
+// Given A ← the 4x4 matrix of scaling(40, 40, 0)
+// When B, err ← checked_inverse(A)
+// Then err = ErrNotInvertible
+func Test_Inverting_a_Singular_Matrix_Fails(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{40, 0, 0, 0},
+		{0, 40, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 1},
+	})
+	// When
+	b, err := a.CheckedInverse()
+	// Then
+	if err != ErrNotInvertible {
+		t.Errorf("CheckedInverse( %v ) = %v, %v, expected error %v", a, b, err, ErrNotInvertible)
+	}
+	// And
+	if _, err := a.ToMatrix4().CheckedInverse(); err != ErrNotInvertible {
+		t.Errorf("Matrix4 CheckedInverse( %v ) returned %v, expected error %v", a, err, ErrNotInvertible)
+	}
+}
+
+// Scenario: A nearly singular matrix is not invertible
+// Given A ← a matrix whose third row is the sum of the first two rows plus 1e-12
+// Then A is not invertible
+func Test_a_Nearly_Singular_Matrix_is_not_Invertible(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3, 0},
+		{4, 5, 6, 0},
+		{5, 7, 9 + 1e-12, 0},
+		{0, 0, 0, 1},
+	})
+	// Then
+	if a.IsInvertible() {
+		t.Errorf("%v is invertible, expected it not to be", a)
+	}
+	// And
+	if a.ToMatrix4().IsInvertible() {
+		t.Errorf("Matrix4 %v is invertible, expected it not to be", a)
+	}
+}
+
+// Scenario: A tiny scaling is invertible
+// Given A ← the 4x4 matrix of scaling(1e-6, 1e-6, 1e-6)
+// When B, err ← checked_inverse(A)
+// Then err = nil
+// And B = scaling(1e6, 1e6, 1e6)
+func Test_a_Tiny_Scaling_is_Invertible(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1e-6, 0, 0, 0},
+		{0, 1e-6, 0, 0},
+		{0, 0, 1e-6, 0},
+		{0, 0, 0, 1},
+	})
+	// When
+	b, err := a.CheckedInverse()
+	// Expected
+	wanted := NewMatrix([][]float64{
+		{1e6, 0, 0, 0},
+		{0, 1e6, 0, 0},
+		{0, 0, 1e6, 0},
+		{0, 0, 0, 1},
+	})
+	// Then
+	if err != nil {
+		t.Fatalf("CheckedInverse( %v ) returned error %v", a, err)
+	}
+	// And
+	if !wanted.Equals(*b) {
+		t.Errorf("CheckedInverse( %v ) = %v, expected %v", a, b, wanted)
+	}
+}
+
+// Scenario: Multiplying rectangular matrices
+// Given the following 2x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// And the following 3x2 matrix B:
+// |  7 |  8 |
+// |  9 | 10 |
+// | 11 | 12 |
+// Then A * B is the following 2x2 matrix:
+// |  58 |  64 |
+// | 139 | 154 |
+func Test_Multiplying_Rectangular_Matrices(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	b := NewMatrix([][]float64{
+		{7, 8},
+		{9, 10},
+		{11, 12},
+	})
+	// Expected
+	wanted := NewMatrix([][]float64{
+		{58, 64},
+		{139, 154},
+	})
+	// When
+	c, err := a.CheckedMultiply(*b)
+	// Then
+	if err != nil {
+		t.Fatalf("CheckedMultiply( %v, %v ) returned error %v", a, b, err)
+	}
+	if !wanted.Equals(*c) {
+		t.Errorf("%v * %v = %v, wanted %v", a, b, c, wanted)
+	}
+}
+
+// Scenario: Multiplying matrices with mismatching dimensions
+// Given the following 2x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// When C, err ← checked_multiply(A, A)
+// Then err = ErrDimensionMismatch
+func Test_Multiplying_Matrices_with_Mismatching_Dimensions(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	// When
+	_, err := a.CheckedMultiply(*a)
+	// Then
+	if err != ErrDimensionMismatch {
+		t.Errorf("CheckedMultiply( %v, %v ) returned error %v, wanted %v", a, a, err, ErrDimensionMismatch)
+	}
+}
+
+// Scenario: Multiplying a matrix that is not 4x4 by a tuple
+// Given the following 3x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// | 7 | 8 | 9 |
+// And b ← tuple(1, 2, 3, 1)
+// When c, err ← checked_multiply_tuple(A, b)
+// Then err = ErrDimensionMismatch
+func Test_Multiplying_a_Matrix_that_is_not_4x4_by_a_Tuple(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+	// And
+	b := tuples.Tuple{X: 1, Y: 2, Z: 3, W: 1}
+	// When
+	_, err := a.CheckedMultiplyTuple(b)
+	// Then
+	if err != ErrDimensionMismatch {
+		t.Errorf("CheckedMultiplyTuple( %v, %v ) returned error %v, wanted %v", a, b, err, ErrDimensionMismatch)
+	}
+}
+
+// Scenario: Constructing a matrix from rows of different lengths
+// Given data ← [[1, 2, 3], [4, 5]]
+// When A, err ← checked_new_matrix(data)
+// Then err = ErrRaggedRows
+func Test_Constructing_a_Matrix_from_Rows_of_Different_Lengths(t *testing.T) {
+	// Given
+	data := [][]float64{
+		{1, 2, 3},
+		{4, 5},
+	}
+	// When
+	_, err := CheckedNewMatrix(data)
+	// Then
+	if err != ErrRaggedRows {
+		t.Errorf("CheckedNewMatrix( %v ) returned error %v, wanted %v", data, err, ErrRaggedRows)
+	}
+}
+
+// Scenario: Transposing a rectangular matrix
+// Given the following 2x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// Then transpose(A) is the following 3x2 matrix:
+// | 1 | 4 |
+// | 2 | 5 |
+// | 3 | 6 |
+func Test_Transposing_a_Rectangular_Matrix(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	// Expected
+	wanted := NewMatrix([][]float64{
+		{1, 4},
+		{2, 5},
+		{3, 6},
+	})
+	// When
+	b := a.Transpose()
+	// Then
+	if b.Rows() != 3 || b.Cols() != 2 {
+		t.Errorf("Size of transpose( %v ) = %dx%d, wanted 3x2", a, b.Rows(), b.Cols())
+	}
+	if !wanted.Equals(*b) {
+		t.Errorf("transpose( %v ) = %v, wanted %v", a, b, wanted)
+	}
+}
+
+// Scenario: A rectangular matrix has no determinant
+// Given the following 2x3 matrix A:
+// | 1 | 2 | 3 |
+// | 4 | 5 | 6 |
+// Then determinant(A) is NaN
+// And A is not invertible
+func Test_a_Rectangular_Matrix_has_no_Determinant(t *testing.T) {
+	// Given
+	a := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	// Then
+	if d := a.Determinant(); !math.IsNaN(d) {
+		t.Errorf("Determinant( %v ) = %9.6f, wanted NaN", a, d)
+	}
+	// And
+	if a.IsInvertible() {
+		t.Errorf("%v is invertible, expected it not to be", a)
+	}
+}
+
+// Scenario: The determinant of the empty matrix is 1
+// Given A ← the 0x0 matrix
+// Then determinant(A) = 1
+func Test_the_Determinant_of_the_Empty_Matrix_is_1(t *testing.T) {
+	// Given
+	a := Zero(0, 0)
+	// Then
+	if det := a.Determinant(); det != 1 {
+		t.Errorf("Determinant( %v ) = %9.6f, expected %9.6f", a, det, 1.0)
+	}
+}
@@ -38,8 +38,8 @@ func Test_Construct_and_Inspect_4x4_Matrix(t *testing.T) {
 		{3, 2, 15.5},
 	}
 	// Then
-	if m.size != 4 {
-		t.Errorf("Size of Matrix = %d, wanted %d", m.size, 4)
+	if m.rows != 4 || m.cols != 4 {
+		t.Errorf("Size of Matrix = %dx%d, wanted %dx%d", m.rows, m.cols, 4, 4)
 	}
 	for i := 0; i < len(cases); i++ {
 		c := cases[i]
@@ -69,8 +69,8 @@ func Test_2x2_Matrix(t *testing.T) {
 		{1, 1, -2},
 	}
 	// Then
-	if m.size != 2 {
-		t.Errorf("Size of Matrix = %d, wanted %d", m.size, 2)
+	if m.rows != 2 || m.cols != 2 {
+		t.Errorf("Size of Matrix = %dx%d, wanted %dx%d", m.rows, m.cols, 2, 2)
 	}
 	for i := 0; i < len(cases); i++ {
 		c := cases[i]
@@ -104,8 +104,8 @@ func Test_3x3_Matrix(t *testing.T) {
 		{2, 2, 1},
 	}
 	// Then
-	if m.size != 3 {
-		t.Errorf("Size of Matrix = %d, wanted %d", m.size, 3)
+	if m.rows != 3 || m.cols != 3 {
+		t.Errorf("Size of Matrix = %dx%d, wanted %dx%d", m.rows, m.cols, 3, 3)
 	}
 	for i := 0; i < len(cases); i++ {
 		c := cases[i]
@@ -225,6 +225,29 @@ func Test_Multiplying_Identity_by_a_Tuple(t *testing.T) {
 	}
 }
 
+// Scenario: A copy of a matrix does not share its values
+// Given A ← identity_matrix
+// And B ← copy(A)
+// When B[0,1] ← 5
+// Then A = identity_matrix
+// And B[0,1] = 5
+func Test_a_Copy_of_a_Matrix_does_not_Share_its_Values(t *testing.T) {
+	// Given
+	a := Identity(4)
+	// And
+	b := a.Copy()
+	// When
+	b.Set(0, 1, 5)
+	// Then
+	if !Identity(4).Equals(*a) {
+		t.Errorf("%v, expected the identity matrix after changing its copy", a)
+	}
+	// And
+	if v := b.Get(0, 1); v != 5 {
+		t.Errorf("copy[0,1] = %v, expected 5", v)
+	}
+}
+
 // Scenario: Transposing a matrix
 // Given the following matrix A:
 // | 0 | 9 | 3 | 0 |
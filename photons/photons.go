@@ -0,0 +1,180 @@
+package photons
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Photon is a packet of light that arrived at a point on a surface, travelling in a direction
+type Photon struct {
+	Position  tuples.Point
+	Direction tuples.Vector
+	Power     colors.Color
+}
+
+// Map stores photons in a balanced kd-tree, to find the photons nearest to a point quickly
+// The tree is kept in a slice: the photon splitting a range of the slice lies in its middle,
+// with the photons below it along its axis before it and the others after it
+type Map struct {
+	photons []Photon
+	axes    []int
+}
+
+// NewPhoton creates a new Photon
+func NewPhoton(position tuples.Point, direction tuples.Vector, power colors.Color) Photon {
+	return Photon{position, direction, power}
+}
+
+// String formats the Photon as a string
+func (p Photon) String() string {
+	return fmt.Sprintf("Photon( %v, %v, %v )", p.Position, p.Direction, p.Power)
+}
+
+// NewMap builds a Map of the photons
+func NewMap(photons []Photon) *Map {
+	m := &Map{
+		photons: append([]Photon(nil), photons...),
+		axes:    make([]int, len(photons)),
+	}
+	m.build(0, len(m.photons))
+	return m
+}
+
+// Len returns the number of photons in the map
+func (m *Map) Len() int {
+	return len(m.photons)
+}
+
+// Photons returns the photons in the map, in no particular order
+func (m *Map) Photons() []Photon {
+	return m.photons
+}
+
+// Nearest finds at most k photons within maxDistance of a point, nearest first
+func (m *Map) Nearest(p tuples.Point, k int, maxDistance float64) []Photon {
+	if k <= 0 {
+		return nil
+	}
+	found := &neighbors{}
+	m.search(0, len(m.photons), p, k, maxDistance*maxDistance, found)
+	sort.Sort(sort.Reverse(found))
+	result := make([]Photon, found.Len())
+	for i, n := range *found {
+		result[i] = n.photon
+	}
+	return result
+}
+
+// build arranges the photons in [lo, hi) into a tree, split along the axis in which they are spread the most
+func (m *Map) build(lo, hi int) {
+	if hi-lo <= 0 {
+		return
+	}
+	axis := widestAxis(m.photons[lo:hi])
+	mid := (lo + hi) / 2
+	part := m.photons[lo:hi]
+	sort.Slice(part, func(i, j int) bool {
+		return coordinate(part[i].Position, axis) < coordinate(part[j].Position, axis)
+	})
+	m.axes[mid] = axis
+	m.build(lo, mid)
+	m.build(mid+1, hi)
+}
+
+// search adds the photons in [lo, hi) nearer than the farthest of the k found so far, within maxDistanceSquared
+func (m *Map) search(lo, hi int, p tuples.Point, k int, maxDistanceSquared float64, found *neighbors) {
+	if hi-lo <= 0 {
+		return
+	}
+	mid := (lo + hi) / 2
+	photon := m.photons[mid]
+	offset := coordinate(p, m.axes[mid]) - coordinate(photon.Position, m.axes[mid])
+
+	near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+	if offset > 0 {
+		near, far = far, near
+	}
+	m.search(near[0], near[1], p, k, maxDistanceSquared, found)
+
+	v := p.Subtract(photon.Position)
+	if d := v.Dot(v); d <= maxDistanceSquared {
+		found.add(neighbor{photon, d}, k)
+	}
+	// the photons on the far side of the splitting plane are at least the offset away
+	limit := maxDistanceSquared
+	if found.Len() == k {
+		limit = math.Min(limit, (*found)[0].distanceSquared)
+	}
+	if offset*offset <= limit {
+		m.search(far[0], far[1], p, k, maxDistanceSquared, found)
+	}
+}
+
+// widestAxis finds the axis along which the photons are spread the most
+func widestAxis(photons []Photon) int {
+	min := [3]float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+	max := [3]float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	for _, photon := range photons {
+		for axis := 0; axis < 3; axis++ {
+			c := coordinate(photon.Position, axis)
+			min[axis] = math.Min(min[axis], c)
+			max[axis] = math.Max(max[axis], c)
+		}
+	}
+	result := 0
+	for axis := 1; axis < 3; axis++ {
+		if max[axis]-min[axis] > max[result]-min[result] {
+			result = axis
+		}
+	}
+	return result
+}
+
+func coordinate(p tuples.Point, axis int) float64 {
+	switch axis {
+	case 0:
+		return p.X
+	case 1:
+		return p.Y
+	}
+	return p.Z
+}
+
+// neighbor is a photon found near a point, with its squared distance to the point
+type neighbor struct {
+	photon          Photon
+	distanceSquared float64
+}
+
+// neighbors is a max-heap of the photons found so far, with the farthest on top
+type neighbors []neighbor
+
+func (n neighbors) Len() int           { return len(n) }
+func (n neighbors) Less(i, j int) bool { return n[i].distanceSquared > n[j].distanceSquared }
+func (n neighbors) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+
+func (n *neighbors) Push(x interface{}) { *n = append(*n, x.(neighbor)) }
+
+func (n *neighbors) Pop() interface{} {
+	old := *n
+	last := old[len(old)-1]
+	*n = old[:len(old)-1]
+	return last
+}
+
+// add keeps the photon when fewer than k photons are found, or when it is nearer than the farthest of them
+func (n *neighbors) add(candidate neighbor, k int) {
+	if n.Len() < k {
+		heap.Push(n, candidate)
+		return
+	}
+	if candidate.distanceSquared < (*n)[0].distanceSquared {
+		(*n)[0] = candidate
+		heap.Fix(n, 0)
+	}
+}
@@ -0,0 +1,80 @@
+package photons
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: An empty photon map finds no photons
+// Given m ← photon_map([])
+// When found ← nearest(m, point(0, 0, 0), 10, 1)
+// Then found is empty
+func Test_an_Empty_Photon_Map_Finds_no_Photons(t *testing.T) {
+	// Given
+	m := NewMap(nil)
+	// When
+	found := m.Nearest(tuples.NewPoint(0, 0, 0), 10, 1)
+	// Then
+	if len(found) != 0 {
+		t.Errorf("Nearest() = %v, expected no photons", found)
+	}
+}
+
+// Scenario: A photon map finds the nearest photons within the maximum distance
+// Given photons ← 2000 photons at random points in the cube from -1 to 1
+// And m ← photon_map(photons)
+// When found ← nearest(m, p, k, max_distance) for random points p and several k and max_distance
+// Then found are the k nearest photons within max_distance of p, nearest first, as found by checking every photon
+func Test_a_Photon_Map_Finds_the_Nearest_Photons_within_the_Maximum_Distance(t *testing.T) {
+	// Given
+	rng := rand.New(rand.NewSource(11))
+	random := func() tuples.Point {
+		return tuples.NewPoint(2*rng.Float64()-1, 2*rng.Float64()-1, 2*rng.Float64()-1)
+	}
+	photons := make([]Photon, 2000)
+	for i := range photons {
+		photons[i] = NewPhoton(random(), tuples.NewVector(0, -1, 0), colors.White())
+	}
+	// And
+	m := NewMap(photons)
+	for i := 0; i < 20; i++ {
+		p := random()
+		for _, k := range []int{1, 8, 50} {
+			for _, maxDistance := range []float64{0.05, 0.2, math.Inf(1)} {
+				// When
+				found := m.Nearest(p, k, maxDistance)
+				// Then
+				wanted := bruteForceNearest(photons, p, k, maxDistance)
+				if len(found) != len(wanted) {
+					t.Fatalf("Nearest( %v, %d, %v ) found %d photons, expected %d", p, k, maxDistance, len(found), len(wanted))
+				}
+				for j := range found {
+					if !found[j].Position.Equals(wanted[j].Position) {
+						t.Errorf("Nearest( %v, %d, %v )[%d] = %v, expected %v", p, k, maxDistance, j, found[j], wanted[j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func bruteForceNearest(photons []Photon, p tuples.Point, k int, maxDistance float64) []Photon {
+	distance := func(photon Photon) float64 {
+		return p.Subtract(photon.Position).Magnitude()
+	}
+	sorted := append([]Photon(nil), photons...)
+	sort.Slice(sorted, func(i, j int) bool { return distance(sorted[i]) < distance(sorted[j]) })
+	result := make([]Photon, 0, k)
+	for _, photon := range sorted {
+		if len(result) == k || distance(photon) > maxDistance {
+			break
+		}
+		result = append(result, photon)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package precision
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// RelativeEquality is the equality tolerance as a fraction of the scale of a scene
+const RelativeEquality = 1e-8
+
+// RelativeRayOffset is the distance rays start from a surface as a fraction of the scale of a scene
+const RelativeRayOffset = 1e-6
+
+// Tolerance separates the precision with which values are compared from the distance by which
+// rays leaving a surface are moved off of it, to keep them from hitting the surface they leave
+// ("acne") because of rounding errors
+// Rounding errors grow with the magnitude of the coordinates, so both are best chosen relative to the
+// scale of the scene: fixed values cause acne in very large scenes and miss details in very small ones
+type Tolerance struct {
+	Equality  float64
+	RayOffset float64
+}
+
+// Default returns the fixed Tolerance of tuples.Epsilon, suitable for scenes of a scale around 10
+func Default() Tolerance {
+	return Tolerance{Equality: tuples.Epsilon, RayOffset: tuples.Epsilon}
+}
+
+// ForScale returns the Tolerance for a scene of the specified scale, see bounds.Box.Scale
+// A scene without scale, like an empty one, gets the Default Tolerance
+func ForScale(scale float64) Tolerance {
+	if scale <= 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+		return Default()
+	}
+	return Tolerance{Equality: scale * RelativeEquality, RayOffset: scale * RelativeRayOffset}
+}
+
+// String formats the Tolerance as a string
+func (t Tolerance) String() string {
+	return fmt.Sprintf("Tolerance( %g, %g )", t.Equality, t.RayOffset)
+}
+
+// IsZero checks if the Tolerance is unset
+func (t Tolerance) IsZero() bool {
+	return t.Equality == 0 && t.RayOffset == 0
+}
+
+// Equal checks if two values are equal within the equality tolerance
+func (t Tolerance) Equal(a, b float64) bool {
+	return math.Abs(a-b) <= t.Equality
+}
@@ -0,0 +1,74 @@
+package precision
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The tolerance grows with the scale of the scene
+// Given t1 ← tolerance_for_scale(1e-6)
+// And t2 ← tolerance_for_scale(1e9)
+// Then t1.equality = 1e-6 * RelativeEquality
+// And t1.ray_offset = 1e-6 * RelativeRayOffset
+// And t2.ray_offset = 1e9 * RelativeRayOffset
+// And the ray offset exceeds the equality tolerance
+func Test_the_Tolerance_Grows_with_the_Scale_of_the_Scene(t *testing.T) {
+	// Given
+	t1 := ForScale(1e-6)
+	// And
+	t2 := ForScale(1e9)
+	// Then
+	if t1.Equality != 1e-6*RelativeEquality {
+		t.Errorf("%v has equality %g, expected %g", t1, t1.Equality, 1e-6*RelativeEquality)
+	}
+	// And
+	if t1.RayOffset != 1e-6*RelativeRayOffset {
+		t.Errorf("%v has ray offset %g, expected %g", t1, t1.RayOffset, 1e-6*RelativeRayOffset)
+	}
+	// And
+	if t2.RayOffset != 1e9*RelativeRayOffset {
+		t.Errorf("%v has ray offset %g, expected %g", t2, t2.RayOffset, 1e9*RelativeRayOffset)
+	}
+	// And
+	for _, tolerance := range []Tolerance{t1, t2, Default()} {
+		if tolerance.RayOffset < tolerance.Equality {
+			t.Errorf("%v has a ray offset below its equality tolerance", tolerance)
+		}
+	}
+}
+
+// Scenario: A scene without scale gets the default tolerance
+// Given the scales 0, +Inf and NaN
+// Then tolerance_for_scale(scale) = default_tolerance()
+// And default_tolerance() = tolerance(EPSILON, EPSILON)
+func Test_a_Scene_without_Scale_Gets_the_Default_Tolerance(t *testing.T) {
+	for _, scale := range []float64{0, math.Inf(1), math.NaN()} {
+		// Then
+		if tolerance := ForScale(scale); tolerance != Default() {
+			t.Errorf("ForScale( %g ) = %v, expected %v", scale, tolerance, Default())
+		}
+	}
+	// And
+	if d := Default(); d.Equality != tuples.Epsilon || d.RayOffset != tuples.Epsilon {
+		t.Errorf("Default() = %v, expected Tolerance( %g, %g )", d, tuples.Epsilon, tuples.Epsilon)
+	}
+}
+
+// Scenario: Comparing values within the equality tolerance
+// Given t ← tolerance_for_scale(1e6)
+// Then equal(t, 1e6, 1e6 + 0.001) is true
+// And equal(t, 1e6, 1e6 + 0.1) is false
+func Test_Comparing_Values_within_the_Equality_Tolerance(t *testing.T) {
+	// Given
+	tolerance := ForScale(1e6)
+	// Then
+	if !tolerance.Equal(1e6, 1e6+0.001) {
+		t.Errorf("%v considers 1e6 and 1e6 + 0.001 different", tolerance)
+	}
+	// And
+	if tolerance.Equal(1e6, 1e6+0.1) {
+		t.Errorf("%v considers 1e6 and 1e6 + 0.1 equal", tolerance)
+	}
+}
@@ -0,0 +1,177 @@
+package quaternions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Quaternion models w + xi + yj + zk
+// Unit quaternions describe rotations without gimbal lock, and interpolate smoothly between them
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewQuaternion creates a new Quaternion
+func NewQuaternion(w, x, y, z float64) Quaternion {
+	return Quaternion{w, x, y, z}
+}
+
+// Identity creates the Quaternion that does not rotate
+func Identity() Quaternion {
+	return Quaternion{1, 0, 0, 0}
+}
+
+// FromAxisAngle creates the Quaternion that rotates r radians around an axis through the origin,
+// in the same direction as transformations.Rotation
+func FromAxisAngle(axis tuples.Vector, r float64) Quaternion {
+	a := axis.Normalize()
+	s := math.Sin(r / 2)
+	return Quaternion{math.Cos(r / 2), a.X * s, a.Y * s, a.Z * s}
+}
+
+// FromEuler creates the Quaternion that rotates x radians around the x-axis, then y around the y-axis
+// and then z around the z-axis, like RotationZ(z) * RotationY(y) * RotationX(x)
+func FromEuler(x, y, z float64) Quaternion {
+	qx := FromAxisAngle(tuples.NewVector(1, 0, 0), x)
+	qy := FromAxisAngle(tuples.NewVector(0, 1, 0), y)
+	qz := FromAxisAngle(tuples.NewVector(0, 0, 1), z)
+	return qz.Multiply(qy).Multiply(qx)
+}
+
+// FromMatrix creates the Quaternion of the rotation in the upper left 3x3 part of a transformation matrix
+// The matrix must be a pure rotation, without scaling or shearing
+func FromMatrix(m matrix.Matrix) Quaternion {
+	trace := m.Get(0, 0) + m.Get(1, 1) + m.Get(2, 2)
+	var q Quaternion
+	switch {
+	case trace > 0:
+		s := 2 * math.Sqrt(trace+1)
+		q = Quaternion{
+			W: s / 4,
+			X: (m.Get(2, 1) - m.Get(1, 2)) / s,
+			Y: (m.Get(0, 2) - m.Get(2, 0)) / s,
+			Z: (m.Get(1, 0) - m.Get(0, 1)) / s,
+		}
+	case m.Get(0, 0) > m.Get(1, 1) && m.Get(0, 0) > m.Get(2, 2):
+		s := 2 * math.Sqrt(1+m.Get(0, 0)-m.Get(1, 1)-m.Get(2, 2))
+		q = Quaternion{
+			W: (m.Get(2, 1) - m.Get(1, 2)) / s,
+			X: s / 4,
+			Y: (m.Get(0, 1) + m.Get(1, 0)) / s,
+			Z: (m.Get(0, 2) + m.Get(2, 0)) / s,
+		}
+	case m.Get(1, 1) > m.Get(2, 2):
+		s := 2 * math.Sqrt(1+m.Get(1, 1)-m.Get(0, 0)-m.Get(2, 2))
+		q = Quaternion{
+			W: (m.Get(0, 2) - m.Get(2, 0)) / s,
+			X: (m.Get(0, 1) + m.Get(1, 0)) / s,
+			Y: s / 4,
+			Z: (m.Get(1, 2) + m.Get(2, 1)) / s,
+		}
+	default:
+		s := 2 * math.Sqrt(1+m.Get(2, 2)-m.Get(0, 0)-m.Get(1, 1))
+		q = Quaternion{
+			W: (m.Get(1, 0) - m.Get(0, 1)) / s,
+			X: (m.Get(0, 2) + m.Get(2, 0)) / s,
+			Y: (m.Get(1, 2) + m.Get(2, 1)) / s,
+			Z: s / 4,
+		}
+	}
+	return q.Normalize()
+}
+
+// String formats the Quaternion as a string
+func (q Quaternion) String() string {
+	return fmt.Sprintf("Quaternion( %9.5f, %9.5f, %9.5f, %9.5f )", q.W, q.X, q.Y, q.Z)
+}
+
+// Equals checks if two Quaternions are equal
+// Note that q and -q describe the same rotation, but are not equal
+func (q Quaternion) Equals(other Quaternion) bool {
+	return math.Abs(q.W-other.W) < tuples.Epsilon &&
+		math.Abs(q.X-other.X) < tuples.Epsilon &&
+		math.Abs(q.Y-other.Y) < tuples.Epsilon &&
+		math.Abs(q.Z-other.Z) < tuples.Epsilon
+}
+
+// Add adds a Quaternion to the current Quaternion
+func (q Quaternion) Add(other Quaternion) Quaternion {
+	return Quaternion{q.W + other.W, q.X + other.X, q.Y + other.Y, q.Z + other.Z}
+}
+
+// Scale multiplies a Quaternion with a scalar
+func (q Quaternion) Scale(factor float64) Quaternion {
+	return Quaternion{q.W * factor, q.X * factor, q.Y * factor, q.Z * factor}
+}
+
+// Dot calculates the dot product of two Quaternions
+func (q Quaternion) Dot(other Quaternion) float64 {
+	return q.W*other.W + q.X*other.X + q.Y*other.Y + q.Z*other.Z
+}
+
+// Magnitude calculates the magnitude of a Quaternion
+func (q Quaternion) Magnitude() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize scales a Quaternion to unit magnitude
+func (q Quaternion) Normalize() Quaternion {
+	return q.Scale(1 / q.Magnitude())
+}
+
+// Conjugate negates the imaginary part of a Quaternion
+// For a unit Quaternion this is the inverse, the rotation in the opposite direction
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Multiply calculates the Hamilton product of two Quaternions
+// Like with matrices, the rotation q * other applies other first and then q
+func (q Quaternion) Multiply(other Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+		X: q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		Y: q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		Z: q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+	}
+}
+
+// Rotate rotates a Vector by a unit Quaternion
+func (q Quaternion) Rotate(v tuples.Vector) tuples.Vector {
+	r := q.Multiply(Quaternion{0, v.X, v.Y, v.Z}).Multiply(q.Conjugate())
+	return tuples.NewVector(r.X, r.Y, r.Z)
+}
+
+// Slerp interpolates spherically between two unit Quaternions, at constant angular speed along the shortest path
+// t = 0 results in q, t = 1 results in other or -other
+func (q Quaternion) Slerp(other Quaternion, t float64) Quaternion {
+	cosTheta := q.Dot(other)
+	// take the shortest path around the sphere
+	if cosTheta < 0 {
+		other = other.Scale(-1)
+		cosTheta = -cosTheta
+	}
+	// nearly parallel, fall back to linear interpolation to avoid dividing by sin(0)
+	if cosTheta > 1-tuples.Epsilon {
+		return q.Scale(1 - t).Add(other.Scale(t)).Normalize()
+	}
+	theta := math.Acos(cosTheta)
+	sinTheta := math.Sin(theta)
+	return q.Scale(math.Sin((1-t)*theta) / sinTheta).Add(other.Scale(math.Sin(t*theta) / sinTheta))
+}
+
+// ToMatrix converts a unit Quaternion to a 4x4 rotation matrix
+func (q Quaternion) ToMatrix() *matrix.Matrix {
+	return matrix.NewMatrix([][]float64{
+		{1 - 2*(q.Y*q.Y+q.Z*q.Z), 2 * (q.X*q.Y - q.W*q.Z), 2 * (q.X*q.Z + q.W*q.Y), 0},
+		{2 * (q.X*q.Y + q.W*q.Z), 1 - 2*(q.X*q.X+q.Z*q.Z), 2 * (q.Y*q.Z - q.W*q.X), 0},
+		{2 * (q.X*q.Z - q.W*q.Y), 2 * (q.Y*q.Z + q.W*q.X), 1 - 2*(q.X*q.X+q.Y*q.Y), 0},
+		{0, 0, 0, 1},
+	})
+}
@@ -0,0 +1,201 @@
+package quaternions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The identity quaternion does not rotate
+// Given q ← identity_quaternion
+// Then matrix(q) = identity_matrix
+// And rotate(q, vector(1, 2, 3)) = vector(1, 2, 3)
+func Test_the_Identity_Quaternion_does_not_Rotate(t *testing.T) {
+	// Given
+	q := Identity()
+	p := tuples.NewVector(1, 2, 3)
+	// Then
+	if m := q.ToMatrix(); !matrix.Identity(4).Equals(*m) {
+		t.Errorf("%v.ToMatrix() = %v, expected the identity matrix", q, m)
+	}
+	// And
+	if r := q.Rotate(p); !p.Equals(r) {
+		t.Errorf("%v.Rotate( %v ) = %v, expected %v", q, p, r, p)
+	}
+}
+
+// Scenario: An axis-angle quaternion rotates around the axis
+// Given q ← quaternion_from_axis_angle(vector(0, 0, 2), π / 2)
+// Then rotate(q, vector(0, 1, 0)) = vector(-1, 0, 0)
+// And rotate(q, vector(0, 0, 1)) = vector(0, 0, 1)
+func Test_an_Axis_Angle_Quaternion_Rotates_around_the_Axis(t *testing.T) {
+	// Given
+	q := FromAxisAngle(tuples.NewVector(0, 0, 2), math.Pi/2)
+	// Expected
+	wantedP := tuples.NewVector(-1, 0, 0)
+	v := tuples.NewVector(0, 0, 1)
+	// Then
+	if r := q.Rotate(tuples.NewVector(0, 1, 0)); !wantedP.Equals(r) {
+		t.Errorf("%v.Rotate( vector(0, 1, 0) ) = %v, expected %v", q, r, wantedP)
+	}
+	// And
+	if r := q.Rotate(v); !v.Equals(r) {
+		t.Errorf("%v.Rotate( %v ) = %v, expected %v", q, v, r, v)
+	}
+}
+
+// Scenario: Rotating around the diagonal cycles the axes
+// Given q ← quaternion_from_axis_angle(vector(1, 1, 1), 2π / 3)
+// Then rotate(q, vector(1, 0, 0)) = vector(0, 1, 0)
+// And matrix(q) * vector(0, 1, 0) = vector(0, 0, 1)
+func Test_a_Quaternion_around_the_Diagonal_Cycles_the_Axes(t *testing.T) {
+	// Given
+	q := FromAxisAngle(tuples.NewVector(1, 1, 1), 2*math.Pi/3)
+	// Expected
+	wantedX := tuples.NewVector(0, 1, 0)
+	wantedY := tuples.NewVector(0, 0, 1)
+	// Then
+	if r := q.Rotate(tuples.NewVector(1, 0, 0)); !wantedX.Equals(r) {
+		t.Errorf("%v.Rotate( vector(1, 0, 0) ) = %v, expected %v", q, r, wantedX)
+	}
+	// And
+	if r := q.ToMatrix().MultiplyVector(tuples.NewVector(0, 1, 0)); !wantedY.Equals(*r) {
+		t.Errorf("%v.ToMatrix() * vector(0, 1, 0) = %v, expected %v", q, r, wantedY)
+	}
+}
+
+// Scenario: An Euler quaternion rotates around x, then y, then z
+// Given q ← quaternion_from_euler(π / 2, π / 2, 0)
+// Then rotate(q, vector(0, 1, 0)) = vector(1, 0, 0)
+// And quaternion_from_euler(0, 0, π / 2) = quaternion_from_axis_angle(vector(0, 0, 1), π / 2)
+func Test_an_Euler_Quaternion_Rotates_around_X_then_Y_then_Z(t *testing.T) {
+	// Given
+	q := FromEuler(math.Pi/2, math.Pi/2, 0)
+	// Expected
+	wanted := tuples.NewVector(1, 0, 0)
+	wantedZ := FromAxisAngle(tuples.NewVector(0, 0, 1), math.Pi/2)
+	// Then
+	if r := q.Rotate(tuples.NewVector(0, 1, 0)); !wanted.Equals(r) {
+		t.Errorf("%v.Rotate( vector(0, 1, 0) ) = %v, expected %v", q, r, wanted)
+	}
+	// And
+	if z := FromEuler(0, 0, math.Pi/2); !wantedZ.Equals(z) {
+		t.Errorf("FromEuler( 0, 0, π / 2 ) = %v, expected %v", z, wantedZ)
+	}
+}
+
+// Scenario: Converting a quaternion to a rotation matrix and back
+// Given the quaternions q:
+// | quaternion_from_axis_angle(vector(1, 2, 3), 1)   |
+// | quaternion_from_axis_angle(vector(1, 0, 0), 3)   |
+// | quaternion_from_axis_angle(vector(0, 1, 0.1), 3) |
+// | quaternion_from_axis_angle(vector(0, 0.1, 1), 3) |
+// Then quaternion_from_matrix(matrix(q)) = q
+func Test_Converting_a_Quaternion_to_a_Rotation_Matrix_and_Back(t *testing.T) {
+	// Given
+	cases := []Quaternion{
+		FromAxisAngle(tuples.NewVector(1, 2, 3), 1),
+		FromAxisAngle(tuples.NewVector(1, 0, 0), 3),
+		FromAxisAngle(tuples.NewVector(0, 1, 0.1), 3),
+		FromAxisAngle(tuples.NewVector(0, 0.1, 1), 3),
+	}
+	for _, q := range cases {
+		// When
+		r := FromMatrix(*q.ToMatrix())
+		// Then
+		if !q.Equals(r) {
+			t.Errorf("FromMatrix( %v ) = %v, expected %v", q.ToMatrix(), r, q)
+		}
+	}
+}
+
+// Scenario: Composing quaternions applies the right one first
+// Given a ← quaternion_from_axis_angle(vector(1, 0, 0), π / 2)
+// And b ← quaternion_from_axis_angle(vector(0, 1, 0), π / 2)
+// Then matrix(b * a) = matrix(b) * matrix(a)
+// And b * a ≠ a * b
+func Test_Composing_Quaternions_Applies_the_Right_One_First(t *testing.T) {
+	// Given
+	a := FromAxisAngle(tuples.NewVector(1, 0, 0), math.Pi/2)
+	b := FromAxisAngle(tuples.NewVector(0, 1, 0), math.Pi/2)
+	// Expected
+	wanted := b.ToMatrix().Multiply(*a.ToMatrix())
+	// Then
+	if m := b.Multiply(a).ToMatrix(); !wanted.Equals(*m) {
+		t.Errorf("( %v * %v ).ToMatrix() = %v, expected %v", b, a, m, wanted)
+	}
+	// And
+	if b.Multiply(a).Equals(a.Multiply(b)) {
+		t.Errorf("%v * %v equals %v * %v, expected them to differ", b, a, a, b)
+	}
+}
+
+// Scenario: The conjugate of a unit quaternion rotates back
+// Given q ← quaternion_from_axis_angle(vector(0, 1, 1), 1.2)
+// Then q * conjugate(q) = identity_quaternion
+func Test_the_Conjugate_of_a_Unit_Quaternion_Rotates_Back(t *testing.T) {
+	// Given
+	q := FromAxisAngle(tuples.NewVector(0, 1, 1), 1.2)
+	// Then
+	if r := q.Multiply(q.Conjugate()); !Identity().Equals(r) {
+		t.Errorf("%v * %v = %v, expected %v", q, q.Conjugate(), r, Identity())
+	}
+}
+
+// Scenario: Normalizing a quaternion
+// Given q ← quaternion(1, 1, 1, 1)
+// Then normalize(q) = quaternion(0.5, 0.5, 0.5, 0.5)
+func Test_Normalizing_a_Quaternion(t *testing.T) {
+	// Given
+	q := NewQuaternion(1, 1, 1, 1)
+	// Expected
+	wanted := NewQuaternion(0.5, 0.5, 0.5, 0.5)
+	// Then
+	if n := q.Normalize(); !wanted.Equals(n) {
+		t.Errorf("Normalize( %v ) = %v, expected %v", q, n, wanted)
+	}
+}
+
+// Scenario: Slerp moves at constant angular speed
+// Given a ← identity_quaternion
+// And b ← quaternion_from_axis_angle(vector(0, 0, 1), π / 2)
+// Then slerp(a, b, 0) = a
+// And slerp(a, b, 1) = b
+// And slerp(a, b, 0.25) = quaternion_from_axis_angle(vector(0, 0, 1), π / 8)
+func Test_Slerp_Moves_at_Constant_Angular_Speed(t *testing.T) {
+	// Given
+	a := Identity()
+	b := FromAxisAngle(tuples.NewVector(0, 0, 1), math.Pi/2)
+	// Expected
+	wanted := FromAxisAngle(tuples.NewVector(0, 0, 1), math.Pi/8)
+	// Then
+	if s := a.Slerp(b, 0); !a.Equals(s) {
+		t.Errorf("Slerp( %v, %v, 0 ) = %v, expected %v", a, b, s, a)
+	}
+	// And
+	if s := a.Slerp(b, 1); !b.Equals(s) {
+		t.Errorf("Slerp( %v, %v, 1 ) = %v, expected %v", a, b, s, b)
+	}
+	// And
+	if s := a.Slerp(b, 0.25); !wanted.Equals(s) {
+		t.Errorf("Slerp( %v, %v, 0.25 ) = %v, expected %v", a, b, s, wanted)
+	}
+}
+
+// Scenario: Slerp takes the shortest path
+// Given a ← quaternion_from_axis_angle(vector(0, 1, 0), 0.1)
+// And b ← -quaternion_from_axis_angle(vector(0, 1, 0), 0.3)
+// Then slerp(a, b, 0.5) = quaternion_from_axis_angle(vector(0, 1, 0), 0.2)
+func Test_Slerp_Takes_the_Shortest_Path(t *testing.T) {
+	// Given
+	a := FromAxisAngle(tuples.NewVector(0, 1, 0), 0.1)
+	b := FromAxisAngle(tuples.NewVector(0, 1, 0), 0.3).Scale(-1)
+	// Expected
+	wanted := FromAxisAngle(tuples.NewVector(0, 1, 0), 0.2)
+	// Then
+	if s := a.Slerp(b, 0.5); !wanted.Equals(s) {
+		t.Errorf("Slerp( %v, %v, 0.5 ) = %v, expected %v", a, b, s, wanted)
+	}
+}
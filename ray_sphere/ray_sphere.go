@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/bas-velthuizen/go-raytracer/canvas"
 	"github.com/bas-velthuizen/go-raytracer/colors"
 	"github.com/bas-velthuizen/go-raytracer/lights"
@@ -20,16 +22,19 @@ func main() {
 	// rot := transformations.RotationY(-math.Pi / 4.0)
 	trans := transformations.Translation(0, 0, 2)
 
-	origin := tuples.Point(0, 0, -5)
+	origin := tuples.NewPoint(0, 0, -5)
 	s := spheres.NewUnitSphere()
 	s.Material.Color = colors.NewColor(0.2, 1, 1)
-	s.SetTransform(trans.Multiply(*scale) /*.Multiply(*rot).Multiply(*shear)*/)
+	if err := s.SetTransform(trans.Multiply(*scale) /*.Multiply(*rot).Multiply(*shear)*/); err != nil {
+		fmt.Println(err)
+		return
+	}
 	depth := 10.0
 	width := 7.0
 	step := width / float64(c.Width)
 
 	// light source
-	lightPosition := tuples.Point(-10, 10, -10)
+	lightPosition := tuples.NewPoint(-10, 10, -10)
 	lightColor := colors.White()
 	light := lights.NewPointLight(lightPosition, lightColor)
 
@@ -37,7 +42,7 @@ func main() {
 		y := width/2.0 - float64(row)*step
 		for col := 0; col < c.Width; col++ {
 			x := float64(col)*step - width/2.0
-			target := tuples.Point(x, y, depth)
+			target := tuples.NewPoint(x, y, depth)
 			direction := target.Subtract(origin).Normalize()
 			ray := rays.NewRay(origin, direction)
 			xs := ray.Intersect(s)
@@ -45,7 +50,7 @@ func main() {
 				point := ray.Position(xs.Hit().Time)
 				normal := xs.Hit().Object.NormalAt(*point)
 				eye := ray.Direction.Negate()
-				color := xs.Hit().Object.Material.Lighting(light, *point, eye, *normal)
+				color := xs.Hit().Object.Material.Lighting(light, *point, eye, *normal, false)
 				c.Set(col, row, color)
 			} else {
 				c.Set(col, row, black)
@@ -53,5 +58,5 @@ func main() {
 		}
 	}
 
-	c.ToPPM().ToFile("picture.ppm")
+	c.SavePPM("picture.ppm", canvas.PPMOptions{Format: canvas.P6, ToneMapping: colors.NewToneMapping(0, colors.NewReinhardOperator())})
 }
@@ -2,18 +2,21 @@ package rays
 
 import (
 	"fmt"
+	"github.com/bas-velthuizen/go-raytracer/precision"
 	"github.com/bas-velthuizen/go-raytracer/spheres"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
 // Intersection aggregates a time value and a Sphere
 type Intersection struct {
-	Time    float64
-	Object  *spheres.Sphere
-	Point   tuples.Tuple
-	EyeV    tuples.Tuple
-	NormalV tuples.Tuple
-	Inside  bool
+	Time      float64
+	Object    *spheres.Sphere
+	Point     tuples.Point
+	OverPoint tuples.Point
+	EyeV      tuples.Vector
+	NormalV   tuples.Normal
+	Inside    bool
+	RayTime   float64
 }
 
 // ByTime defines a Sort interface for Intersection Slices by Time
@@ -67,15 +70,27 @@ func (xs Intersections) Hit() *Intersection {
 	return xs[minIndex]
 }
 
-// PrepareHit precomputes the state of an intersection
+// PrepareHit precomputes the state of an intersection, using the default ray offset
 func (i *Intersection) PrepareHit(ray Ray) {
+	i.PrepareHitWithOffset(ray, precision.Default().RayOffset)
+}
+
+// PrepareHitWithOffset precomputes the state of an intersection
+// OverPoint lies the offset above the surface, as the origin for rays leaving it
+// NormalV is the normal for shading, changed by the bump of the material; whether the hit is
+// Inside and where OverPoint lies are determined by the geometric normal
+func (i *Intersection) PrepareHitWithOffset(ray Ray, offset float64) {
 	i.Point = *ray.Position(i.Time)
 	i.EyeV = ray.Direction.Negate()
-	i.NormalV = *i.Object.NormalAt(i.Point)
-	if i.NormalV.Dot(i.EyeV) < 0 {
+	geometric := *i.Object.NormalAtTime(i.Point, ray.Time)
+	i.NormalV = *i.Object.ShadingNormalAtTime(i.Point, ray.Time)
+	if geometric.Dot(i.EyeV) < 0 {
 		i.Inside = true
+		geometric = geometric.Negate()
 		i.NormalV = i.NormalV.Negate()
 	} else {
 		i.Inside = false
 	}
+	i.OverPoint = i.Point.Add(geometric.Vector().Multiply(offset))
+	i.RayTime = ray.Time
 }
@@ -1,9 +1,14 @@
 package rays
 
 import (
+	"math"
 	"testing"
 
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
 	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/textures"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
@@ -67,7 +72,7 @@ func Test_Aggregating_Intersections(t *testing.T) {
 // And hit.normalv = vector(0, 0, -1)
 func Test_Precomputing_the_State_of_an_Intersection(t *testing.T) {
 	// Given
-	ray := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	ray := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	shape := spheres.NewUnitSphere()
 	// And
@@ -75,9 +80,9 @@ func Test_Precomputing_the_State_of_an_Intersection(t *testing.T) {
 	// When
 	hit.PrepareHit(*ray)
 	// Expected
-	wantedP := tuples.Point(0, 0, -1)
-	wantedE := tuples.Vector(0, 0, -1)
-	wantedN := tuples.Vector(0, 0, -1)
+	wantedP := tuples.NewPoint(0, 0, -1)
+	wantedE := tuples.NewVector(0, 0, -1)
+	wantedN := tuples.NewNormal(0, 0, -1)
 	// Then
 	if !hit.Point.Equals(wantedP) {
 		t.Errorf("hit.Point = %v, expected %v", hit.Point, wantedP)
@@ -92,6 +97,34 @@ func Test_Precomputing_the_State_of_an_Intersection(t *testing.T) {
 	}
 }
 
+// Scenario: The hit should offset the point
+// Given ray ← ray(point(0, 0, -5), vector(0, 0, 1))
+// And shape ← sphere() with:
+// | transform | translation(0, 0, 1) |
+// And hit ← intersection(5, shape)
+// When prepare_hit_with_offset(hit, ray, 0.001)
+// Then hit.over_point.z = -0.001
+// And hit.point.z > hit.over_point.z
+func Test_the_Hit_Should_Offset_the_Point(t *testing.T) {
+	// Given
+	ray := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// And
+	shape := spheres.NewUnitSphere()
+	shape.SetTransform(transformations.Translation(0, 0, 1))
+	// And
+	hit := NewIntersection(5, shape)
+	// When
+	hit.PrepareHitWithOffset(*ray, 0.001)
+	// Then
+	if math.Abs(hit.OverPoint.Z+0.001) > 1e-9 {
+		t.Errorf("hit.OverPoint = %v, expected z = -0.001", hit.OverPoint)
+	}
+	// And
+	if hit.Point.Z <= hit.OverPoint.Z {
+		t.Errorf("hit.Point = %v is not above hit.OverPoint = %v", hit.Point, hit.OverPoint)
+	}
+}
+
 // Scenario: An intersection occurs on the outside
 // Given ray ← ray(point(0, 0, -5), vector(0, 0, 1))
 // And shape ← sphere()
@@ -100,7 +133,7 @@ func Test_Precomputing_the_State_of_an_Intersection(t *testing.T) {
 // Then hit.inside = false
 func Test_An_Intersection_Occurs_on_the_Outside(t *testing.T) {
 	// Given
-	ray := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	ray := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	shape := spheres.NewUnitSphere()
 	// And
@@ -125,7 +158,7 @@ func Test_An_Intersection_Occurs_on_the_Outside(t *testing.T) {
 // And hit.normalv = vector(0, 0, -1)
 func Test_An_Intersection_Occurs_on_the_Inside(t *testing.T) {
 	// Given
-	ray := NewRay(tuples.Point(0, 0, 0), tuples.Vector(0, 0, 1))
+	ray := NewRay(tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, 1))
 	// And
 	shape := spheres.NewUnitSphere()
 	// And
@@ -133,9 +166,9 @@ func Test_An_Intersection_Occurs_on_the_Inside(t *testing.T) {
 	// When
 	hit.PrepareHit(*ray)
 	// Expected
-	wantedP := tuples.Point(0, 0, 1)
-	wantedE := tuples.Vector(0, 0, -1)
-	wantedN := tuples.Vector(0, 0, -1)
+	wantedP := tuples.NewPoint(0, 0, 1)
+	wantedE := tuples.NewVector(0, 0, -1)
+	wantedN := tuples.NewNormal(0, 0, -1)
 	// Then
 	if !hit.Point.Equals(wantedP) {
 		t.Errorf("hit.Point = %v, expected %v", hit.Point, wantedP)
@@ -153,3 +186,37 @@ func Test_An_Intersection_Occurs_on_the_Inside(t *testing.T) {
 		t.Errorf("hit.NormalV = %v, expected %v", hit.NormalV, wantedN)
 	}
 }
+
+// Scenario: A bumped hit shades with the bumped normal but offsets along the surface normal
+// Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// And shape ← sphere() with a normal map tilting every normal along u
+// And hit ← intersection(4, shape)
+// When prepare_hit(hit, r)
+// Then hit.normalv = normalize(normal(1, 0, -1))
+// And hit.over_point = point(0, 0, -1 - EPSILON)
+// And hit.inside = false
+func Test_a_Bumped_Hit_Shades_with_the_Bumped_Normal_but_Offsets_along_the_Surface_Normal(t *testing.T) {
+	// Given
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// And
+	shape := spheres.NewUnitSphere()
+	tilt := canvas.NewCanvas(1, 1)
+	tilt.Set(0, 0, colors.NewColor(1, 0.5, 1))
+	shape.Material.Bump = textures.NewNormalMap(textures.NewImageTexture(tilt, textures.Nearest), textures.NewSphericalMapping())
+	// And
+	hit := NewIntersection(4, shape)
+	// When
+	hit.PrepareHit(*r)
+	// Then
+	if wanted := tuples.NewNormal(1, 0, -1).Normalize(); !hit.NormalV.Equals(wanted) {
+		t.Errorf("hit.NormalV = %v, expected %v", hit.NormalV, wanted)
+	}
+	// And
+	if wanted := tuples.NewPoint(0, 0, -1-tuples.Epsilon); !hit.OverPoint.Equals(wanted) {
+		t.Errorf("hit.OverPoint = %v, expected %v", hit.OverPoint, wanted)
+	}
+	// And
+	if hit.Inside {
+		t.Errorf("hit.Inside = %v, expected %v", hit.Inside, false)
+	}
+}
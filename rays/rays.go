@@ -9,37 +9,43 @@ import (
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
-// Ray describes a ray of light with an origin and direction
+// Ray describes a ray of light with an origin and direction, cast at a certain moment in time
 type Ray struct {
-	Origin    tuples.Tuple
-	Direction tuples.Tuple
+	Origin    tuples.Point
+	Direction tuples.Vector
+	Time      float64
 }
 
 // NewRay creates a new ray from a origin and direction
-func NewRay(origin, direction tuples.Tuple) *Ray {
+func NewRay(origin tuples.Point, direction tuples.Vector) *Ray {
 	return &Ray{Origin: origin, Direction: direction}
 }
 
+// NewRayAtTime creates a new ray from a origin and direction, cast at the specified time
+func NewRayAtTime(origin tuples.Point, direction tuples.Vector, time float64) *Ray {
+	return &Ray{Origin: origin, Direction: direction, Time: time}
+}
+
 // String formats the ray as a string
 func (r Ray) String() string {
-	return fmt.Sprintf("Ray( %v, %v )", r.Origin, r.Direction)
+	return fmt.Sprintf("Ray( %v, %v, %9.6f )", r.Origin, r.Direction, r.Time)
 }
 
 // Position calculates the position of the ray at time t
-func (r Ray) Position(t float64) *tuples.Tuple {
+func (r Ray) Position(t float64) *tuples.Point {
 	result := r.Origin.Add(r.Direction.Multiply(t))
 	return &result
 }
 
 // Intersect calculates the intersections with a Sphere
 func (r Ray) Intersect(s *spheres.Sphere) Intersections {
-	rTransformed := r.Transform(*s.Transform.Inverse())
+	rTransformed := r.Transform4(s.InverseAt(r.Time))
 
 	sphereToRay := rTransformed.Origin.Subtract(s.Center)
 
 	a := rTransformed.Direction.Dot(rTransformed.Direction)
 	b := 2 * rTransformed.Direction.Dot(sphereToRay)
-	c := sphereToRay.Dot(sphereToRay) - 1.0
+	c := sphereToRay.Dot(sphereToRay) - s.Radius*s.Radius
 
 	discriminant := b*b - 4*a*c
 
@@ -60,9 +66,14 @@ func (r Ray) Intersect(s *spheres.Sphere) Intersections {
 	return *NewIntersections([]*Intersection{intersection1, intersection2})
 }
 
-// Transform transforms a ray with a matrix, returnning a new ray
+// Transform transforms a ray with a matrix, returnning a new ray at the same time
 func (r Ray) Transform(m matrix.Matrix) *Ray {
-	newOrigin := m.MultiplyTuple(r.Origin)
-	newDirection := m.MultiplyTuple(r.Direction)
-	return NewRay(*newOrigin, *newDirection)
+	newOrigin := m.MultiplyPoint(r.Origin)
+	newDirection := m.MultiplyVector(r.Direction)
+	return NewRayAtTime(*newOrigin, *newDirection, r.Time)
+}
+
+// Transform4 transforms a ray with a Matrix4, returning a new ray at the same time
+func (r Ray) Transform4(m matrix.Matrix4) Ray {
+	return Ray{Origin: m.MultiplyPoint(r.Origin), Direction: m.MultiplyVector(r.Direction), Time: r.Time}
 }
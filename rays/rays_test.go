@@ -1,6 +1,7 @@
 package rays
 
 import (
+	"math"
 	"testing"
 
 	"github.com/bas-velthuizen/go-raytracer/spheres"
@@ -16,9 +17,9 @@ import (
 // And r.direction = direction
 func Test_Creating_and_Querying_a_Ray(t *testing.T) {
 	// Given
-	origin := tuples.Point(1, 2, 3)
+	origin := tuples.NewPoint(1, 2, 3)
 	// And
-	direction := tuples.Vector(4, 5, 6)
+	direction := tuples.NewVector(4, 5, 6)
 	// When
 	r := NewRay(origin, direction)
 	// Then
@@ -39,12 +40,12 @@ func Test_Creating_and_Querying_a_Ray(t *testing.T) {
 // And position(r, 2.5) = point(4.5, 3, 4)
 func Test_Computing_a_Point_from_a_Distance(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(2, 3, 4), tuples.Vector(1, 0, 0))
+	r := NewRay(tuples.NewPoint(2, 3, 4), tuples.NewVector(1, 0, 0))
 	// Expected
-	wanted0 := tuples.Point(2, 3, 4)
-	wanted1 := tuples.Point(3, 3, 4)
-	wantedMinus1 := tuples.Point(1, 3, 4)
-	wanted2Dot5 := tuples.Point(4.5, 3, 4)
+	wanted0 := tuples.NewPoint(2, 3, 4)
+	wanted1 := tuples.NewPoint(3, 3, 4)
+	wantedMinus1 := tuples.NewPoint(1, 3, 4)
+	wanted2Dot5 := tuples.NewPoint(4.5, 3, 4)
 	// Then
 	p0 := r.Position(0)
 	if !wanted0.Equals(*p0) {
@@ -76,7 +77,7 @@ func Test_Computing_a_Point_from_a_Distance(t *testing.T) {
 // And xs[1] = 6
 func Test_a_Ray_Intersects_a_Sphere_at_Two_Points(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -108,7 +109,7 @@ func Test_a_Ray_Intersects_a_Sphere_at_Two_Points(t *testing.T) {
 // And xs[1] = 5
 func Test_a_Ray_Intersects_a_Sphere_at_a_Tangent(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 1, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 1, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -138,7 +139,7 @@ func Test_a_Ray_Intersects_a_Sphere_at_a_Tangent(t *testing.T) {
 // Then xs.count = 0
 func Test_a_Ray_Misses_a_Sphere(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 2, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 2, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -160,7 +161,7 @@ func Test_a_Ray_Misses_a_Sphere(t *testing.T) {
 // And xs[1] = 1
 func Test_a_Ray_Originates_Inside_a_Sphere(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, 0), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -192,7 +193,7 @@ func Test_a_Ray_Originates_Inside_a_Sphere(t *testing.T) {
 // And xs[1] = -4
 func Test_a_Sphere_is_Behind_a_Ray(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, 5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, 5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -224,7 +225,7 @@ func Test_a_Sphere_is_Behind_a_Ray(t *testing.T) {
 // And xs[1].object = s
 func Test_Intersects_Sets_the_Object_on_the_Intersection(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// Expected
@@ -355,14 +356,14 @@ func Test_The_Hit_is_Always_the_Lowest_NonNegative_Intersections(t *testing.T) {
 // And r2.direction = vector(0, 1, 0)
 func Test_Translating_a_Ray(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(1, 2, 3), tuples.Vector(0, 1, 0))
+	r := NewRay(tuples.NewPoint(1, 2, 3), tuples.NewVector(0, 1, 0))
 	// And
 	m := transformations.Translation(3, 4, 5)
 	// Whens
 	r2 := r.Transform(*m)
 	// Expected
-	wantedP := tuples.Point(4, 6, 8)
-	wantedD := tuples.Vector(0, 1, 0)
+	wantedP := tuples.NewPoint(4, 6, 8)
+	wantedD := tuples.NewVector(0, 1, 0)
 	// Then
 	if !wantedP.Equals(r2.Origin) {
 		t.Errorf("Transform( %v, %v ).Origin = %v, wanted %v", r, m, r2.Origin, wantedP)
@@ -381,14 +382,14 @@ func Test_Translating_a_Ray(t *testing.T) {
 // And r2.direction = vector(0, 3, 0)
 func Test_Scaling_a_Ray(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(1, 2, 3), tuples.Vector(0, 1, 0))
+	r := NewRay(tuples.NewPoint(1, 2, 3), tuples.NewVector(0, 1, 0))
 	// And
 	m := transformations.Scaling(2, 3, 4)
 	// Whens
 	r2 := r.Transform(*m)
 	// Expected
-	wantedP := tuples.Point(2, 6, 12)
-	wantedD := tuples.Vector(0, 3, 0)
+	wantedP := tuples.NewPoint(2, 6, 12)
+	wantedD := tuples.NewVector(0, 3, 0)
 	// Then
 	if !wantedP.Equals(r2.Origin) {
 		t.Errorf("Transform( %v, %v ).Origin = %v, wanted %v", r, m, r2.Origin, wantedP)
@@ -409,7 +410,7 @@ func Test_Scaling_a_Ray(t *testing.T) {
 // And xs[1].t = 7
 func Test_Intersecting_a_Scaled_Sphere_with_a_Ray(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// When
@@ -434,6 +435,38 @@ func Test_Intersecting_a_Scaled_Sphere_with_a_Ray(t *testing.T) {
 	}
 }
 
+// Scenario: Intersecting a sphere with a center and radius with a ray
+// Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// And s ← sphere(point(0, 0, 1), 2)
+// When xs ← intersect(s, r)
+// Then xs.count = 2
+// And xs[0].t = 4
+// And xs[1].t = 8
+func Test_Intersecting_a_Sphere_with_a_Center_and_Radius_with_a_Ray(t *testing.T) {
+	// Given
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// And
+	s := spheres.NewSphere(tuples.NewPoint(0, 0, 1), 2)
+	// When
+	xs := r.Intersect(s)
+	// Expected
+	wantedCount := 2
+	wanted0 := 4.0
+	wanted1 := 8.0
+	// Then
+	if wantedCount != len(xs) {
+		t.Fatalf("len(%v) = %d, expected %d", xs, len(xs), wantedCount)
+	}
+	// And
+	if wanted0 != (*xs[0]).Time {
+		t.Errorf("(%v).Time = %9.6f, expected %9.6f", *xs[0], (*xs[0]).Time, wanted0)
+	}
+	// And
+	if wanted1 != (*xs[1]).Time {
+		t.Errorf("(%v).Time = %9.6f, expected %9.6f", *xs[1], (*xs[1]).Time, wanted1)
+	}
+}
+
 // Scenario: Intersecting a translated sphere with a ray
 // Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
 // And s ← sphere()
@@ -442,7 +475,7 @@ func Test_Intersecting_a_Scaled_Sphere_with_a_Ray(t *testing.T) {
 // Then xs.count = 0
 func Test_Intersecting_a_Translated_Sphere_with_a_Ray(t *testing.T) {
 	// Given
-	r := NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	r := NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	s := spheres.NewUnitSphere()
 	// When
@@ -456,3 +489,61 @@ func Test_Intersecting_a_Translated_Sphere_with_a_Ray(t *testing.T) {
 		t.Errorf("len(%v) = %d, expected %d", xs, len(xs), wantedCount)
 	}
 }
+
+// Scenario: Transforming a ray keeps its time
+// Given r ← ray(point(1, 2, 3), vector(0, 1, 0)) at time 0.75
+// And m ← translation(3, 4, 5)
+// When r2 ← transform(r, m)
+// Then r2.time = 0.75
+func Test_Transforming_a_Ray_Keeps_its_Time(t *testing.T) {
+	// Given
+	r := NewRayAtTime(tuples.NewPoint(1, 2, 3), tuples.NewVector(0, 1, 0), 0.75)
+	// And
+	m := transformations.Translation(3, 4, 5)
+	// When
+	r2 := r.Transform(*m)
+	// Then
+	if r2.Time != 0.75 {
+		t.Errorf("transform(%v, %v).Time = %9.6f, expected %9.6f", r, m, r2.Time, 0.75)
+	}
+}
+
+// Scenario: Intersecting a moving sphere depends on the time of the ray
+// Given s ← sphere() moving from translation(0, 0, 0) to translation(4, 0, 0)
+// And r1 ← ray(point(2, 0, -5), vector(0, 0, 1)) at time 0
+// And r2 ← ray(point(2, 0, -5), vector(0, 0, 1)) at time 0.5
+// When xs1 ← intersect(s, r1)
+// And xs2 ← intersect(s, r2)
+// Then xs1.count = 0
+// And xs2.count = 2
+// And xs2[0] = 4
+// And xs2[1] = 6
+func Test_Intersecting_a_Moving_Sphere_Depends_on_the_Time_of_the_Ray(t *testing.T) {
+	// Given
+	s := spheres.NewUnitSphere()
+	s.SetMotion(transformations.Translation(0, 0, 0), transformations.Translation(4, 0, 0))
+	// And
+	r1 := NewRayAtTime(tuples.NewPoint(2, 0, -5), tuples.NewVector(0, 0, 1), 0)
+	// And
+	r2 := NewRayAtTime(tuples.NewPoint(2, 0, -5), tuples.NewVector(0, 0, 1), 0.5)
+	// When
+	xs1 := r1.Intersect(s)
+	// And
+	xs2 := r2.Intersect(s)
+	// Then
+	if len(xs1) != 0 {
+		t.Errorf("len(%v) = %d, expected %d", xs1, len(xs1), 0)
+	}
+	// And
+	if len(xs2) != 2 {
+		t.Fatalf("len(%v) = %d, expected %d", xs2, len(xs2), 2)
+	}
+	// And
+	if math.Abs(xs2[0].Time-4.0) > tuples.Epsilon {
+		t.Errorf("(%v).Time = %9.6f, expected %9.6f", *xs2[0], xs2[0].Time, 4.0)
+	}
+	// And
+	if math.Abs(xs2[1].Time-6.0) > tuples.Epsilon {
+		t.Errorf("(%v).Time = %9.6f, expected %9.6f", *xs2[1], xs2[1].Time, 6.0)
+	}
+}
@@ -0,0 +1,69 @@
+package sampling
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Film accumulates weighted samples and reconstructs the pixels of a Canvas from them
+type Film struct {
+	Width   int
+	Height  int
+	Filter  Filter
+	sums    []colors.Color
+	weights []float64
+}
+
+// NewFilm creates a new Film of the specified size, reconstructing pixels with the specified filter
+func NewFilm(width, height int, filter Filter) *Film {
+	return &Film{
+		Width:   width,
+		Height:  height,
+		Filter:  filter,
+		sums:    make([]colors.Color, width*height),
+		weights: make([]float64, width*height),
+	}
+}
+
+// AddSample adds the color of a sample at position (x, y) on the film to all pixels within the filter radius
+// The position is measured in pixels from the top left corner, so the center of pixel (0, 0) is at (0.5, 0.5)
+func (f *Film) AddSample(x, y float64, color colors.Color) {
+	radius := f.Filter.Radius()
+	minX := int(math.Max(0, math.Ceil(x-0.5-radius)))
+	maxX := int(math.Min(float64(f.Width-1), math.Floor(x-0.5+radius)))
+	minY := int(math.Max(0, math.Ceil(y-0.5-radius)))
+	maxY := int(math.Min(float64(f.Height-1), math.Floor(y-0.5+radius)))
+	for py := minY; py <= maxY; py++ {
+		for px := minX; px <= maxX; px++ {
+			weight := f.Filter.Evaluate(float64(px)+0.5-x, float64(py)+0.5-y)
+			if weight == 0 {
+				continue
+			}
+			i := py*f.Width + px
+			f.sums[i] = f.sums[i].Add(color.Multiply(weight))
+			f.weights[i] += weight
+		}
+	}
+}
+
+// Get returns the reconstructed color of a pixel, the weighted average of its samples
+func (f Film) Get(x, y int) colors.Color {
+	i := y*f.Width + x
+	if math.Abs(f.weights[i]) < 1e-12 {
+		return colors.Black()
+	}
+	return f.sums[i].Multiply(1 / f.weights[i])
+}
+
+// ToCanvas writes the reconstructed pixels to a new Canvas
+func (f Film) ToCanvas() *canvas.Canvas {
+	c := canvas.NewCanvas(f.Width, f.Height)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			c.Set(x, y, f.Get(x, y))
+		}
+	}
+	return c
+}
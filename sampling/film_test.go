@@ -0,0 +1,71 @@
+package sampling
+
+import (
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+)
+
+// Scenario: A film averages the samples within a pixel
+// Given film ← film(2, 2, box_filter(0.5))
+// When add_sample(film, 0.25, 0.25, color(1, 0, 0))
+// And add_sample(film, 0.75, 0.75, color(0, 0, 1))
+// Then pixel_at(film, 0, 0) = color(0.5, 0, 0.5)
+// And pixel_at(film, 1, 1) = color(0, 0, 0)
+func Test_a_Film_Averages_the_Samples_within_a_Pixel(t *testing.T) {
+	// Given
+	film := NewFilm(2, 2, NewBoxFilter(0.5))
+	// When
+	film.AddSample(0.25, 0.25, colors.NewColor(1, 0, 0))
+	// And
+	film.AddSample(0.75, 0.75, colors.NewColor(0, 0, 1))
+	// Expected
+	wanted := colors.NewColor(0.5, 0, 0.5)
+	// Then
+	if c := film.Get(0, 0); !wanted.Equals(c) {
+		t.Errorf("film.Get(0, 0) = %v, expected %v", c, wanted)
+	}
+	// And
+	if c := film.Get(1, 1); !colors.Black().Equals(c) {
+		t.Errorf("film.Get(1, 1) = %v, expected %v", c, colors.Black())
+	}
+}
+
+// Scenario: A wide filter spreads a sample over neighboring pixels
+// Given film ← film(3, 1, tent_filter(1.5))
+// When add_sample(film, 1.5, 0.5, color(1, 1, 1))
+// Then pixel_at(film, 0, 0) = color(1, 1, 1)
+// And pixel_at(film, 1, 0) = color(1, 1, 1)
+// And pixel_at(film, 2, 0) = color(1, 1, 1)
+func Test_a_Wide_Filter_Spreads_a_Sample_over_Neighboring_Pixels(t *testing.T) {
+	// Given
+	film := NewFilm(3, 1, NewTentFilter(1.5))
+	// When
+	film.AddSample(1.5, 0.5, colors.White())
+	// Then
+	for x := 0; x < 3; x++ {
+		if c := film.Get(x, 0); !colors.White().Equals(c) {
+			t.Errorf("film.Get(%d, 0) = %v, expected %v", x, c, colors.White())
+		}
+	}
+}
+
+// Scenario: Closer samples weigh more with a tent filter
+// Given film ← film(1, 1, tent_filter(1))
+// When add_sample(film, 0.5, 0.5, color(1, 1, 1))
+// And add_sample(film, 1.0, 0.5, color(0, 0, 0))
+// Then pixel_at(film, 0, 0) = color(2/3, 2/3, 2/3)
+func Test_Closer_Samples_Weigh_More_with_a_Tent_Filter(t *testing.T) {
+	// Given
+	film := NewFilm(1, 1, NewTentFilter(1))
+	// When
+	film.AddSample(0.5, 0.5, colors.White())
+	// And
+	film.AddSample(1.0, 0.5, colors.Black())
+	// Expected
+	wanted := colors.NewColor(2.0/3.0, 2.0/3.0, 2.0/3.0)
+	// Then
+	if c := film.Get(0, 0); !wanted.Equals(c) {
+		t.Errorf("film.Get(0, 0) = %v, expected %v", c, wanted)
+	}
+}
@@ -0,0 +1,139 @@
+package sampling
+
+import (
+	"fmt"
+	"math"
+)
+
+// Filter weighs the contribution of a sample to a pixel, based on their distance
+type Filter interface {
+	// Radius returns the distance in pixels beyond which a sample has no influence
+	Radius() float64
+	// Evaluate returns the weight of a sample at offset (x, y) from the center of a pixel
+	Evaluate(x, y float64) float64
+}
+
+// BoxFilter weighs all samples within its radius equally
+type BoxFilter struct {
+	radius float64
+}
+
+// TentFilter weighs samples linearly decreasing with the distance
+type TentFilter struct {
+	radius float64
+}
+
+// GaussianFilter weighs samples with a Gaussian bell curve that is shifted to reach zero at its radius
+type GaussianFilter struct {
+	radius float64
+	Alpha  float64
+}
+
+// MitchellFilter is the Mitchell-Netravali cubic filter, a trade-off between ringing and blurring
+// controlled by parameters B and C
+type MitchellFilter struct {
+	radius float64
+	B      float64
+	C      float64
+}
+
+// NewBoxFilter creates a new BoxFilter
+// A radius of 0.5 only counts the samples that lie inside a pixel
+func NewBoxFilter(radius float64) BoxFilter {
+	return BoxFilter{radius}
+}
+
+// NewTentFilter creates a new TentFilter
+func NewTentFilter(radius float64) TentFilter {
+	return TentFilter{radius}
+}
+
+// NewGaussianFilter creates a new GaussianFilter, alpha controls how fast the weight falls off
+func NewGaussianFilter(radius, alpha float64) GaussianFilter {
+	return GaussianFilter{radius, alpha}
+}
+
+// NewMitchellFilter creates a new MitchellFilter, B = C = 1/3 is the recommended setting
+func NewMitchellFilter(radius, b, c float64) MitchellFilter {
+	return MitchellFilter{radius, b, c}
+}
+
+// Radius returns the radius of the filter
+func (f BoxFilter) Radius() float64 {
+	return f.radius
+}
+
+// Evaluate returns 1 within the radius of the filter
+func (f BoxFilter) Evaluate(x, y float64) float64 {
+	if math.Abs(x) > f.radius || math.Abs(y) > f.radius {
+		return 0
+	}
+	return 1
+}
+
+// String formats the BoxFilter as a string
+func (f BoxFilter) String() string {
+	return fmt.Sprintf("BoxFilter( %9.6f )", f.radius)
+}
+
+// Radius returns the radius of the filter
+func (f TentFilter) Radius() float64 {
+	return f.radius
+}
+
+// Evaluate returns the weight of a sample at the specified offset
+func (f TentFilter) Evaluate(x, y float64) float64 {
+	return math.Max(0, f.radius-math.Abs(x)) * math.Max(0, f.radius-math.Abs(y))
+}
+
+// String formats the TentFilter as a string
+func (f TentFilter) String() string {
+	return fmt.Sprintf("TentFilter( %9.6f )", f.radius)
+}
+
+// Radius returns the radius of the filter
+func (f GaussianFilter) Radius() float64 {
+	return f.radius
+}
+
+// Evaluate returns the weight of a sample at the specified offset
+func (f GaussianFilter) Evaluate(x, y float64) float64 {
+	return f.gaussian(x) * f.gaussian(y)
+}
+
+func (f GaussianFilter) gaussian(d float64) float64 {
+	return math.Max(0, math.Exp(-f.Alpha*d*d)-math.Exp(-f.Alpha*f.radius*f.radius))
+}
+
+// String formats the GaussianFilter as a string
+func (f GaussianFilter) String() string {
+	return fmt.Sprintf("GaussianFilter( %9.6f, %9.6f )", f.radius, f.Alpha)
+}
+
+// Radius returns the radius of the filter
+func (f MitchellFilter) Radius() float64 {
+	return f.radius
+}
+
+// Evaluate returns the weight of a sample at the specified offset, which can be negative
+func (f MitchellFilter) Evaluate(x, y float64) float64 {
+	return f.mitchell(x/f.radius) * f.mitchell(y/f.radius)
+}
+
+// mitchell evaluates the one dimensional filter for x in [-1, 1]
+func (f MitchellFilter) mitchell(x float64) float64 {
+	x = math.Abs(2 * x)
+	b, c := f.B, f.C
+	if x > 2 {
+		return 0
+	}
+	if x > 1 {
+		return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+	}
+	return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+}
+
+// String formats the MitchellFilter as a string
+func (f MitchellFilter) String() string {
+	return fmt.Sprintf("MitchellFilter( %9.6f, %9.6f, %9.6f )", f.radius, f.B, f.C)
+}
@@ -0,0 +1,97 @@
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: A box filter weighs all samples within its radius equally
+// Given f ← box_filter(0.5)
+// Then evaluate(f, 0, 0) = 1
+// And evaluate(f, 0.4, -0.4) = 1
+// And evaluate(f, 0.6, 0) = 0
+func Test_a_Box_Filter_Weighs_All_Samples_within_its_Radius_Equally(t *testing.T) {
+	// Given
+	f := NewBoxFilter(0.5)
+	// Then
+	if w := f.Evaluate(0, 0); w != 1 {
+		t.Errorf("%v.Evaluate(0, 0) = %9.6f, expected %9.6f", f, w, 1.0)
+	}
+	// And
+	if w := f.Evaluate(0.4, -0.4); w != 1 {
+		t.Errorf("%v.Evaluate(0.4, -0.4) = %9.6f, expected %9.6f", f, w, 1.0)
+	}
+	// And
+	if w := f.Evaluate(0.6, 0); w != 0 {
+		t.Errorf("%v.Evaluate(0.6, 0) = %9.6f, expected %9.6f", f, w, 0.0)
+	}
+}
+
+// Scenario: A tent filter falls off linearly
+// Given f ← tent_filter(1)
+// Then evaluate(f, 0, 0) = 1
+// And evaluate(f, 0.5, 0) = 0.5
+// And evaluate(f, 0.5, 0.5) = 0.25
+// And evaluate(f, 1, 0) = 0
+func Test_a_Tent_Filter_Falls_Off_Linearly(t *testing.T) {
+	// Given
+	f := NewTentFilter(1)
+	cases := []struct {
+		x, y   float64
+		wanted float64
+	}{
+		{0, 0, 1},
+		{0.5, 0, 0.5},
+		{0.5, 0.5, 0.25},
+		{1, 0, 0},
+	}
+	// Then
+	for _, c := range cases {
+		if w := f.Evaluate(c.x, c.y); math.Abs(w-c.wanted) > tuples.Epsilon {
+			t.Errorf("%v.Evaluate(%9.6f, %9.6f) = %9.6f, expected %9.6f", f, c.x, c.y, w, c.wanted)
+		}
+	}
+}
+
+// Scenario: A Gaussian filter reaches zero at its radius
+// Given f ← gaussian_filter(1.5, 2)
+// Then evaluate(f, 0, 0) > evaluate(f, 0.5, 0) > 0
+// And evaluate(f, 1.5, 0) = 0
+func Test_a_Gaussian_Filter_Reaches_Zero_at_its_Radius(t *testing.T) {
+	// Given
+	f := NewGaussianFilter(1.5, 2)
+	// Then
+	center := f.Evaluate(0, 0)
+	half := f.Evaluate(0.5, 0)
+	if !(center > half && half > 0) {
+		t.Errorf("%v.Evaluate(0, 0) = %9.6f and Evaluate(0.5, 0) = %9.6f, expected decreasing positive weights", f, center, half)
+	}
+	// And
+	if w := f.Evaluate(1.5, 0); math.Abs(w) > tuples.Epsilon {
+		t.Errorf("%v.Evaluate(1.5, 0) = %9.6f, expected %9.6f", f, w, 0.0)
+	}
+}
+
+// Scenario: A Mitchell filter has negative lobes
+// Given f ← mitchell_filter(2, 1/3, 1/3)
+// Then evaluate(f, 0, 0) > 0
+// And evaluate(f, 1.5, 0) < 0
+// And evaluate(f, 2, 0) = 0
+func Test_a_Mitchell_Filter_Has_Negative_Lobes(t *testing.T) {
+	// Given
+	f := NewMitchellFilter(2, 1.0/3.0, 1.0/3.0)
+	// Then
+	if w := f.Evaluate(0, 0); w <= 0 {
+		t.Errorf("%v.Evaluate(0, 0) = %9.6f, expected positive", f, w)
+	}
+	// And
+	if w := f.Evaluate(1.5, 0); w >= 0 {
+		t.Errorf("%v.Evaluate(1.5, 0) = %9.6f, expected negative", f, w)
+	}
+	// And
+	if w := f.Evaluate(2, 0); math.Abs(w) > tuples.Epsilon {
+		t.Errorf("%v.Evaluate(2, 0) = %9.6f, expected %9.6f", f, w, 0.0)
+	}
+}
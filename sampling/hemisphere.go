@@ -0,0 +1,12 @@
+package sampling
+
+import "math"
+
+// CosineHemisphere maps a sample in the unit square onto the hemisphere around the z axis,
+// with a density proportional to the cosine of the angle with the z axis, cos / π
+// It projects the concentric mapping of the sample onto the unit disk up onto the hemisphere
+func CosineHemisphere(s Sample) (float64, float64, float64) {
+	x, y := ConcentricDisk(s)
+	z := math.Sqrt(math.Max(0, 1-x*x-y*y))
+	return x, y, z
+}
@@ -0,0 +1,50 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Scenario: Cosine weighted samples lie on the upper unit hemisphere
+// Given rng ← random(7)
+// When (x, y, z) ← cosine_hemisphere(sample(rng, rng)) for 1000 samples
+// Then x² + y² + z² = 1
+// And z ≥ 0
+func Test_Cosine_Weighted_Samples_Lie_on_the_Upper_Unit_Hemisphere(t *testing.T) {
+	// Given
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 1000; i++ {
+		s := Sample{rng.Float64(), rng.Float64()}
+		// When
+		x, y, z := CosineHemisphere(s)
+		// Then
+		if math.Abs(x*x+y*y+z*z-1) > 1e-9 {
+			t.Errorf("CosineHemisphere( %v ) = (%v, %v, %v), expected a unit vector", s, x, y, z)
+		}
+		// And
+		if z < 0 {
+			t.Errorf("CosineHemisphere( %v ) = (%v, %v, %v), expected z >= 0", s, x, y, z)
+		}
+	}
+}
+
+// Scenario: Cosine weighted samples have a mean cosine of 2/3
+// Given a regular grid of 64 × 64 samples
+// When (x, y, z) ← cosine_hemisphere(s) for every sample s
+// Then the mean of z = 2/3, the mean cosine for the density cos / π
+func Test_Cosine_Weighted_Samples_have_a_Mean_Cosine_of_Two_Thirds(t *testing.T) {
+	// Given
+	samples := NewRegularSampler().Samples(64*64, nil)
+	// When
+	sum := 0.0
+	for _, s := range samples {
+		_, _, z := CosineHemisphere(s)
+		sum += z
+	}
+	mean := sum / float64(len(samples))
+	// Then
+	if math.Abs(mean-2.0/3) > 2e-3 {
+		t.Errorf("mean cosine = %v, expected %v", mean, 2.0/3)
+	}
+}
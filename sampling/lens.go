@@ -0,0 +1,43 @@
+package sampling
+
+import "math"
+
+// ConcentricDisk maps a sample in the unit square onto the unit disk,
+// keeping neighboring samples close together and preserving their stratification
+func ConcentricDisk(s Sample) (float64, float64) {
+	a := 2*s.X - 1
+	b := 2*s.Y - 1
+	if a == 0 && b == 0 {
+		return 0, 0
+	}
+	var r, theta float64
+	if math.Abs(a) > math.Abs(b) {
+		r = a
+		theta = (math.Pi / 4) * (b / a)
+	} else {
+		r = b
+		theta = math.Pi/2 - (math.Pi/4)*(a/b)
+	}
+	return r * math.Cos(theta), r * math.Sin(theta)
+}
+
+// RegularPolygon maps a sample in the unit square uniformly onto a regular polygon,
+// with the specified number of sides and its corners on the unit circle
+// The first corner is at the specified angle in radians from the x axis
+func RegularPolygon(s Sample, sides int, rotation float64) (float64, float64) {
+	// choose the triangle between the center and one side, and reuse the remainder of s.X within it
+	sector := s.X * float64(sides)
+	i := math.Floor(sector)
+	u := sector - i
+	v := s.Y
+
+	angle := 2 * math.Pi / float64(sides)
+	x1, y1 := math.Cos(rotation+i*angle), math.Sin(rotation+i*angle)
+	x2, y2 := math.Cos(rotation+(i+1)*angle), math.Sin(rotation+(i+1)*angle)
+
+	// uniform sampling of the triangle (center, corner 1, corner 2)
+	su := math.Sqrt(u)
+	b1 := su * (1 - v)
+	b2 := su * v
+	return b1*x1 + b2*x2, b1*y1 + b2*y2
+}
@@ -0,0 +1,66 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The center of the unit square maps onto the center of the disk
+// Given s ← sample(0.5, 0.5)
+// When (x, y) ← concentric_disk(s)
+// Then (x, y) = (0, 0)
+func Test_the_Center_of_the_Unit_Square_Maps_onto_the_Center_of_the_Disk(t *testing.T) {
+	// Given
+	s := Sample{0.5, 0.5}
+	// When
+	x, y := ConcentricDisk(s)
+	// Then
+	if x != 0 || y != 0 {
+		t.Errorf("ConcentricDisk(%v) = (%9.6f, %9.6f), expected (0, 0)", s, x, y)
+	}
+}
+
+// Scenario: The edges of the unit square map onto the edge of the disk
+// Given s ← sample(1, 0.5)
+// When (x, y) ← concentric_disk(s)
+// Then (x, y) = (1, 0)
+// And every sample maps inside the unit disk
+func Test_the_Edges_of_the_Unit_Square_Map_onto_the_Edge_of_the_Disk(t *testing.T) {
+	// Given
+	s := Sample{1, 0.5}
+	// When
+	x, y := ConcentricDisk(s)
+	// Then
+	if math.Abs(x-1) > tuples.Epsilon || math.Abs(y) > tuples.Epsilon {
+		t.Errorf("ConcentricDisk(%v) = (%9.6f, %9.6f), expected (1, 0)", s, x, y)
+	}
+	// And
+	for _, s := range NewJitteredSampler().Samples(64, rand.New(rand.NewSource(5))) {
+		if x, y := ConcentricDisk(s); x*x+y*y > 1+tuples.Epsilon {
+			t.Errorf("ConcentricDisk(%v) = (%9.6f, %9.6f), expected inside the unit disk", s, x, y)
+		}
+	}
+}
+
+// Scenario: Samples on a hexagon stay inside the hexagon
+// Given samples ← 64 jittered samples
+// When points ← regular_polygon(samples, 6, 0)
+// Then every point lies within the inscribed radius cos(π/6) when measured along the normal of its side
+func Test_Samples_on_a_Hexagon_Stay_inside_the_Hexagon(t *testing.T) {
+	// Given
+	samples := NewJitteredSampler().Samples(64, rand.New(rand.NewSource(9)))
+	inscribed := math.Cos(math.Pi / 6)
+	// Then
+	for _, s := range samples {
+		x, y := RegularPolygon(s, 6, 0)
+		for side := 0; side < 6; side++ {
+			normal := math.Pi/6 + float64(side)*math.Pi/3
+			if d := x*math.Cos(normal) + y*math.Sin(normal); d > inscribed+tuples.Epsilon {
+				t.Errorf("RegularPolygon(%v, 6, 0) = (%9.6f, %9.6f), expected inside the hexagon", s, x, y)
+			}
+		}
+	}
+}
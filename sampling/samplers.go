@@ -0,0 +1,112 @@
+package sampling
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Sample is a position within a pixel, both coordinates in [0, 1)
+type Sample struct {
+	X float64
+	Y float64
+}
+
+// String formats the Sample as a string
+func (s Sample) String() string {
+	return fmt.Sprintf("Sample( %9.6f, %9.6f )", s.X, s.Y)
+}
+
+// Sampler distributes sample positions over a pixel
+type Sampler interface {
+	// Samples returns n positions within a pixel, using rng for any randomness
+	Samples(n int, rng *rand.Rand) []Sample
+}
+
+// RegularSampler places samples in the centers of a regular grid
+type RegularSampler struct{}
+
+// JitteredSampler places one random sample in every cell of a regular grid
+type JitteredSampler struct{}
+
+// HaltonSampler places samples along the low-discrepancy Halton sequence in bases 2 and 3,
+// randomly shifted per pixel to avoid repeating the same pattern in every pixel
+type HaltonSampler struct{}
+
+// NewRegularSampler creates a new RegularSampler
+func NewRegularSampler() RegularSampler {
+	return RegularSampler{}
+}
+
+// NewJitteredSampler creates a new JitteredSampler
+func NewJitteredSampler() JitteredSampler {
+	return JitteredSampler{}
+}
+
+// NewHaltonSampler creates a new HaltonSampler
+func NewHaltonSampler() HaltonSampler {
+	return HaltonSampler{}
+}
+
+// Samples places samples in the centers of the cells of a grid
+// The grid is as square as possible, with rows * columns = n
+func (s RegularSampler) Samples(n int, rng *rand.Rand) []Sample {
+	rows, cols := gridSize(n)
+	result := make([]Sample, 0, n)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			result = append(result, Sample{(float64(col) + 0.5) / float64(cols), (float64(row) + 0.5) / float64(rows)})
+		}
+	}
+	return result
+}
+
+// Samples places a random sample in every cell of a grid
+// The grid is as square as possible, with rows * columns = n
+func (s JitteredSampler) Samples(n int, rng *rand.Rand) []Sample {
+	rows, cols := gridSize(n)
+	result := make([]Sample, 0, n)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			result = append(result, Sample{(float64(col) + rng.Float64()) / float64(cols), (float64(row) + rng.Float64()) / float64(rows)})
+		}
+	}
+	return result
+}
+
+// Samples returns the first n points of the Halton sequence, shifted by a random offset
+func (s HaltonSampler) Samples(n int, rng *rand.Rand) []Sample {
+	offsetX := rng.Float64()
+	offsetY := rng.Float64()
+	result := make([]Sample, n)
+	for i := 0; i < n; i++ {
+		x := RadicalInverse(i+1, 2) + offsetX
+		y := RadicalInverse(i+1, 3) + offsetY
+		result[i] = Sample{x - math.Floor(x), y - math.Floor(y)}
+	}
+	return result
+}
+
+// RadicalInverse mirrors the digits of i in the specified base around the decimal point
+func RadicalInverse(i int, base int) float64 {
+	result := 0.0
+	fraction := 1.0 / float64(base)
+	for i > 0 {
+		result += float64(i%base) * fraction
+		i /= base
+		fraction /= float64(base)
+	}
+	return result
+}
+
+// gridSize finds the most square grid of rows * cols = n cells
+func gridSize(n int) (int, int) {
+	if n < 1 {
+		return 0, 0
+	}
+	rows := int(math.Sqrt(float64(n)))
+	for n%rows != 0 {
+		rows--
+	}
+	return rows, n / rows
+}
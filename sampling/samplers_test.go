@@ -0,0 +1,112 @@
+package sampling
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: A regular sampler places samples in the centers of a grid
+// Given sampler ← regular_sampler()
+// When samples ← samples(sampler, 4)
+// Then samples = [(0.25, 0.25), (0.75, 0.25), (0.25, 0.75), (0.75, 0.75)]
+func Test_a_Regular_Sampler_Places_Samples_in_the_Centers_of_a_Grid(t *testing.T) {
+	// Given
+	sampler := NewRegularSampler()
+	// When
+	samples := sampler.Samples(4, rand.New(rand.NewSource(1)))
+	// Expected
+	wanted := []Sample{{0.25, 0.25}, {0.75, 0.25}, {0.25, 0.75}, {0.75, 0.75}}
+	// Then
+	if len(samples) != len(wanted) {
+		t.Fatalf("Samples(4) returned %d samples, expected %d", len(samples), len(wanted))
+	}
+	for i := range wanted {
+		if samples[i] != wanted[i] {
+			t.Errorf("samples[%d] = %v, expected %v", i, samples[i], wanted[i])
+		}
+	}
+}
+
+// Scenario: A single regular sample lies in the center of the pixel
+// Given sampler ← regular_sampler()
+// When samples ← samples(sampler, 1)
+// Then samples = [(0.5, 0.5)]
+func Test_a_Single_Regular_Sample_Lies_in_the_Center_of_the_Pixel(t *testing.T) {
+	// Given
+	sampler := NewRegularSampler()
+	// When
+	samples := sampler.Samples(1, rand.New(rand.NewSource(1)))
+	// Then
+	if len(samples) != 1 || samples[0] != (Sample{0.5, 0.5}) {
+		t.Errorf("Samples(1) = %v, expected [%v]", samples, Sample{0.5, 0.5})
+	}
+}
+
+// Scenario: A jittered sampler places one sample in every cell of a grid
+// Given sampler ← jittered_sampler()
+// When samples ← samples(sampler, 6)
+// Then samples[i] lies in cell i of a 3 by 2 grid
+func Test_a_Jittered_Sampler_Places_One_Sample_in_Every_Cell_of_a_Grid(t *testing.T) {
+	// Given
+	sampler := NewJitteredSampler()
+	// When
+	samples := sampler.Samples(6, rand.New(rand.NewSource(7)))
+	// Then
+	if len(samples) != 6 {
+		t.Fatalf("Samples(6) returned %d samples, expected %d", len(samples), 6)
+	}
+	for i, s := range samples {
+		row, col := i/3, i%3
+		if int(s.X*3) != col || int(s.Y*2) != row {
+			t.Errorf("samples[%d] = %v, expected it in cell (%d, %d)", i, s, col, row)
+		}
+	}
+}
+
+// Scenario: The radical inverse mirrors the digits of a number
+// Then radical_inverse(1, 2) = 0.5
+// And radical_inverse(6, 2) = 0.375
+// And radical_inverse(5, 3) = 0.777...
+func Test_the_Radical_Inverse_Mirrors_the_Digits_of_a_Number(t *testing.T) {
+	cases := []struct {
+		i      int
+		base   int
+		wanted float64
+	}{
+		{1, 2, 0.5},
+		{6, 2, 0.375},
+		{5, 3, 7.0 / 9.0},
+	}
+	for _, c := range cases {
+		if r := RadicalInverse(c.i, c.base); math.Abs(r-c.wanted) > tuples.Epsilon {
+			t.Errorf("RadicalInverse(%d, %d) = %9.6f, expected %9.6f", c.i, c.base, r, c.wanted)
+		}
+	}
+}
+
+// Scenario: Halton samples cover every quarter of the pixel
+// Given sampler ← halton_sampler()
+// When samples ← samples(sampler, 16)
+// Then every quadrant of the pixel contains 4 samples
+func Test_Halton_Samples_Cover_Every_Quarter_of_the_Pixel(t *testing.T) {
+	// Given
+	sampler := NewHaltonSampler()
+	// When
+	samples := sampler.Samples(16, rand.New(rand.NewSource(3)))
+	// Then
+	counts := map[[2]int]int{}
+	for _, s := range samples {
+		if s.X < 0 || s.X >= 1 || s.Y < 0 || s.Y >= 1 {
+			t.Errorf("%v lies outside of the pixel", s)
+		}
+		counts[[2]int{int(s.X * 2), int(s.Y * 2)}]++
+	}
+	for quadrant, count := range counts {
+		if count < 2 || count > 6 {
+			t.Errorf("quadrant %v contains %d samples, expected about 4", quadrant, count)
+		}
+	}
+}
@@ -4,55 +4,206 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/bas-velthuizen/go-raytracer/bounds"
 	"github.com/bas-velthuizen/go-raytracer/materials"
 
 	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 )
 
 // Sphere describes a sphere shape
+// A moving sphere has an end transform; its transform is interpolated between
+// the transform at time 0 and the end transform at time 1
+// The transform is set through SetTransform or SetMotion, which copy it and cache its inverse
 type Sphere struct {
-	Center    tuples.Tuple
-	Radius    float64
-	Transform matrix.Matrix
-	Material  materials.Material
+	Center       tuples.Point
+	Radius       float64
+	Material     materials.Material
+	transform    matrix.Matrix
+	endTransform *matrix.Matrix
+	inverse      matrix.Matrix4
 }
 
 // NewSphere creates a new Sphere instance
-func NewSphere(center tuples.Tuple, radius float64) *Sphere {
-	return &Sphere{center, radius, *matrix.Identity(4), materials.DefaultMaterial()}
+func NewSphere(center tuples.Point, radius float64) *Sphere {
+	return &Sphere{
+		Center:    center,
+		Radius:    radius,
+		Material:  materials.DefaultMaterial(),
+		transform: *matrix.Identity(4),
+		inverse:   matrix.Identity4(),
+	}
 }
 
 // NewUnitSphere creates a new Sphere instance
 func NewUnitSphere() *Sphere {
-	return NewSphere(tuples.Point(0, 0, 0), 1.0)
+	return NewSphere(tuples.NewPoint(0, 0, 0), 1.0)
 }
 
 // String formats Object to readable string
 func (s Sphere) String() string {
-	return fmt.Sprintf("Sphere( %v, %v, %v, %v )", s.Center, s.Radius, s.Transform, s.Material)
+	if s.IsMoving() {
+		return fmt.Sprintf("Sphere( %v, %v, %v -> %v, %v )", s.Center, s.Radius, s.transform, *s.endTransform, s.Material)
+	}
+	return fmt.Sprintf("Sphere( %v, %v, %v, %v )", s.Center, s.Radius, s.transform, s.Material)
 }
 
 // SetTransform sets the transform value of the sphere
-func (s *Sphere) SetTransform(transform *matrix.Matrix) {
-	s.Transform = *transform
+// A transform that is not 4x4 or cannot be inverted is rejected, leaving the sphere unchanged
+func (s *Sphere) SetTransform(transform *matrix.Matrix) error {
+	inverse, err := checkedInverse(*transform)
+	if err != nil {
+		return fmt.Errorf("sphere transform %v: %w", transform, err)
+	}
+	s.transform = *transform.Copy()
+	s.endTransform = nil
+	s.inverse = inverse
 	// fmt.Printf("sphere with new transform: %v\n\n", s)
+	return nil
+}
+
+// SetMotion makes the sphere move from the start transform at time 0 to the end transform at time 1
+// Transforms that are not 4x4 or cannot be inverted, or whose interpolation cannot be inverted at some time, are rejected,
+// leaving the sphere unchanged
+func (s *Sphere) SetMotion(start, end *matrix.Matrix) error {
+	inverse, err := checkedInverse(*start)
+	if err != nil {
+		return fmt.Errorf("sphere start transform %v: %w", start, err)
+	}
+	if _, err := checkedInverse(*end); err != nil {
+		return fmt.Errorf("sphere end transform %v: %w", end, err)
+	}
+	if !transformations.InterpolationIsInvertible(*start, *end) {
+		return fmt.Errorf("sphere motion from %v to %v: %w on the way", start, end, matrix.ErrNotInvertible)
+	}
+	s.transform = *start.Copy()
+	s.endTransform = end.Copy()
+	s.inverse = inverse
+	return nil
+}
+
+// checkedInverse calculates the inverse of a transform,
+// or returns ErrDimensionMismatch if it is not 4x4 or ErrNotInvertible if it cannot be inverted
+func checkedInverse(transform matrix.Matrix) (matrix.Matrix4, error) {
+	m4, err := transform.CheckedToMatrix4()
+	if err != nil {
+		return matrix.Matrix4{}, err
+	}
+	return m4.CheckedInverse()
+}
+
+// Transform returns a copy of the transform of the sphere, or of its transform at time 0 when it is moving
+func (s Sphere) Transform() matrix.Matrix {
+	return *s.transform.Copy()
+}
+
+// EndTransform returns a copy of the transform of a moving sphere at time 1, or nil when the sphere is not moving
+func (s Sphere) EndTransform() *matrix.Matrix {
+	if !s.IsMoving() {
+		return nil
+	}
+	return s.endTransform.Copy()
+}
+
+// IsMoving checks if the transform of the sphere changes over time
+func (s Sphere) IsMoving() bool {
+	return s.endTransform != nil
+}
+
+// TransformAt calculates the transform of the sphere at a certain time
+// Times outside of [0, 1] are clamped to the start or end of the motion
+func (s Sphere) TransformAt(time float64) *matrix.Matrix {
+	if !s.IsMoving() {
+		return s.transform.Copy()
+	}
+	return transformations.Interpolate(s.transform, *s.endTransform, math.Max(0, math.Min(1, time)))
+}
+
+// InverseAt returns the inverse of the transform of the sphere at a certain time
+func (s Sphere) InverseAt(time float64) matrix.Matrix4 {
+	if !s.IsMoving() {
+		return s.inverse
+	}
+	return s.TransformAt(time).ToMatrix4().Inverse()
+}
+
+// Bounds calculates the box that contains the sphere, or for a moving sphere its start and end positions
+func (s Sphere) Bounds() bounds.Box {
+	radius := tuples.NewVector(s.Radius, s.Radius, s.Radius)
+	object := bounds.NewBox(s.Center.SubtractVector(radius), s.Center.Add(radius))
+	if !s.IsMoving() {
+		return object.Transform(s.transform)
+	}
+	return object.Transform(*s.TransformAt(0)).Union(object.Transform(*s.TransformAt(1)))
+}
+
+// ShapePointAt maps a world point at a certain time to the space of the unit sphere around the origin,
+// in which the sphere is textured
+func (s Sphere) ShapePointAt(worldPoint tuples.Point, time float64) tuples.Point {
+	return s.shapePoint(s.InverseAt(time).MultiplyPoint(worldPoint))
 }
 
 // NormalAt calculates the normal vector on a sphere at a certain world point
-func (s Sphere) NormalAt(worldPoint tuples.Tuple) *tuples.Tuple {
-	objectPoint := s.Transform.Inverse().MultiplyTuple(worldPoint)
-	objectNormal := objectPoint.Subtract(s.Center)
-	worldNormal := s.Transform.Inverse().Transpose().MultiplyTuple(objectNormal)
-	worldNormal.W = 0
-	normal := worldNormal.Normalize()
+func (s Sphere) NormalAt(worldPoint tuples.Point) *tuples.Normal {
+	return s.NormalAtTime(worldPoint, 0)
+}
+
+// NormalAtTime calculates the normal vector on a sphere at a certain world point and time
+func (s Sphere) NormalAtTime(worldPoint tuples.Point, time float64) *tuples.Normal {
+	inverse := s.InverseAt(time)
+	objectPoint := inverse.MultiplyPoint(worldPoint)
+	objectNormal := tuples.Normal(objectPoint.Subtract(s.Center))
+	normal := inverse.TransformNormal(objectNormal).Normalize()
+	return &normal
+}
+
+// ShadingNormalAtTime calculates the normal vector used for shading the sphere at a certain world point and time,
+// which is the normal changed by the Bump of its material
+func (s Sphere) ShadingNormalAtTime(worldPoint tuples.Point, time float64) *tuples.Normal {
+	if s.Material.Bump == nil {
+		return s.NormalAtTime(worldPoint, time)
+	}
+	inverse := s.InverseAt(time)
+	objectPoint := inverse.MultiplyPoint(worldPoint)
+	objectNormal := s.Material.Bump.Perturb(s.shapePoint(objectPoint), tuples.Normal(objectPoint.Subtract(s.Center)))
+	normal := inverse.TransformNormal(objectNormal).Normalize()
 	return &normal
 }
 
+// SampleSurface maps u and v, both in [0, 1), to a point on the sphere at a certain time and its normal,
+// spreading uniformly distributed u and v uniformly over the sphere before its transform
+// The area is the reciprocal of the probability density of the point per unit of area in the world,
+// which differs between points when the transform stretches the sphere unevenly
+func (s Sphere) SampleSurface(u, v float64, time float64) (tuples.Point, tuples.Normal, float64) {
+	z := 1 - 2*u
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	phi := 2 * math.Pi * v
+	direction := tuples.NewNormal(r*math.Cos(phi), r*math.Sin(phi), z)
+	objectPoint := s.Center.Add(direction.Vector().Multiply(s.Radius))
+
+	transform := s.TransformAt(time).ToMatrix4()
+	inverse := s.InverseAt(time)
+	worldNormal := inverse.TransformNormal(direction)
+	// an area element around the normal grows with the determinant of the transform,
+	// and shrinks with the scale of the transformed normal
+	scale := math.Abs(transform.Determinant()) * worldNormal.Vector().Magnitude()
+	area := 4 * math.Pi * s.Radius * s.Radius * scale
+	return transform.MultiplyPoint(objectPoint), worldNormal.Normalize(), area
+}
+
+// shapePoint maps an object point to the space of the unit sphere around the origin
+func (s Sphere) shapePoint(objectPoint tuples.Point) tuples.Point {
+	offset := objectPoint.Subtract(s.Center).DivideBy(s.Radius)
+	return tuples.NewPoint(offset.X, offset.Y, offset.Z)
+}
+
 // Equals checks if another sphere is equal to the current sphere
 func (s Sphere) Equals(other Sphere) bool {
 	return s.Center.Equals(other.Center) &&
 		s.Material.Equals(other.Material) &&
 		(math.Abs(s.Radius-other.Radius) < tuples.Epsilon) &&
-		s.Transform.Equals(other.Transform)
+		s.transform.Equals(other.transform) &&
+		s.IsMoving() == other.IsMoving() &&
+		(!s.IsMoving() || s.endTransform.Equals(*other.endTransform))
 }
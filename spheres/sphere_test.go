@@ -1,10 +1,13 @@
 package spheres
 
 import (
+	"errors"
 	"math"
 	"testing"
 
+	"github.com/bas-velthuizen/go-raytracer/colors"
 	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/textures"
 
 	"github.com/bas-velthuizen/go-raytracer/transformations"
 
@@ -21,8 +24,8 @@ func Test_Sphere_has_Default_Transformation(t *testing.T) {
 	// Expected
 	wanted := matrix.Identity(4)
 	// Then
-	if !wanted.Equals(s.Transform) {
-		t.Errorf("Transform of %v = %v, expected %v", s, s.Transform, wanted)
+	if !wanted.Equals(s.Transform()) {
+		t.Errorf("Transform of %v = %v, expected %v", s, s.Transform(), wanted)
 	}
 }
 
@@ -39,8 +42,8 @@ func Test_Changing_a_Sphere_s_Transformation(t *testing.T) {
 	// When
 	s.SetTransform(trans)
 	// Then
-	if !trans.Equals(s.Transform) {
-		t.Errorf("Transform of %v = %v, expected %v", s, s.Transform, trans)
+	if !trans.Equals(s.Transform()) {
+		t.Errorf("Transform of %v = %v, expected %v", s, s.Transform(), trans)
 	}
 }
 
@@ -52,11 +55,11 @@ func Test_Normal_of_a_Sphere_at_a_Point_on_the_X_Axis(t *testing.T) {
 	// Given
 	s := NewUnitSphere()
 	// And
-	point := tuples.Point(1, 0, 0)
+	point := tuples.NewPoint(1, 0, 0)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(1, 0, 0)
+	wanted := tuples.NewNormal(1, 0, 0)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -71,11 +74,11 @@ func Test_Normal_of_a_Sphere_at_a_Point_on_the_Y_Axis(t *testing.T) {
 	// Given
 	s := NewUnitSphere()
 	// And
-	point := tuples.Point(0, 1, 0)
+	point := tuples.NewPoint(0, 1, 0)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(0, 1, 0)
+	wanted := tuples.NewNormal(0, 1, 0)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -90,11 +93,11 @@ func Test_Normal_of_a_Sphere_at_a_Point_on_the_Z_Axis(t *testing.T) {
 	// Given
 	s := NewUnitSphere()
 	// And
-	point := tuples.Point(0, 0, 1)
+	point := tuples.NewPoint(0, 0, 1)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(0, 0, 1)
+	wanted := tuples.NewNormal(0, 0, 1)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -110,11 +113,11 @@ func Test_Normal_of_a_Sphere_at_a_NonAxial_Point(t *testing.T) {
 	s := NewUnitSphere()
 	// And
 	val := math.Sqrt(3) / 3.0
-	point := tuples.Point(val, val, val)
+	point := tuples.NewPoint(val, val, val)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(val, val, val)
+	wanted := tuples.NewNormal(val, val, val)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -130,15 +133,11 @@ func Test_Normal_is_a_Normalized_Vector(t *testing.T) {
 	s := NewUnitSphere()
 	// And
 	val := math.Sqrt(3) / 3.0
-	point := tuples.Point(val, val, val)
+	point := tuples.NewPoint(val, val, val)
 	// When
 	n := s.NormalAt(point)
 	// Expected
 	wanted := n.Normalize()
-	// And
-	if !n.IsVector() {
-		t.Errorf("normal_at( %v, %v ) is Point , wanted Vector", s, point)
-	}
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v (normalized) , wanted %v", s, point, n, wanted)
@@ -156,11 +155,11 @@ func Test_Computing_the_Normal_on_a_Translated_Sphere(t *testing.T) {
 	// And
 	s.SetTransform(transformations.Translation(0, 1, 0))
 	// And
-	point := tuples.Point(0, 1.70711, -0.70711)
+	point := tuples.NewPoint(0, 1.70711, -0.70711)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(0, 0.70711, -0.70711)
+	wanted := tuples.NewNormal(0, 0.70711, -0.70711)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -178,11 +177,11 @@ func Test_Computing_the_Normal_on_a_scaled_Sphere(t *testing.T) {
 	// And
 	s.SetTransform(transformations.Scaling(1, 0.5, 1))
 	// And
-	point := tuples.Point(0, math.Sqrt(2)/2.0, -math.Sqrt(2)/2.0)
+	point := tuples.NewPoint(0, math.Sqrt(2)/2.0, -math.Sqrt(2)/2.0)
 	// When
 	n := s.NormalAt(point)
 	// Expected
-	wanted := tuples.Vector(0, 0.97014, -0.24254)
+	wanted := tuples.NewNormal(0, 0.97014, -0.24254)
 	// Then
 	if !wanted.Equals(*n) {
 		t.Errorf("normal_at( %v, %v ) = %v , wanted %v", s, point, n, wanted)
@@ -223,3 +222,299 @@ func Test_a_Sphere_May_Be_Assigned_a_Material(t *testing.T) {
 		t.Errorf("%v has material %v, expected %v", s, s.Material, m)
 	}
 }
+
+// Scenario: A moving sphere interpolates its transformation over time
+// Given s ← sphere()
+// When set_motion(s, translation(0, 0, 0), translation(2, 4, 6))
+// Then transform_at(s, 0) = translation(0, 0, 0)
+// And transform_at(s, 0.5) = translation(1, 2, 3)
+// And transform_at(s, 1) = translation(2, 4, 6)
+// And transform_at(s, 2) = translation(2, 4, 6)
+func Test_a_Moving_Sphere_Interpolates_its_Transformation_over_Time(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// When
+	s.SetMotion(transformations.Translation(0, 0, 0), transformations.Translation(2, 4, 6))
+	// Expected
+	cases := []struct {
+		time   float64
+		wanted *matrix.Matrix
+	}{
+		{0, transformations.Translation(0, 0, 0)},
+		{0.5, transformations.Translation(1, 2, 3)},
+		{1, transformations.Translation(2, 4, 6)},
+		{2, transformations.Translation(2, 4, 6)},
+	}
+	// Then
+	for _, c := range cases {
+		if m := s.TransformAt(c.time); !c.wanted.Equals(*m) {
+			t.Errorf("%v.TransformAt(%9.6f) = %v, expected %v", s, c.time, m, c.wanted)
+		}
+	}
+}
+
+// Scenario: The normal on a moving sphere depends on time
+// Given s ← sphere()
+// And set_motion(s, translation(0, 0, 0), translation(2, 0, 0))
+// When n ← normal_at(s, point(1, 0, 1), 0.5)
+// Then n = vector(0, 0, 1)
+func Test_the_Normal_on_a_Moving_Sphere_Depends_on_Time(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	s.SetMotion(transformations.Translation(0, 0, 0), transformations.Translation(2, 0, 0))
+	// When
+	n := s.NormalAtTime(tuples.NewPoint(1, 0, 1), 0.5)
+	// Expected
+	wanted := tuples.NewNormal(0, 0, 1)
+	// Then
+	if !wanted.Equals(*n) {
+		t.Errorf("%v.NormalAtTime(%v, 0.5) = %v, expected %v", s, tuples.NewPoint(1, 0, 1), n, wanted)
+	}
+}
+
+// Scenario: Setting a transformation stops the motion of a sphere
+// Given s ← sphere()
+// And set_motion(s, translation(0, 0, 0), translation(2, 0, 0))
+// When set_transform(s, translation(1, 0, 0))
+// Then s is not moving
+func Test_Setting_a_Transformation_Stops_the_Motion_of_a_Sphere(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	s.SetMotion(transformations.Translation(0, 0, 0), transformations.Translation(2, 0, 0))
+	// When
+	s.SetTransform(transformations.Translation(1, 0, 0))
+	// Then
+	if s.IsMoving() {
+		t.Errorf("%v is moving, expected it to be static", s)
+	}
+}
+
+// Scenario: Setting a transformation caches its inverse
+// Given s ← sphere()
+// When set_transform(s, scaling(2, 4, 8))
+// Then inverse_at(s, 0) = inverse(scaling(2, 4, 8))
+func Test_Setting_a_Transformation_Caches_its_Inverse(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// When
+	trans := transformations.Scaling(2, 4, 8)
+	s.SetTransform(trans)
+	// Expected
+	wanted := trans.Inverse().ToMatrix4()
+	// Then
+	if inverse := s.InverseAt(0); !wanted.Equals(inverse) {
+		t.Errorf("%v.InverseAt(0) = %v, expected %v", s, inverse, wanted)
+	}
+}
+
+// Scenario: A sphere rejects a transformation that is not 4x4
+// Given s ← sphere()
+// When err ← set_transform(s, identity_matrix of size 3)
+// Then err is ErrDimensionMismatch
+// And s.transform = identity_matrix
+// And set_motion(s, identity_matrix, identity_matrix of size 3) fails with ErrDimensionMismatch
+func Test_a_Sphere_Rejects_a_Transformation_that_is_not_4x4(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// When
+	err := s.SetTransform(matrix.Identity(3))
+	// Then
+	if !errors.Is(err, matrix.ErrDimensionMismatch) {
+		t.Errorf("SetTransform(%v) returned error %v, expected %v", matrix.Identity(3), err, matrix.ErrDimensionMismatch)
+	}
+	// And
+	if trans := s.Transform(); !matrix.Identity(4).Equals(trans) {
+		t.Errorf("%v.Transform() = %v, expected %v", s, trans, matrix.Identity(4))
+	}
+	// And
+	if err := s.SetMotion(matrix.Identity(4), matrix.Identity(3)); !errors.Is(err, matrix.ErrDimensionMismatch) {
+		t.Errorf("SetMotion to %v returned error %v, expected %v", matrix.Identity(3), err, matrix.ErrDimensionMismatch)
+	}
+}
+
+// Scenario: A sphere keeps its own copy of its transformation
+// Given s ← sphere()
+// And m ← scaling(2, 2, 2)
+// And set_transform(s, m)
+// When m is changed to scaling(4, 2, 2)
+// And transform(s) is changed to scaling(8, 2, 2)
+// Then s.transform = scaling(2, 2, 2)
+// And inverse_at(s, 0) = inverse(scaling(2, 2, 2))
+func Test_a_Sphere_Keeps_its_Own_Copy_of_its_Transformation(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	m := transformations.Scaling(2, 2, 2)
+	s.SetTransform(m)
+	// When
+	m.Set(0, 0, 4)
+	// And
+	s.Transform().Set(0, 0, 8)
+	// Expected
+	wanted := transformations.Scaling(2, 2, 2)
+	// Then
+	if trans := s.Transform(); !wanted.Equals(trans) {
+		t.Errorf("%v.Transform() = %v, expected %v", s, trans, wanted)
+	}
+	// And
+	if inverse := s.InverseAt(0); !wanted.Inverse().ToMatrix4().Equals(inverse) {
+		t.Errorf("%v.InverseAt(0) = %v, expected %v", s, inverse, wanted.Inverse().ToMatrix4())
+	}
+}
+
+// Scenario: A moving sphere keeps its own copy of its end transformation
+// Given s ← sphere()
+// And end ← translation(2, 0, 0)
+// And set_motion(s, identity_matrix, end)
+// When end is changed to translation(4, 0, 0)
+// And end_transform(s) is changed to translation(8, 0, 0)
+// Then end_transform(s) = translation(2, 0, 0)
+func Test_a_Moving_Sphere_Keeps_its_Own_Copy_of_its_End_Transformation(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	end := transformations.Translation(2, 0, 0)
+	s.SetMotion(matrix.Identity(4), end)
+	// When
+	end.Set(0, 3, 4)
+	// And
+	s.EndTransform().Set(0, 3, 8)
+	// Expected
+	wanted := transformations.Translation(2, 0, 0)
+	// Then
+	if trans := s.EndTransform(); !wanted.Equals(*trans) {
+		t.Errorf("%v.EndTransform() = %v, expected %v", s, trans, wanted)
+	}
+}
+
+// Scenario: A sphere rejects a transformation that cannot be inverted
+// Given s ← sphere()
+// And set_transform(s, translation(1, 2, 3))
+// When err ← set_transform(s, scaling(40, 40, 0))
+// Then err is not nil
+// And s.transform = translation(1, 2, 3)
+// And set_motion(s, translation(1, 2, 3), scaling(0, 1, 1)) fails
+// And set_motion(s, translation(1, 2, 3), scaling(1, -1, 1)) fails
+func Test_a_Sphere_Rejects_a_Transformation_that_Cannot_be_Inverted(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	trans := transformations.Translation(1, 2, 3)
+	s.SetTransform(trans)
+	// When
+	err := s.SetTransform(transformations.Scaling(40, 40, 0))
+	// Then
+	if err == nil {
+		t.Errorf("SetTransform(%v) succeeded, expected an error", transformations.Scaling(40, 40, 0))
+	}
+	// And
+	if !trans.Equals(s.Transform()) {
+		t.Errorf("Transform of %v = %v, expected %v", s, s.Transform(), trans)
+	}
+	// And
+	if err := s.SetMotion(trans, transformations.Scaling(0, 1, 1)); err == nil {
+		t.Errorf("SetMotion(%v, %v) succeeded, expected an error", trans, transformations.Scaling(0, 1, 1))
+	}
+	// And
+	if err := s.SetMotion(trans, transformations.Scaling(1, -1, 1)); err == nil {
+		t.Errorf("SetMotion(%v, %v) succeeded, expected an error", trans, transformations.Scaling(1, -1, 1))
+	}
+}
+
+// Scenario: The shading normal of a bumped sphere is tilted
+// Given s ← sphere()
+// And s.material.bump ← bump_map(height: 0.5 * x, strength: 1)
+// When n ← shading_normal_at(s, point(0, 1, 0))
+// And g ← normal_at(s, point(0, 1, 0))
+// Then n = normalize(normal(-0.5, 1, 0))
+// And g = normal(0, 1, 0)
+func Test_the_Shading_Normal_of_a_Bumped_Sphere_is_Tilted(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	s.Material.Bump = textures.NewBumpMap(textures.NewPatternHeight(xGradient{}), 1)
+	// When
+	n := s.ShadingNormalAtTime(tuples.NewPoint(0, 1, 0), 0)
+	// And
+	g := s.NormalAt(tuples.NewPoint(0, 1, 0))
+	// Then
+	if wanted := tuples.NewNormal(-0.5, 1, 0).Normalize(); !n.Equals(wanted) {
+		t.Errorf("ShadingNormalAtTime() = %v, expected %v", n, wanted)
+	}
+	// And
+	if wanted := tuples.NewNormal(0, 1, 0); !g.Equals(wanted) {
+		t.Errorf("NormalAt() = %v, expected %v", g, wanted)
+	}
+}
+
+// xGradient is a gray Pattern with a luminance of 0.5 * x
+type xGradient struct{}
+
+func (xGradient) ColorAt(p tuples.Point) colors.Color {
+	return colors.NewColor(0.5*p.X, 0.5*p.X, 0.5*p.X)
+}
+
+// Scenario: Sampling the surface of a transformed sphere
+// Given s ← sphere()
+// And set_transform(s, translation(0, 1, 0) * scaling(2, 2, 2))
+// When (p, n, area) ← sample_surface(s, u, v, 0) for a grid of u and v
+// Then p lies at distance 2 from point(0, 1, 0)
+// And n points away from point(0, 1, 0)
+// And area = 16π
+func Test_Sampling_the_Surface_of_a_Transformed_Sphere(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	s.SetTransform(transformations.Translation(0, 1, 0).Multiply(*transformations.Scaling(2, 2, 2)))
+	center := tuples.NewPoint(0, 1, 0)
+	for _, u := range []float64{0, 0.1, 0.5, 0.9} {
+		for _, v := range []float64{0, 0.3, 0.7} {
+			// When
+			p, n, area := s.SampleSurface(u, v, 0)
+			// Then
+			offset := p.Subtract(center)
+			if math.Abs(offset.Magnitude()-2) > tuples.Epsilon {
+				t.Errorf("SampleSurface( %v, %v ) = %v, expected a point at distance 2 from %v", u, v, p, center)
+			}
+			// And
+			if !n.Equals(tuples.Normal(offset.Normalize())) {
+				t.Errorf("SampleSurface( %v, %v ) has normal %v, expected %v", u, v, n, offset.Normalize())
+			}
+			// And
+			if math.Abs(area-16*math.Pi) > tuples.Epsilon {
+				t.Errorf("SampleSurface( %v, %v ) has area %v, expected %v", u, v, area, 16*math.Pi)
+			}
+		}
+	}
+}
+
+// Scenario: The areas of samples on a stretched sphere add up to its surface area
+// Given s ← sphere()
+// And set_transform(s, scaling(3, 1, 1))
+// When total ← the mean area of sample_surface(s, u, v, 0) over a fine grid of u and v
+// Then total = the surface area of the ellipsoid with semi-axes 3, 1 and 1
+func Test_the_Areas_of_Samples_on_a_Stretched_Sphere_Add_up_to_its_Surface_Area(t *testing.T) {
+	// Given
+	s := NewUnitSphere()
+	// And
+	s.SetTransform(transformations.Scaling(3, 1, 1))
+	// When
+	const steps = 200
+	total := 0.0
+	for i := 0; i < steps; i++ {
+		for j := 0; j < steps; j++ {
+			_, _, area := s.SampleSurface((float64(i)+0.5)/steps, (float64(j)+0.5)/steps, 0)
+			total += area
+		}
+	}
+	total /= steps * steps
+	// Expected: the prolate spheroid with a = 3, b = 1 has area 2πb² + 2πab·asin(e)/e
+	e := math.Sqrt(1 - 1.0/9)
+	wanted := 2*math.Pi + 2*math.Pi*3*math.Asin(e)/e
+	// Then
+	if math.Abs(total-wanted) > 1e-3*wanted {
+		t.Errorf("mean area = %v, expected %v", total, wanted)
+	}
+}
@@ -0,0 +1,139 @@
+package textures
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Mapping maps a point on the surface of a shape to the texture coordinates u and v, both in [0, 1)
+type Mapping interface {
+	Map(p tuples.Point) (u, v float64)
+}
+
+// SphericalMapping wraps a texture around the unit sphere, like a map of the world around a globe
+// u runs once around the y axis, v from the south pole at 0 to the north pole at 1
+type SphericalMapping struct{}
+
+// PlanarMapping tiles a texture over the xz plane, repeating it every TileWidth along x and every TileDepth along z
+// A TileWidth or TileDepth of 0 or less means a tile of 1
+type PlanarMapping struct {
+	TileWidth float64
+	TileDepth float64
+}
+
+// CylindricalMapping wraps a texture around a cylinder along the y axis, repeating it every unit of height
+type CylindricalMapping struct{}
+
+// CubeFace identifies a face of a cube
+type CubeFace int
+
+// The faces of the cube from -1 to 1; Front is the face at z = 1 and Up the face at y = 1
+const (
+	Left CubeFace = iota
+	Front
+	Right
+	Back
+	Up
+	Down
+)
+
+// NewSphericalMapping creates a new SphericalMapping
+func NewSphericalMapping() SphericalMapping {
+	return SphericalMapping{}
+}
+
+// NewPlanarMapping creates a new PlanarMapping with the size of a tile of the texture
+func NewPlanarMapping(tileWidth, tileDepth float64) PlanarMapping {
+	return PlanarMapping{tileWidth, tileDepth}
+}
+
+// NewCylindricalMapping creates a new CylindricalMapping
+func NewCylindricalMapping() CylindricalMapping {
+	return CylindricalMapping{}
+}
+
+// Map calculates the texture coordinates of a point on the unit sphere
+func (m SphericalMapping) Map(p tuples.Point) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	radius := tuples.NewVector(p.X, p.Y, p.Z).Magnitude()
+	phi := math.Acos(p.Y / radius)
+	rawU := theta / (2 * math.Pi)
+	u := 1 - (rawU + 0.5)
+	v := 1 - phi/math.Pi
+	return u, v
+}
+
+// Map calculates the texture coordinates of a point on the xz plane
+func (m PlanarMapping) Map(p tuples.Point) (float64, float64) {
+	return wrap(p.X/m.tileWidth(), 1), wrap(p.Z/m.tileDepth(), 1)
+}
+
+// tileWidth returns the TileWidth, or 1 when it is not set
+func (m PlanarMapping) tileWidth() float64 {
+	if m.TileWidth <= 0 {
+		return 1
+	}
+	return m.TileWidth
+}
+
+// tileDepth returns the TileDepth, or 1 when it is not set
+func (m PlanarMapping) tileDepth() float64 {
+	if m.TileDepth <= 0 {
+		return 1
+	}
+	return m.TileDepth
+}
+
+// Map calculates the texture coordinates of a point on the cylinder of radius 1 around the y axis
+func (m CylindricalMapping) Map(p tuples.Point) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	rawU := theta / (2 * math.Pi)
+	u := 1 - (rawU + 0.5)
+	return u, wrap(p.Y, 1)
+}
+
+// FaceFromPoint finds the face of the cube a point on its surface lies on
+func FaceFromPoint(p tuples.Point) CubeFace {
+	coord := math.Max(math.Abs(p.X), math.Max(math.Abs(p.Y), math.Abs(p.Z)))
+	switch coord {
+	case p.X:
+		return Right
+	case -p.X:
+		return Left
+	case p.Y:
+		return Up
+	case -p.Y:
+		return Down
+	case p.Z:
+		return Front
+	}
+	return Back
+}
+
+// CubeUV calculates the texture coordinates of a point on a face of the cube,
+// with u running left to right and v bottom to top, as seen from outside the face
+func CubeUV(face CubeFace, p tuples.Point) (float64, float64) {
+	switch face {
+	case Front:
+		return wrap(p.X+1, 2) / 2, wrap(p.Y+1, 2) / 2
+	case Back:
+		return wrap(1-p.X, 2) / 2, wrap(p.Y+1, 2) / 2
+	case Left:
+		return wrap(p.Z+1, 2) / 2, wrap(p.Y+1, 2) / 2
+	case Right:
+		return wrap(1-p.Z, 2) / 2, wrap(p.Y+1, 2) / 2
+	case Up:
+		return wrap(p.X+1, 2) / 2, wrap(1-p.Z, 2) / 2
+	}
+	return wrap(p.X+1, 2) / 2, wrap(p.Z+1, 2) / 2
+}
+
+// wrap calculates value modulo period, in [0, period) also for negative values
+func wrap(value, period float64) float64 {
+	result := math.Mod(value, period)
+	if result < 0 {
+		result += period
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package textures
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+type uvCase struct {
+	point tuples.Point
+	u, v  float64
+}
+
+func checkMapping(t *testing.T, name string, mapping func(tuples.Point) (float64, float64), cases []uvCase) {
+	t.Helper()
+	for _, c := range cases {
+		if u, v := mapping(c.point); math.Abs(u-c.u) > tuples.Epsilon || math.Abs(v-c.v) > tuples.Epsilon {
+			t.Errorf("%s( %v ) = (%g, %g), expected (%g, %g)", name, c.point, u, v, c.u, c.v)
+		}
+	}
+}
+
+// Scenario Outline: Using a spherical mapping on a 3D point
+// Given p ← <point>
+// When (u, v) ← spherical_map(p)
+// Then u = <u>
+// And v = <v>
+// Examples:
+// | point                | u    | v    |
+// | point(0, 0, -1)      | 0.0  | 0.5  |
+// | point(1, 0, 0)       | 0.25 | 0.5  |
+// | point(0, 0, 1)       | 0.5  | 0.5  |
+// | point(-1, 0, 0)      | 0.75 | 0.5  |
+// | point(0, 1, 0)       | 0.5  | 1.0  |
+// | point(0, -1, 0)      | 0.5  | 0.0  |
+// | point(√2/2, √2/2, 0) | 0.25 | 0.75 |
+func Test_Using_a_Spherical_Mapping_on_a_3D_Point(t *testing.T) {
+	checkMapping(t, "spherical_map", NewSphericalMapping().Map, []uvCase{
+		{tuples.NewPoint(0, 0, -1), 0.0, 0.5},
+		{tuples.NewPoint(1, 0, 0), 0.25, 0.5},
+		{tuples.NewPoint(0, 0, 1), 0.5, 0.5},
+		{tuples.NewPoint(-1, 0, 0), 0.75, 0.5},
+		{tuples.NewPoint(0, 1, 0), 0.5, 1.0},
+		{tuples.NewPoint(0, -1, 0), 0.5, 0.0},
+		{tuples.NewPoint(math.Sqrt2/2, math.Sqrt2/2, 0), 0.25, 0.75},
+	})
+}
+
+// Scenario Outline: Using a planar mapping on a 3D point
+// Given p ← <point>
+// When (u, v) ← planar_map(p)
+// Then u = <u>
+// And v = <v>
+// Examples:
+// | point                   | u    | v    |
+// | point(0.25, 0, 0.5)     | 0.25 | 0.5  |
+// | point(0.25, 0, -0.25)   | 0.25 | 0.75 |
+// | point(0.25, 0.5, -0.25) | 0.25 | 0.75 |
+// | point(1.25, 0, 0.5)     | 0.25 | 0.5  |
+// | point(0.25, 0, -1.75)   | 0.25 | 0.25 |
+// | point(1, 0, -1)         | 0.0  | 0.0  |
+// | point(0, 0, 0)          | 0.0  | 0.0  |
+func Test_Using_a_Planar_Mapping_on_a_3D_Point(t *testing.T) {
+	checkMapping(t, "planar_map", NewPlanarMapping(1, 1).Map, []uvCase{
+		{tuples.NewPoint(0.25, 0, 0.5), 0.25, 0.5},
+		{tuples.NewPoint(0.25, 0, -0.25), 0.25, 0.75},
+		{tuples.NewPoint(0.25, 0.5, -0.25), 0.25, 0.75},
+		{tuples.NewPoint(1.25, 0, 0.5), 0.25, 0.5},
+		{tuples.NewPoint(0.25, 0, -1.75), 0.25, 0.25},
+		{tuples.NewPoint(1, 0, -1), 0.0, 0.0},
+		{tuples.NewPoint(0, 0, 0), 0.0, 0.0},
+	})
+}
+
+// Scenario: A planar mapping tiles the texture
+// Given m ← planar_mapping(tile_width: 4, tile_depth: 0.5)
+// Then planar_map(m, point(5, 0, 0.75)) = (0.25, 0.5)
+// And planar_map(m, point(-1, 0, -0.125)) = (0.75, 0.75)
+func Test_a_Planar_Mapping_Tiles_the_Texture(t *testing.T) {
+	checkMapping(t, "planar_map", NewPlanarMapping(4, 0.5).Map, []uvCase{
+		{tuples.NewPoint(5, 0, 0.75), 0.25, 0.5},
+		{tuples.NewPoint(-1, 0, -0.125), 0.75, 0.75},
+	})
+}
+
+// Scenario: A planar mapping without a tile size tiles the texture every unit
+// Given m ← planar_mapping() without tile width and tile depth
+// Then planar_map(m, point(1.25, 0, -0.25)) = (0.25, 0.75)
+func Test_a_Planar_Mapping_without_a_Tile_Size_Tiles_the_Texture_every_Unit(t *testing.T) {
+	checkMapping(t, "planar_map", PlanarMapping{}.Map, []uvCase{
+		{tuples.NewPoint(1.25, 0, -0.25), 0.25, 0.75},
+	})
+}
+
+// Scenario Outline: Using a cylindrical mapping on a 3D point
+// Given p ← <point>
+// When (u, v) ← cylindrical_map(p)
+// Then u = <u>
+// And v = <v>
+// Examples:
+// | point                          | u     | v    |
+// | point(0, 0, -1)                | 0.0   | 0.0  |
+// | point(0, 0.5, -1)              | 0.0   | 0.5  |
+// | point(0, 1, -1)                | 0.0   | 0.0  |
+// | point(0.70711, 0.5, -0.70711)  | 0.125 | 0.5  |
+// | point(1, 0.5, 0)               | 0.25  | 0.5  |
+// | point(0.70711, 0.5, 0.70711)   | 0.375 | 0.5  |
+// | point(0, -0.25, 1)             | 0.5   | 0.75 |
+// | point(-0.70711, 0.5, 0.70711)  | 0.625 | 0.5  |
+// | point(-1, 1.25, 0)             | 0.75  | 0.25 |
+// | point(-0.70711, 0.5, -0.70711) | 0.875 | 0.5  |
+func Test_Using_a_Cylindrical_Mapping_on_a_3D_Point(t *testing.T) {
+	checkMapping(t, "cylindrical_map", NewCylindricalMapping().Map, []uvCase{
+		{tuples.NewPoint(0, 0, -1), 0.0, 0.0},
+		{tuples.NewPoint(0, 0.5, -1), 0.0, 0.5},
+		{tuples.NewPoint(0, 1, -1), 0.0, 0.0},
+		{tuples.NewPoint(0.70711, 0.5, -0.70711), 0.125, 0.5},
+		{tuples.NewPoint(1, 0.5, 0), 0.25, 0.5},
+		{tuples.NewPoint(0.70711, 0.5, 0.70711), 0.375, 0.5},
+		{tuples.NewPoint(0, -0.25, 1), 0.5, 0.75},
+		{tuples.NewPoint(-0.70711, 0.5, 0.70711), 0.625, 0.5},
+		{tuples.NewPoint(-1, 1.25, 0), 0.75, 0.25},
+		{tuples.NewPoint(-0.70711, 0.5, -0.70711), 0.875, 0.5},
+	})
+}
+
+// Scenario Outline: Identifying the face of a cube from a point
+// When face ← face_from_point(<point>)
+// Then face = <face>
+// Examples:
+// | point                  | face  |
+// | point(-1, 0.5, -0.25)  | left  |
+// | point(1.1, -0.75, 0.8) | right |
+// | point(0.1, 0.6, 0.9)   | front |
+// | point(-0.7, 0, -2)     | back  |
+// | point(0.5, 1, 0.9)     | up    |
+// | point(-0.2, -1.3, 1.1) | down  |
+func Test_Identifying_the_Face_of_a_Cube_from_a_Point(t *testing.T) {
+	cases := []struct {
+		point tuples.Point
+		face  CubeFace
+	}{
+		{tuples.NewPoint(-1, 0.5, -0.25), Left},
+		{tuples.NewPoint(1.1, -0.75, 0.8), Right},
+		{tuples.NewPoint(0.1, 0.6, 0.9), Front},
+		{tuples.NewPoint(-0.7, 0, -2), Back},
+		{tuples.NewPoint(0.5, 1, 0.9), Up},
+		{tuples.NewPoint(-0.2, -1.3, 1.1), Down},
+	}
+	for _, c := range cases {
+		// Then
+		if face := FaceFromPoint(c.point); face != c.face {
+			t.Errorf("FaceFromPoint( %v ) = %d, expected %d", c.point, face, c.face)
+		}
+	}
+}
+
+// Scenario: UV mapping the faces of a cube
+// Given the points on each face of the cube
+// When (u, v) ← cube_uv_<face>(p)
+// Then u and v are as in the book's examples
+func Test_UV_Mapping_the_Faces_of_a_Cube(t *testing.T) {
+	cases := []struct {
+		face CubeFace
+		uvCase
+	}{
+		{Front, uvCase{tuples.NewPoint(-0.5, 0.5, 1), 0.25, 0.75}},
+		{Front, uvCase{tuples.NewPoint(0.5, -0.5, 1), 0.75, 0.25}},
+		{Back, uvCase{tuples.NewPoint(0.5, 0.5, -1), 0.25, 0.75}},
+		{Back, uvCase{tuples.NewPoint(-0.5, -0.5, -1), 0.75, 0.25}},
+		{Left, uvCase{tuples.NewPoint(-1, 0.5, -0.5), 0.25, 0.75}},
+		{Left, uvCase{tuples.NewPoint(-1, -0.5, 0.5), 0.75, 0.25}},
+		{Right, uvCase{tuples.NewPoint(1, 0.5, 0.5), 0.25, 0.75}},
+		{Right, uvCase{tuples.NewPoint(1, -0.5, -0.5), 0.75, 0.25}},
+		{Up, uvCase{tuples.NewPoint(-0.5, 1, -0.5), 0.25, 0.75}},
+		{Up, uvCase{tuples.NewPoint(0.5, 1, 0.5), 0.75, 0.25}},
+		{Down, uvCase{tuples.NewPoint(-0.5, -1, 0.5), 0.25, 0.75}},
+		{Down, uvCase{tuples.NewPoint(0.5, -1, -0.5), 0.75, 0.25}},
+	}
+	for _, c := range cases {
+		face := c.face
+		checkMapping(t, "cube_uv", func(p tuples.Point) (float64, float64) { return CubeUV(face, p) }, []uvCase{c.uvCase})
+	}
+}
@@ -0,0 +1,144 @@
+package textures
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// derivativeStep is the distance in the space of the shape over which derivatives are estimated
+const derivativeStep = 1e-4
+
+// NormalModifier changes the normal used for shading a surface, to add detail without extra geometry
+type NormalModifier interface {
+	// Perturb returns the modified normal at a point, both in the space of the shape
+	Perturb(p tuples.Point, normal tuples.Normal) tuples.Normal
+}
+
+// HeightField is a scalar height above a surface, at a point in the space of the shape
+type HeightField interface {
+	HeightAt(p tuples.Point) float64
+}
+
+// PatternHeight uses the luminance of a Pattern as height
+type PatternHeight struct {
+	Pattern Pattern
+}
+
+// NoiseHeight uses Perlin noise as height, with features of about 1 / Frequency across
+type NoiseHeight struct {
+	Frequency float64
+}
+
+// BumpMap tilts the normal towards where the height decreases, Strength times the slope of the height
+type BumpMap struct {
+	Height   HeightField
+	Strength float64
+}
+
+// NormalMap replaces the normal by one read from an image, mapped onto the surface like a texture
+// The red, green and blue components hold the normal along the direction in which u increases,
+// the direction in which v increases and the surface normal, mapped from [-1, 1] to [0, 1].
+// Strength scales the tilt of the normals; 1 applies the normals as they are stored
+type NormalMap struct {
+	Image    UVPattern
+	Mapping  Mapping
+	Strength float64
+}
+
+// NewPatternHeight creates a new PatternHeight
+func NewPatternHeight(pattern Pattern) PatternHeight {
+	return PatternHeight{pattern}
+}
+
+// NewNoiseHeight creates a new NoiseHeight
+func NewNoiseHeight(frequency float64) NoiseHeight {
+	return NoiseHeight{frequency}
+}
+
+// NewBumpMap creates a new BumpMap
+func NewBumpMap(height HeightField, strength float64) BumpMap {
+	return BumpMap{height, strength}
+}
+
+// NewNormalMap creates a new NormalMap applying its normals as they are stored
+// Load the image with canvas.ReadPNGData or canvas.ReadPPM, which keep the stored values
+func NewNormalMap(image UVPattern, mapping Mapping) NormalMap {
+	return NormalMap{image, mapping, 1}
+}
+
+// HeightAt returns the luminance of the pattern at the point
+func (h PatternHeight) HeightAt(p tuples.Point) float64 {
+	return h.Pattern.ColorAt(p).Luminance()
+}
+
+// HeightAt returns the noise at the point
+func (h NoiseHeight) HeightAt(p tuples.Point) float64 {
+	return Noise(tuples.NewPoint(p.X*h.Frequency, p.Y*h.Frequency, p.Z*h.Frequency))
+}
+
+// Perturb tilts the normal by the slope of the height along the surface
+func (b BumpMap) Perturb(p tuples.Point, normal tuples.Normal) tuples.Normal {
+	n := normal.Normalize()
+	height := func(dx, dy, dz float64) float64 {
+		return b.Height.HeightAt(tuples.NewPoint(p.X+dx, p.Y+dy, p.Z+dz))
+	}
+	gradient := tuples.NewVector(
+		height(derivativeStep, 0, 0)-height(-derivativeStep, 0, 0),
+		height(0, derivativeStep, 0)-height(0, -derivativeStep, 0),
+		height(0, 0, derivativeStep)-height(0, 0, -derivativeStep),
+	).DivideBy(2 * derivativeStep)
+	// only the slope along the surface tilts the normal
+	surfaceGradient := gradient.Subtract(n.Vector().Multiply(n.Dot(gradient)))
+	return tuples.Normal(n.Vector().Subtract(surfaceGradient.Multiply(b.Strength))).Normalize()
+}
+
+// Perturb reads the normal from the image, in the frame of the directions in which u and v increase
+func (m NormalMap) Perturb(p tuples.Point, normal tuples.Normal) tuples.Normal {
+	n := normal.Normalize()
+	tangent, bitangent, ok := m.tangentFrame(p, n)
+	if !ok {
+		return n
+	}
+	u, v := m.Mapping.Map(p)
+	stored := m.Image.ColorAtUV(u, v)
+	x := (2*stored.Red - 1) * m.Strength
+	y := (2*stored.Green - 1) * m.Strength
+	z := 2*stored.Blue - 1
+	perturbed := tangent.Multiply(x).Add(bitangent.Multiply(y)).Add(n.Vector().Multiply(z))
+	if perturbed.Magnitude() < tuples.Epsilon {
+		return n
+	}
+	return tuples.Normal(perturbed).Normalize()
+}
+
+// tangentFrame finds the unit directions along the surface in which u and v increase
+// The directions are estimated from the Mapping, so they work for any mapping; ok is false
+// where u does not change along the surface, such as at the poles of a sphere
+func (m NormalMap) tangentFrame(p tuples.Point, n tuples.Normal) (tuples.Vector, tuples.Vector, bool) {
+	a, b := n.Vector().OrthonormalBasis()
+	u0, v0 := m.Mapping.Map(p)
+	gradient := func(direction tuples.Vector) (float64, float64) {
+		u, v := m.Mapping.Map(p.Add(direction.Multiply(derivativeStep)))
+		return wrapDifference(u - u0), wrapDifference(v - v0)
+	}
+	duA, dvA := gradient(a)
+	duB, dvB := gradient(b)
+
+	tangent := a.Multiply(duA).Add(b.Multiply(duB))
+	if tangent.Magnitude() < derivativeStep*tuples.Epsilon {
+		return tuples.Vector{}, tuples.Vector{}, false
+	}
+	tangent = tangent.Normalize()
+	bitangent := a.Multiply(dvA).Add(b.Multiply(dvB))
+	bitangent = bitangent.Subtract(tangent.Multiply(bitangent.Dot(tangent)))
+	if bitangent.Magnitude() < derivativeStep*tuples.Epsilon {
+		return tuples.Vector{}, tuples.Vector{}, false
+	}
+	return tangent, bitangent.Normalize(), true
+}
+
+// wrapDifference undoes the jump of a texture coordinate where it wraps around from 1 to 0
+func wrapDifference(d float64) float64 {
+	return d - math.Round(d)
+}
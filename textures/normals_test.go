@@ -0,0 +1,131 @@
+package textures
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// heightFunc is a HeightField defined by a function
+type heightFunc func(p tuples.Point) float64
+
+func (f heightFunc) HeightAt(p tuples.Point) float64 {
+	return f(p)
+}
+
+// uniform is a UVPattern of a single color
+type uniform colors.Color
+
+func (c uniform) ColorAtUV(u, v float64) colors.Color {
+	return colors.Color(c)
+}
+
+// Scenario: Noise is smooth, bounded and zero on the integer lattice
+// Given the points point(x / 7, y / 5, z / 3) for x, y, z in 0..20
+// Then noise(p) is in [-1, 1]
+// And noise(p) = 0 where p has integer coordinates
+// And noise differs little between points 0.001 apart
+func Test_Noise_is_Smooth_Bounded_and_Zero_on_the_Integer_Lattice(t *testing.T) {
+	nonZero := false
+	for x := 0; x <= 20; x++ {
+		for y := 0; y <= 20; y++ {
+			for z := 0; z <= 20; z++ {
+				p := tuples.NewPoint(float64(x)/7, float64(y)/5, float64(z)/3)
+				n := Noise(p)
+				// Then
+				if n < -1 || n > 1 {
+					t.Errorf("Noise( %v ) = %g, expected a value in [-1, 1]", p, n)
+				}
+				// And
+				if x%7 == 0 && y%5 == 0 && z%3 == 0 && n != 0 {
+					t.Errorf("Noise( %v ) = %g, expected 0", p, n)
+				}
+				// And
+				if d := math.Abs(Noise(p.Add(tuples.NewVector(0.001, 0.001, 0.001))) - n); d > 0.01 {
+					t.Errorf("Noise changes by %g near %v, expected a smooth change", d, p)
+				}
+				nonZero = nonZero || math.Abs(n) > 0.1
+			}
+		}
+	}
+	if !nonZero {
+		t.Errorf("Noise is nearly 0 everywhere")
+	}
+}
+
+// Scenario: A flat bump map leaves the normal unchanged
+// Given bump ← bump_map(height: 0.3 everywhere, strength: 1)
+// When n ← perturb(bump, point(0, 1, 0), normal(0, 2, 0))
+// Then n = normal(0, 1, 0)
+func Test_a_Flat_Bump_Map_Leaves_the_Normal_Unchanged(t *testing.T) {
+	// Given
+	bump := NewBumpMap(heightFunc(func(p tuples.Point) float64 { return 0.3 }), 1)
+	// When
+	n := bump.Perturb(tuples.NewPoint(0, 1, 0), tuples.NewNormal(0, 2, 0))
+	// Then
+	if wanted := tuples.NewNormal(0, 1, 0); !n.Equals(wanted) {
+		t.Errorf("Perturb() = %v, expected %v", n, wanted)
+	}
+}
+
+// Scenario: A bump map tilts the normal away from rising height
+// Given bump ← bump_map(height: 0.5 * x + 7 * y, strength: 2)
+// When n ← perturb(bump, point(0, 1, 0), normal(0, 1, 0))
+// Then n = normalize(normal(-1, 1, 0))
+func Test_a_Bump_Map_Tilts_the_Normal_away_from_Rising_Height(t *testing.T) {
+	// Given
+	bump := NewBumpMap(heightFunc(func(p tuples.Point) float64 { return 0.5*p.X + 7*p.Y }), 2)
+	// When
+	n := bump.Perturb(tuples.NewPoint(0, 1, 0), tuples.NewNormal(0, 1, 0))
+	// Then
+	if wanted := tuples.NewNormal(-1, 1, 0).Normalize(); !n.Equals(wanted) {
+		t.Errorf("Perturb() = %v, expected %v", n, wanted)
+	}
+}
+
+// Scenario: A normal map pointing straight out leaves the normal unchanged
+// Given nm ← normal_map(uniform(color(0.5, 0.5, 1)), spherical_map)
+// When n ← perturb(nm, point(0.6, 0, -0.8), normal(0.6, 0, -0.8))
+// Then n = normal(0.6, 0, -0.8)
+func Test_a_Normal_Map_Pointing_Straight_out_Leaves_the_Normal_Unchanged(t *testing.T) {
+	// Given
+	nm := NewNormalMap(uniform(colors.NewColor(0.5, 0.5, 1)), NewSphericalMapping())
+	// When
+	n := nm.Perturb(tuples.NewPoint(0.6, 0, -0.8), tuples.NewNormal(0.6, 0, -0.8))
+	// Then
+	if wanted := tuples.NewNormal(0.6, 0, -0.8); !n.Equals(wanted) {
+		t.Errorf("Perturb() = %v, expected %v", n, wanted)
+	}
+}
+
+// Scenario Outline: A normal map is applied in the frame of the texture coordinates
+// Given nm ← normal_map(uniform(<stored>), spherical_map)
+// When n ← perturb(nm, point(0, 0, -1), normal(0, 0, -1))
+// Then n = <normal>
+// Examples:
+// | stored                  | normal                          |
+// | color(1, 0.5, 0.5)      | normal(1, 0, 0)                 |
+// | color(0.5, 1, 0.5)      | normal(0, 1, 0)                 |
+// | color(0.5, 0, 1)        | normalize(normal(0, -1, -1))    |
+func Test_a_Normal_Map_is_Applied_in_the_Frame_of_the_Texture_Coordinates(t *testing.T) {
+	cases := []struct {
+		stored colors.Color
+		wanted tuples.Normal
+	}{
+		{colors.NewColor(1, 0.5, 0.5), tuples.NewNormal(1, 0, 0)},
+		{colors.NewColor(0.5, 1, 0.5), tuples.NewNormal(0, 1, 0)},
+		{colors.NewColor(0.5, 0, 1), tuples.NewNormal(0, -1, -1).Normalize()},
+	}
+	for _, c := range cases {
+		// Given
+		nm := NewNormalMap(uniform(c.stored), NewSphericalMapping())
+		// When
+		n := nm.Perturb(tuples.NewPoint(0, 0, -1), tuples.NewNormal(0, 0, -1))
+		// Then
+		if !n.Equals(c.wanted) {
+			t.Errorf("Perturb() with %v = %v, expected %v", c.stored, n, c.wanted)
+		}
+	}
+}
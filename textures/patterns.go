@@ -0,0 +1,162 @@
+package textures
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Pattern determines the color of a surface at a point in the space of the shape
+type Pattern interface {
+	ColorAt(p tuples.Point) colors.Color
+}
+
+// UVPattern determines the color of a surface at the texture coordinates u and v
+type UVPattern interface {
+	ColorAtUV(u, v float64) colors.Color
+}
+
+// Interpolation selects how an ImageTexture samples its image between pixel centers
+type Interpolation int
+
+const (
+	// Nearest takes the color of the nearest pixel
+	Nearest Interpolation = iota
+	// Bilinear blends the colors of the four nearest pixels
+	Bilinear
+)
+
+// TextureMap applies a UVPattern to a surface through a Mapping
+type TextureMap struct {
+	Pattern UVPattern
+	Mapping Mapping
+}
+
+// CubeMap applies a UVPattern to each face of the cube from -1 to 1
+type CubeMap struct {
+	Faces [6]UVPattern
+}
+
+// UVCheckers divides the texture in Width by Height squares alternating between colors A and B
+type UVCheckers struct {
+	Width  int
+	Height int
+	A      colors.Color
+	B      colors.Color
+}
+
+// ImageTexture looks up the colors of the texture in an image, with u = 0, v = 0 at its bottom left corner
+type ImageTexture struct {
+	Image         *canvas.Canvas
+	Interpolation Interpolation
+}
+
+// NewTextureMap creates a new TextureMap
+func NewTextureMap(pattern UVPattern, mapping Mapping) TextureMap {
+	return TextureMap{pattern, mapping}
+}
+
+// NewCubeMap creates a new CubeMap with a pattern for each face
+func NewCubeMap(left, front, right, back, up, down UVPattern) CubeMap {
+	var m CubeMap
+	m.Faces[Left] = left
+	m.Faces[Front] = front
+	m.Faces[Right] = right
+	m.Faces[Back] = back
+	m.Faces[Up] = up
+	m.Faces[Down] = down
+	return m
+}
+
+// NewUVCheckers creates a new UVCheckers pattern
+func NewUVCheckers(width, height int, a, b colors.Color) UVCheckers {
+	return UVCheckers{width, height, a, b}
+}
+
+// NewImageTexture creates a new ImageTexture of a loaded image
+func NewImageTexture(image *canvas.Canvas, interpolation Interpolation) ImageTexture {
+	return ImageTexture{image, interpolation}
+}
+
+// String formats the Interpolation as a string
+func (i Interpolation) String() string {
+	switch i {
+	case Nearest:
+		return "Nearest"
+	case Bilinear:
+		return "Bilinear"
+	}
+	return fmt.Sprintf("Interpolation(%d)", int(i))
+}
+
+// ColorAt maps the point to texture coordinates, and looks up their color
+func (m TextureMap) ColorAt(p tuples.Point) colors.Color {
+	u, v := m.Mapping.Map(p)
+	return m.Pattern.ColorAtUV(u, v)
+}
+
+// ColorAt looks up the color of the point on the face of the cube it lies on
+func (m CubeMap) ColorAt(p tuples.Point) colors.Color {
+	face := FaceFromPoint(p)
+	u, v := CubeUV(face, p)
+	return m.Faces[face].ColorAtUV(u, v)
+}
+
+// ColorAtUV returns A or B, depending on the square the texture coordinates fall in
+func (c UVCheckers) ColorAtUV(u, v float64) colors.Color {
+	u2 := int(math.Floor(u * float64(c.Width)))
+	v2 := int(math.Floor(v * float64(c.Height)))
+	if (u2+v2)%2 == 0 {
+		return c.A
+	}
+	return c.B
+}
+
+// ColorAtUV samples the image at the texture coordinates, with every pixel covering an equal part of [0, 1]
+// u wraps around, so the left and right edges of the image meet as on a globe; v is clamped to [0, 1]
+func (t ImageTexture) ColorAtUV(u, v float64) colors.Color {
+	width, height := t.Image.Width, t.Image.Height
+	x := u * float64(width)
+	y := (1 - clamp01(v)) * float64(height)
+	if t.Interpolation == Nearest {
+		return t.Image.Get(wrapInt(int(math.Floor(x)), width), clampInt(int(math.Floor(y)), 0, height-1))
+	}
+	// blend between the centers of the pixels, which lie at half a pixel from their edges
+	x, y = x-0.5, y-0.5
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	fx, fy := x-float64(x0), y-float64(y0)
+	left, right := wrapInt(x0, width), wrapInt(x0+1, width)
+	row0, row1 := clampInt(y0, 0, height-1), clampInt(y0+1, 0, height-1)
+	top := lerp(t.Image.Get(left, row0), t.Image.Get(right, row0), fx)
+	bottom := lerp(t.Image.Get(left, row1), t.Image.Get(right, row1), fx)
+	return lerp(top, bottom, fy)
+}
+
+func lerp(a, b colors.Color, t float64) colors.Color {
+	return a.Multiply(1 - t).Add(b.Multiply(t))
+}
+
+func clamp01(value float64) float64 {
+	return math.Min(math.Max(value, 0), 1)
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func wrapInt(value, period int) int {
+	result := value % period
+	if result < 0 {
+		result += period
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+package textures
+
+import (
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/canvas"
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+var (
+	black = colors.Black()
+	white = colors.White()
+)
+
+// Scenario Outline: Checker pattern in 2D
+// Given checkers ← uv_checkers(2, 2, black, white)
+// When color ← uv_pattern_at(checkers, <u>, <v>)
+// Then color = <expected>
+// Examples:
+// | u   | v   | expected |
+// | 0.0 | 0.0 | black    |
+// | 0.5 | 0.0 | white    |
+// | 0.0 | 0.5 | white    |
+// | 0.5 | 0.5 | black    |
+// | 1.0 | 1.0 | black    |
+func Test_Checker_Pattern_in_2D(t *testing.T) {
+	// Given
+	checkers := NewUVCheckers(2, 2, black, white)
+	cases := []struct {
+		u, v   float64
+		wanted colors.Color
+	}{
+		{0.0, 0.0, black},
+		{0.5, 0.0, white},
+		{0.0, 0.5, white},
+		{0.5, 0.5, black},
+		{1.0, 1.0, black},
+	}
+	for _, c := range cases {
+		// Then
+		if color := checkers.ColorAtUV(c.u, c.v); !color.Equals(c.wanted) {
+			t.Errorf("ColorAtUV( %g, %g ) = %v, expected %v", c.u, c.v, color, c.wanted)
+		}
+	}
+}
+
+// Scenario Outline: Using a texture map pattern with a spherical map
+// Given checkers ← uv_checkers(16, 8, black, white)
+// And pattern ← texture_map(checkers, spherical_map)
+// Then pattern_at(pattern, <point>) = <color>
+// Examples:
+// | point                          | color |
+// | point(0.4315, 0.4670, 0.7719)  | white |
+// | point(-0.9654, 0.2552, -0.0534) | black |
+// | ...                            | ...   |
+func Test_Using_a_Texture_Map_Pattern_with_a_Spherical_Map(t *testing.T) {
+	// Given
+	checkers := NewUVCheckers(16, 8, black, white)
+	// And
+	pattern := NewTextureMap(checkers, NewSphericalMapping())
+	cases := []struct {
+		point  tuples.Point
+		wanted colors.Color
+	}{
+		{tuples.NewPoint(0.4315, 0.4670, 0.7719), white},
+		{tuples.NewPoint(-0.9654, 0.2552, -0.0534), black},
+		{tuples.NewPoint(0.1039, 0.7090, 0.6975), white},
+		{tuples.NewPoint(-0.4986, -0.7856, -0.3663), black},
+		{tuples.NewPoint(-0.0317, -0.9395, 0.3411), black},
+		{tuples.NewPoint(0.4809, -0.7721, 0.4154), black},
+		{tuples.NewPoint(0.0285, -0.9612, -0.2745), black},
+		{tuples.NewPoint(-0.5734, -0.2162, -0.7903), white},
+		{tuples.NewPoint(0.7688, -0.1470, 0.6223), black},
+		{tuples.NewPoint(-0.7652, 0.2175, 0.6060), black},
+	}
+	for _, c := range cases {
+		// Then
+		if color := pattern.ColorAt(c.point); !color.Equals(c.wanted) {
+			t.Errorf("ColorAt( %v ) = %v, expected %v", c.point, color, c.wanted)
+		}
+	}
+}
+
+// Scenario: Finding the colors on a mapped cube
+// Given a uniformly colored pattern for each face: yellow, cyan, red, green, orange and purple
+// And pattern ← cube_map(left, front, right, back, up, down)
+// Then the center of each face has the color of its pattern
+func Test_Finding_the_Colors_on_a_Mapped_Cube(t *testing.T) {
+	// Given
+	faceColors := []colors.Color{
+		colors.NewColor(1, 1, 0),
+		colors.NewColor(0, 1, 1),
+		colors.NewColor(1, 0, 0),
+		colors.NewColor(0, 1, 0),
+		colors.NewColor(1, 0.5, 0),
+		colors.NewColor(1, 0, 1),
+	}
+	var faces [6]UVPattern
+	for i, c := range faceColors {
+		faces[i] = NewUVCheckers(1, 1, c, c)
+	}
+	// And
+	pattern := NewCubeMap(faces[Left], faces[Front], faces[Right], faces[Back], faces[Up], faces[Down])
+	cases := []struct {
+		point tuples.Point
+		face  CubeFace
+	}{
+		{tuples.NewPoint(-1, 0, 0), Left},
+		{tuples.NewPoint(0, 0, 1), Front},
+		{tuples.NewPoint(1, 0, 0), Right},
+		{tuples.NewPoint(0, 0, -1), Back},
+		{tuples.NewPoint(0, 1, 0), Up},
+		{tuples.NewPoint(0, -1, 0), Down},
+	}
+	for _, c := range cases {
+		// Then
+		if color := pattern.ColorAt(c.point); !color.Equals(faceColors[c.face]) {
+			t.Errorf("ColorAt( %v ) = %v, expected %v", c.point, color, faceColors[c.face])
+		}
+	}
+}
+
+// Scenario Outline: A UV image pattern with nearest interpolation
+// Given c ← a 10x10 canvas where pixel (x, y) has gray value (x + y) / 100
+// And pattern ← uv_image(c, nearest)
+// When color ← uv_pattern_at(pattern, <u>, <v>)
+// Then color = color(<gray>, <gray>, <gray>)
+// Examples:
+// | u    | v    | gray |
+// | 0    | 0    | 0.09 |
+// | 0.35 | 0    | 0.12 |
+// | 0.65 | 0.35 | 0.12 |
+// | 0.95 | 1    | 0.09 |
+// | 1    | 1    | 0.00 |
+func Test_a_UV_Image_Pattern_with_Nearest_Interpolation(t *testing.T) {
+	// Given
+	c := grayCanvas(10, 10)
+	// And
+	pattern := NewImageTexture(c, Nearest)
+	cases := []struct {
+		u, v float64
+		gray float64
+	}{
+		{0, 0, 0.09},
+		{0.35, 0, 0.12},
+		{0.65, 0.35, 0.12},
+		{0.95, 1, 0.09},
+		{1, 1, 0},
+	}
+	for _, tc := range cases {
+		// Then
+		wanted := colors.NewColor(tc.gray, tc.gray, tc.gray)
+		if color := pattern.ColorAtUV(tc.u, tc.v); !color.Equals(wanted) {
+			t.Errorf("ColorAtUV( %g, %g ) = %v, expected %v", tc.u, tc.v, color, wanted)
+		}
+	}
+}
+
+// Scenario: A UV image pattern with bilinear interpolation blends between pixels
+// Given c ← canvas(2, 2) with pixels black, white on the top row and white, black on the bottom row
+// And pattern ← uv_image(c, bilinear)
+// Then uv_pattern_at(pattern, 0.5, 0.5) = color(0.5, 0.5, 0.5)
+// And uv_pattern_at(pattern, 0.75, 0.75) = white, the center of the top right pixel
+// And uv_pattern_at(pattern, 0.75, 1) = white
+// And uv_pattern_at(pattern, 0, 0.75) = color(0.5, 0.5, 0.5), blending across the left and right edges
+// And uv_pattern_at(pattern, 1, 0.75) = color(0.5, 0.5, 0.5)
+func Test_a_UV_Image_Pattern_with_Bilinear_Interpolation_Blends_between_Pixels(t *testing.T) {
+	// Given
+	c := canvas.NewCanvas(2, 2)
+	c.Set(1, 0, white)
+	c.Set(0, 1, white)
+	// And
+	pattern := NewImageTexture(c, Bilinear)
+	cases := []struct {
+		u, v   float64
+		wanted colors.Color
+	}{
+		{0.5, 0.5, colors.NewColor(0.5, 0.5, 0.5)},
+		{0.75, 0.75, white},
+		{0.75, 1, white},
+		{0, 0.75, colors.NewColor(0.5, 0.5, 0.5)},
+		{1, 0.75, colors.NewColor(0.5, 0.5, 0.5)},
+	}
+	for _, tc := range cases {
+		// Then
+		if color := pattern.ColorAtUV(tc.u, tc.v); !color.Equals(tc.wanted) {
+			t.Errorf("ColorAtUV( %g, %g ) = %v, expected %v", tc.u, tc.v, color, tc.wanted)
+		}
+	}
+}
+
+func grayCanvas(width, height int) *canvas.Canvas {
+	c := canvas.NewCanvas(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gray := float64(x+y) / 100
+			c.Set(x, y, colors.NewColor(gray, gray, gray))
+		}
+	}
+	return c
+}
@@ -0,0 +1,64 @@
+package transformations
+
+import (
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Builder composes a transformation from steps that are listed in the order in which they are applied,
+// so that NewBuilder().RotateX(r).Scale(x, y, z).Translate(x, y, z) equals
+// Translation(x, y, z) * Scaling(x, y, z) * RotationX(r)
+// Every step returns a new Builder, leaving the original unchanged
+type Builder struct {
+	transform matrix.Matrix
+}
+
+// NewBuilder creates a new Builder, starting with the identity transformation
+func NewBuilder() Builder {
+	return Builder{*matrix.Identity(4)}
+}
+
+// Then applies another transformation after the transformation built so far
+func (b Builder) Then(m matrix.Matrix) Builder {
+	return Builder{*m.Multiply(b.transform)}
+}
+
+// Translate applies a translation
+func (b Builder) Translate(x, y, z float64) Builder {
+	return b.Then(*Translation(x, y, z))
+}
+
+// Scale applies a scaling
+func (b Builder) Scale(x, y, z float64) Builder {
+	return b.Then(*Scaling(x, y, z))
+}
+
+// RotateX applies a rotation around the x-axis, r is in radians
+func (b Builder) RotateX(r float64) Builder {
+	return b.Then(*RotationX(r))
+}
+
+// RotateY applies a rotation around the y-axis, r is in radians
+func (b Builder) RotateY(r float64) Builder {
+	return b.Then(*RotationY(r))
+}
+
+// RotateZ applies a rotation around the z-axis, r is in radians
+func (b Builder) RotateZ(r float64) Builder {
+	return b.Then(*RotationZ(r))
+}
+
+// Rotate applies a rotation around an arbitrary axis through the origin, r is in radians
+func (b Builder) Rotate(axis tuples.Vector, r float64) Builder {
+	return b.Then(*Rotation(axis, r))
+}
+
+// Shear applies a shearing
+func (b Builder) Shear(xy, xz, yx, yz, zx, zy float64) Builder {
+	return b.Then(*Shearing(xy, xz, yx, yz, zx, zy))
+}
+
+// Matrix returns a copy of the transformation that was built
+func (b Builder) Matrix() *matrix.Matrix {
+	return b.transform.Multiply(*matrix.Identity(4))
+}
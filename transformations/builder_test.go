@@ -0,0 +1,100 @@
+package transformations
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: Built transformations are applied in the order they are listed
+// Given p ← point(1, 0, 1)
+// When T ← builder() rotate_x(π / 2) scale(5, 5, 5) translate(10, 5, 7)
+// Then T = translation(10, 5, 7) * scaling(5, 5, 5) * rotation_x(π / 2)
+// And T * p = point(15, 0, 7)
+func Test_Built_Transforms_Are_Applied_In_Listed_Order(t *testing.T) {
+	// Given
+	p := tuples.NewPoint(1, 0, 1)
+	// When
+	transform := NewBuilder().
+		RotateX(math.Pi/2).
+		Scale(5, 5, 5).
+		Translate(10, 5, 7).
+		Matrix()
+	// Expected
+	wantedTransform := Translation(10, 5, 7).Multiply(*Scaling(5, 5, 5)).Multiply(*RotationX(math.Pi / 2))
+	wanted := tuples.NewPoint(15, 0, 7)
+	// Then
+	if !wantedTransform.Equals(*transform) {
+		t.Errorf("built transform = %v, expected %v", transform, wantedTransform)
+	}
+	// And
+	if p2 := transform.MultiplyPoint(p); !wanted.Equals(*p2) {
+		t.Errorf("%v * %v = %v, expected %v", transform, p, p2, wanted)
+	}
+}
+
+// Scenario: Adding a step leaves the original builder unchanged
+// Given B ← builder() translate(1, 2, 3)
+// When C ← B scale(2, 2, 2)
+// Then B = translation(1, 2, 3)
+// And C = scaling(2, 2, 2) * translation(1, 2, 3)
+func Test_Adding_a_Step_Leaves_the_Original_Builder_Unchanged(t *testing.T) {
+	// Given
+	b := NewBuilder().Translate(1, 2, 3)
+	// When
+	c := b.Scale(2, 2, 2)
+	// Expected
+	wantedB := Translation(1, 2, 3)
+	wantedC := Scaling(2, 2, 2).Multiply(*Translation(1, 2, 3))
+	// Then
+	if !wantedB.Equals(*b.Matrix()) {
+		t.Errorf("original builder = %v, expected %v", b.Matrix(), wantedB)
+	}
+	// And
+	if !wantedC.Equals(*c.Matrix()) {
+		t.Errorf("extended builder = %v, expected %v", c.Matrix(), wantedC)
+	}
+}
+
+// Scenario: Rotating around a principal axis equals the matching rotation
+// Given r ← π / 5
+// Then rotation(vector(1, 0, 0), r) = rotation_x(r)
+// And rotation(vector(0, 2, 0), r) = rotation_y(r)
+// And rotation(vector(0, 0, 1), r) = rotation_z(r)
+func Test_Rotating_Around_a_Principal_Axis(t *testing.T) {
+	// Given
+	r := math.Pi / 5
+	// Then
+	if m := Rotation(tuples.NewVector(1, 0, 0), r); !RotationX(r).Equals(*m) {
+		t.Errorf("Rotation( x, %9.6f ) = %v, expected %v", r, m, RotationX(r))
+	}
+	// And
+	if m := Rotation(tuples.NewVector(0, 2, 0), r); !RotationY(r).Equals(*m) {
+		t.Errorf("Rotation( y, %9.6f ) = %v, expected %v", r, m, RotationY(r))
+	}
+	// And
+	if m := Rotation(tuples.NewVector(0, 0, 1), r); !RotationZ(r).Equals(*m) {
+		t.Errorf("Rotation( z, %9.6f ) = %v, expected %v", r, m, RotationZ(r))
+	}
+}
+
+// Scenario: Rotating around the diagonal cycles the axes
+// Given T ← builder() rotate(vector(1, 1, 1), 2π / 3)
+// Then T * point(1, 0, 0) = point(0, 1, 0)
+// And T * point(0, 1, 0) = point(0, 0, 1)
+func Test_Rotating_Around_the_Diagonal_Cycles_the_Axes(t *testing.T) {
+	// Given
+	transform := NewBuilder().Rotate(tuples.NewVector(1, 1, 1), 2*math.Pi/3).Matrix()
+	// Expected
+	wantedX := tuples.NewPoint(0, 1, 0)
+	wantedY := tuples.NewPoint(0, 0, 1)
+	// Then
+	if p := transform.MultiplyPoint(tuples.NewPoint(1, 0, 0)); !wantedX.Equals(*p) {
+		t.Errorf("%v * point(1, 0, 0) = %v, expected %v", transform, p, wantedX)
+	}
+	// And
+	if p := transform.MultiplyPoint(tuples.NewPoint(0, 1, 0)); !wantedY.Equals(*p) {
+		t.Errorf("%v * point(0, 1, 0) = %v, expected %v", transform, p, wantedY)
+	}
+}
@@ -0,0 +1,130 @@
+package transformations
+
+import (
+	"fmt"
+
+	"github.com/bas-velthuizen/go-raytracer/matrix"
+	"github.com/bas-velthuizen/go-raytracer/quaternions"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Decomposition describes an affine transformation as a translation, a rotation, a shearing and a scaling,
+// so that the transformation equals Translation * Rotation * Shearing * Scaling
+// The shearing only moves x in proportion to y and z, and y in proportion to z
+type Decomposition struct {
+	Translation tuples.Vector
+	Rotation    matrix.Matrix
+	ShearXY     float64
+	ShearXZ     float64
+	ShearYZ     float64
+	Scale       tuples.Vector
+}
+
+// Decompose splits an affine transformation matrix into its translation, rotation, shear and scale components
+// The columns of the upper left 3x3 matrix are made orthonormal with Gram-Schmidt: their lengths
+// after removing the parts along the previous columns are the scale, and the removed parts the shear
+func Decompose(m matrix.Matrix) Decomposition {
+	translation := tuples.NewVector(m.Get(0, 3), m.Get(1, 3), m.Get(2, 3))
+
+	columns := [3]tuples.Vector{}
+	for col := 0; col < 3; col++ {
+		columns[col] = tuples.NewVector(m.Get(0, col), m.Get(1, col), m.Get(2, col))
+	}
+
+	// a negative determinant means the transformation mirrors, which cannot be expressed as a rotation,
+	// so mirror the x axis before decomposing and move the mirroring into the x scale
+	mirror := 1.0
+	if columns[0].Cross(columns[1]).Dot(columns[2]) < 0 {
+		mirror = -1
+		columns[0] = columns[0].Negate()
+	}
+
+	scale := [3]float64{}
+	scale[0], columns[0] = normalizeColumn(columns[0])
+
+	xy := columns[0].Dot(columns[1])
+	columns[1] = columns[1].Subtract(columns[0].Multiply(xy))
+	scale[1], columns[1] = normalizeColumn(columns[1])
+
+	xz := columns[0].Dot(columns[2])
+	yz := columns[1].Dot(columns[2])
+	columns[2] = columns[2].Subtract(columns[0].Multiply(xz)).Subtract(columns[1].Multiply(yz))
+	scale[2], columns[2] = normalizeColumn(columns[2])
+
+	rotation := matrix.Identity(4)
+	for col := 0; col < 3; col++ {
+		rotation.Set(0, col, columns[col].X)
+		rotation.Set(1, col, columns[col].Y)
+		rotation.Set(2, col, columns[col].Z)
+	}
+
+	return Decomposition{
+		Translation: translation,
+		Rotation:    *rotation,
+		ShearXY:     divideOrZero(xy, scale[1]),
+		ShearXZ:     divideOrZero(xz, scale[2]),
+		ShearYZ:     divideOrZero(yz, scale[2]),
+		Scale:       tuples.NewVector(mirror*scale[0], scale[1], scale[2]),
+	}
+}
+
+// normalizeColumn returns the length of a column and the column scaled to unit length
+func normalizeColumn(column tuples.Vector) (float64, tuples.Vector) {
+	length := column.Magnitude()
+	if length == 0 {
+		return 0, column
+	}
+	return length, column.DivideBy(length)
+}
+
+func divideOrZero(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
+
+// String formats the Decomposition as a string
+func (d Decomposition) String() string {
+	return fmt.Sprintf("Decomposition( %v, %v, %9.6f, %9.6f, %9.6f, %v )", d.Translation, d.Rotation, d.ShearXY, d.ShearXZ, d.ShearYZ, d.Scale)
+}
+
+// Matrix recomposes the transformation matrix
+func (d Decomposition) Matrix() *matrix.Matrix {
+	translation := Translation(d.Translation.X, d.Translation.Y, d.Translation.Z)
+	shearing := Shearing(d.ShearXY, d.ShearXZ, 0, d.ShearYZ, 0, 0)
+	scaling := Scaling(d.Scale.X, d.Scale.Y, d.Scale.Z)
+	return translation.Multiply(d.Rotation).Multiply(*shearing).Multiply(*scaling)
+}
+
+// Interpolate blends two decompositions, linearly for translation, shear and scale and spherically for rotation
+// t = 0 results in d, t = 1 results in other
+func (d Decomposition) Interpolate(other Decomposition, t float64) Decomposition {
+	from := quaternions.FromMatrix(d.Rotation)
+	to := quaternions.FromMatrix(other.Rotation)
+	return Decomposition{
+		Translation: lerp(d.Translation, other.Translation, t),
+		Rotation:    *from.Slerp(to, t).ToMatrix(),
+		ShearXY:     d.ShearXY + (other.ShearXY-d.ShearXY)*t,
+		ShearXZ:     d.ShearXZ + (other.ShearXZ-d.ShearXZ)*t,
+		ShearYZ:     d.ShearYZ + (other.ShearYZ-d.ShearYZ)*t,
+		Scale:       lerp(d.Scale, other.Scale, t),
+	}
+}
+
+// Interpolate blends two affine transformation matrices by interpolating their decompositions
+func Interpolate(from, to matrix.Matrix, t float64) *matrix.Matrix {
+	return Decompose(from).Interpolate(Decompose(to), t).Matrix()
+}
+
+// InterpolationIsInvertible checks if every transformation interpolated between two invertible transformations
+// can be inverted, which fails when a scale passes through zero, as from a mirroring to a transformation that does not mirror
+func InterpolationIsInvertible(from, to matrix.Matrix) bool {
+	a := Decompose(from).Scale
+	b := Decompose(to).Scale
+	return a.X*b.X > 0 && a.Y*b.Y > 0 && a.Z*b.Z > 0
+}
+
+func lerp(from, to tuples.Vector, t float64) tuples.Vector {
+	return from.Add(to.Subtract(from).Multiply(t))
+}
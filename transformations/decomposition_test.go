@@ -0,0 +1,195 @@
+package transformations
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: Decomposing a chained transformation
+// Given transform ← translation(10, 5, 7) * rotation_y(π / 3) * scaling(2, 3, 4)
+// When d ← decompose(transform)
+// Then d.translation = vector(10, 5, 7)
+// And d.rotation = rotation_y(π / 3)
+// And d.scale = vector(2, 3, 4)
+func Test_Decomposing_a_Chained_Transformation(t *testing.T) {
+	// Given
+	rotation := RotationY(math.Pi / 3)
+	transform := Translation(10, 5, 7).Multiply(*rotation).Multiply(*Scaling(2, 3, 4))
+	// When
+	d := Decompose(*transform)
+	// Expected
+	wantedTranslation := tuples.NewVector(10, 5, 7)
+	wantedScale := tuples.NewVector(2, 3, 4)
+	// Then
+	if !wantedTranslation.Equals(d.Translation) {
+		t.Errorf("Decompose(%v).Translation = %v, expected %v", transform, d.Translation, wantedTranslation)
+	}
+	// And
+	if !rotation.Equals(d.Rotation) {
+		t.Errorf("Decompose(%v).Rotation = %v, expected %v", transform, d.Rotation, rotation)
+	}
+	// And
+	if !wantedScale.Equals(d.Scale) {
+		t.Errorf("Decompose(%v).Scale = %v, expected %v", transform, d.Scale, wantedScale)
+	}
+}
+
+// Scenario: Recomposing a decomposed transformation
+// Given transform ← translation(1, -2, 3) * rotation_x(π / 5) * rotation_z(π / 7) * scaling(1, 0.5, 2)
+// When d ← decompose(transform)
+// Then matrix(d) = transform
+func Test_Recomposing_a_Decomposed_Transformation(t *testing.T) {
+	// Given
+	transform := Translation(1, -2, 3).
+		Multiply(*RotationX(math.Pi / 5)).
+		Multiply(*RotationZ(math.Pi / 7)).
+		Multiply(*Scaling(1, 0.5, 2))
+	// When
+	d := Decompose(*transform)
+	// Then
+	if !transform.Equals(*d.Matrix()) {
+		t.Errorf("Decompose(%v).Matrix() = %v, expected %v", transform, d.Matrix(), transform)
+	}
+}
+
+// Scenario: Decomposing a mirroring transformation
+// Given transform ← scaling(-1, 1, 1)
+// When d ← decompose(transform)
+// Then d.scale = vector(-1, 1, 1)
+// And matrix(d) = transform
+func Test_Decomposing_a_Mirroring_Transformation(t *testing.T) {
+	// Given
+	transform := Scaling(-1, 1, 1)
+	// When
+	d := Decompose(*transform)
+	// Expected
+	wantedScale := tuples.NewVector(-1, 1, 1)
+	// Then
+	if !wantedScale.Equals(d.Scale) {
+		t.Errorf("Decompose(%v).Scale = %v, expected %v", transform, d.Scale, wantedScale)
+	}
+	// And
+	if !transform.Equals(*d.Matrix()) {
+		t.Errorf("Decompose(%v).Matrix() = %v, expected %v", transform, d.Matrix(), transform)
+	}
+}
+
+// Scenario: Decomposing a sheared transformation
+// Given transform ← translation(1, 2, 3) * rotation_z(π / 4) * shearing(0.5, 0.25, 0, 0.75, 0, 0) * scaling(2, 3, 4)
+// When d ← decompose(transform)
+// Then d.rotation = rotation_z(π / 4)
+// And d.shear_xy = 0.5, d.shear_xz = 0.25 and d.shear_yz = 0.75
+// And d.scale = vector(2, 3, 4)
+// And matrix(d) = transform
+func Test_Decomposing_a_Sheared_Transformation(t *testing.T) {
+	// Given
+	rotation := RotationZ(math.Pi / 4)
+	transform := NewBuilder().
+		Scale(2, 3, 4).
+		Shear(0.5, 0.25, 0, 0.75, 0, 0).
+		Then(*rotation).
+		Translate(1, 2, 3).
+		Matrix()
+	// When
+	d := Decompose(*transform)
+	// Expected
+	wantedScale := tuples.NewVector(2, 3, 4)
+	// Then
+	if !rotation.Equals(d.Rotation) {
+		t.Errorf("Decompose(%v).Rotation = %v, expected %v", transform, d.Rotation, rotation)
+	}
+	// And
+	if math.Abs(d.ShearXY-0.5) > tuples.Epsilon || math.Abs(d.ShearXZ-0.25) > tuples.Epsilon || math.Abs(d.ShearYZ-0.75) > tuples.Epsilon {
+		t.Errorf("Decompose(%v) shear = (%9.6f, %9.6f, %9.6f), expected (0.5, 0.25, 0.75)", transform, d.ShearXY, d.ShearXZ, d.ShearYZ)
+	}
+	// And
+	if !wantedScale.Equals(d.Scale) {
+		t.Errorf("Decompose(%v).Scale = %v, expected %v", transform, d.Scale, wantedScale)
+	}
+	// And
+	if !transform.Equals(*d.Matrix()) {
+		t.Errorf("Decompose(%v).Matrix() = %v, expected %v", transform, d.Matrix(), transform)
+	}
+}
+
+// Scenario: Recomposing any shearing
+// Given transform ← shearing(1, 0, 0.5, 0, 0.2, 0.3) * scaling(-1, 2, 1)
+// When d ← decompose(transform)
+// Then matrix(d) = transform
+func Test_Recomposing_any_Shearing(t *testing.T) {
+	// Given
+	transform := Shearing(1, 0, 0.5, 0, 0.2, 0.3).Multiply(*Scaling(-1, 2, 1))
+	// When
+	d := Decompose(*transform)
+	// Then
+	if !transform.Equals(*d.Matrix()) {
+		t.Errorf("Decompose(%v).Matrix() = %v, expected %v", transform, d.Matrix(), transform)
+	}
+}
+
+// Scenario: Interpolating halfway between two transformations
+// Given from ← translation(0, 0, 0)
+// And to ← translation(4, 2, 0) * rotation_y(π / 2) * scaling(3, 3, 3)
+// When m ← interpolate(from, to, 0.5)
+// Then m = translation(2, 1, 0) * rotation_y(π / 4) * scaling(2, 2, 2)
+func Test_Interpolating_Halfway_Between_Two_Transformations(t *testing.T) {
+	// Given
+	from := Translation(0, 0, 0)
+	// And
+	to := Translation(4, 2, 0).Multiply(*RotationY(math.Pi / 2)).Multiply(*Scaling(3, 3, 3))
+	// When
+	m := Interpolate(*from, *to, 0.5)
+	// Expected
+	wanted := Translation(2, 1, 0).Multiply(*RotationY(math.Pi / 4)).Multiply(*Scaling(2, 2, 2))
+	// Then
+	if !wanted.Equals(*m) {
+		t.Errorf("Interpolate(%v, %v, 0.5) = %v, expected %v", from, to, m, wanted)
+	}
+}
+
+// Scenario: Interpolating at the ends returns the original transformations
+// Given from ← rotation_x(π / 3) * scaling(1, 2, 1)
+// And to ← translation(0, 5, 0) * rotation_z(-π / 4)
+// Then interpolate(from, to, 0) = from
+// And interpolate(from, to, 1) = to
+func Test_Interpolating_at_the_Ends_Returns_the_Original_Transformations(t *testing.T) {
+	// Given
+	from := RotationX(math.Pi / 3).Multiply(*Scaling(1, 2, 1))
+	// And
+	to := Translation(0, 5, 0).Multiply(*RotationZ(-math.Pi / 4))
+	// Then
+	if m := Interpolate(*from, *to, 0); !from.Equals(*m) {
+		t.Errorf("Interpolate(%v, %v, 0) = %v, expected %v", from, to, m, from)
+	}
+	// And
+	if m := Interpolate(*from, *to, 1); !to.Equals(*m) {
+		t.Errorf("Interpolate(%v, %v, 1) = %v, expected %v", from, to, m, to)
+	}
+}
+
+// Scenario: Interpolating between a mirroring and a transformation that does not mirror is not invertible
+// Given from ← translation(1, 0, 0) * scaling(1, 1, 1)
+// And to ← scaling(1, -1, 1)
+// Then interpolation_is_invertible(from, from * rotation_y(π / 2)) is true
+// And interpolation_is_invertible(from, to) is false
+// And interpolate(from, to, 0.5) is not invertible
+func Test_Interpolating_Between_a_Mirroring_and_a_Transformation_that_does_not_Mirror_is_not_Invertible(t *testing.T) {
+	// Given
+	from := Translation(1, 0, 0).Multiply(*Scaling(1, 1, 1))
+	// And
+	to := Scaling(1, -1, 1)
+	// Then
+	if rotated := from.Multiply(*RotationY(math.Pi / 2)); !InterpolationIsInvertible(*from, *rotated) {
+		t.Errorf("InterpolationIsInvertible(%v, %v) is false, expected true", from, rotated)
+	}
+	// And
+	if InterpolationIsInvertible(*from, *to) {
+		t.Errorf("InterpolationIsInvertible(%v, %v) is true, expected false", from, to)
+	}
+	// And
+	if m := Interpolate(*from, *to, 0.5); m.IsInvertible() {
+		t.Errorf("Interpolate(%v, %v, 0.5) = %v is invertible, expected singular", from, to, m)
+	}
+}
@@ -58,6 +58,21 @@ func RotationZ(r float64) *matrix.Matrix {
 	return t
 }
 
+// Rotation creates a new transformation matrix for rotation around an arbitrary axis through the origin
+// r is in radians, and rotates the same way as RotationX, RotationY and RotationZ do around their axes
+func Rotation(axis tuples.Vector, r float64) *matrix.Matrix {
+	a := axis.Normalize()
+	c := math.Cos(r)
+	s := math.Sin(r)
+	t := 1 - c
+	return matrix.NewMatrix([][]float64{
+		{t*a.X*a.X + c, t*a.X*a.Y - s*a.Z, t*a.X*a.Z + s*a.Y, 0},
+		{t*a.X*a.Y + s*a.Z, t*a.Y*a.Y + c, t*a.Y*a.Z - s*a.X, 0},
+		{t*a.X*a.Z - s*a.Y, t*a.Y*a.Z + s*a.X, t*a.Z*a.Z + c, 0},
+		{0, 0, 0, 1},
+	})
+}
+
 // Shearing creates a new Transformation matrix form shearing
 func Shearing(xy, xz, yx, yz, zx, zy float64) *matrix.Matrix {
 	t := matrix.Identity(4)
@@ -71,6 +86,15 @@ func Shearing(xy, xz, yx, yz, zx, zy float64) *matrix.Matrix {
 }
 
 // NewViewTransform creates a view matrix defined by the fromPoint, toPoint and upVector
-func NewViewTransform(fromPoint, toPoint, upVector tuples.Tuple) matrix.Matrix {
-	return *matrix.Identity(4)
+func NewViewTransform(fromPoint, toPoint tuples.Point, upVector tuples.Vector) matrix.Matrix {
+	forward := toPoint.Subtract(fromPoint).Normalize()
+	left := forward.Cross(upVector.Normalize())
+	trueUp := left.Cross(forward)
+	orientation := matrix.NewMatrix([][]float64{
+		{left.X, left.Y, left.Z, 0},
+		{trueUp.X, trueUp.Y, trueUp.Z, 0},
+		{-forward.X, -forward.Y, -forward.Z, 0},
+		{0, 0, 0, 1},
+	})
+	return *orientation.Multiply(*Translation(-fromPoint.X, -fromPoint.Y, -fromPoint.Z))
 }
@@ -19,11 +19,11 @@ func Test_Multiplying_By_a_Translation_Matrix(t *testing.T) {
 	// Given
 	transform := Translation(5, -3, 2)
 	// And
-	p := tuples.Point(-3, 4, 5)
+	p := tuples.NewPoint(-3, 4, 5)
 	// Expected
-	wanted := tuples.Point(2, 1, 7)
+	wanted := tuples.NewPoint(2, 1, 7)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -41,11 +41,11 @@ func Test_Multiplying_By_the_Inverse_Of_a_Translation_Matrix(t *testing.T) {
 	// And
 	inv := transform.Inverse()
 	// And
-	p := tuples.Point(-3, 4, 5)
+	p := tuples.NewPoint(-3, 4, 5)
 	// Expected
-	wanted := tuples.Point(-8, 7, 3)
+	wanted := tuples.NewPoint(-8, 7, 3)
 	// When
-	r := inv.MultiplyTuple(p)
+	r := inv.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", inv, p, r, wanted)
@@ -60,9 +60,9 @@ func Test_Translation_Does_Not_Affect_Vectors(t *testing.T) {
 	// Given
 	transform := Translation(5, -3, 2)
 	// And
-	v := tuples.Vector(-3, 4, 5)
+	v := tuples.NewVector(-3, 4, 5)
 	// When
-	r := transform.MultiplyTuple(v)
+	r := transform.MultiplyVector(v)
 	// Then
 	if !v.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, v, r, v)
@@ -81,11 +81,11 @@ func Test_Multiplying_By_a_Scaling_Matrix(t *testing.T) {
 	// Given
 	transform := Scaling(2, 3, 4)
 	// And
-	p := tuples.Point(-4, 6, 8)
+	p := tuples.NewPoint(-4, 6, 8)
 	// Expected
-	wanted := tuples.Point(-8, 18, 32)
+	wanted := tuples.NewPoint(-8, 18, 32)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -100,11 +100,11 @@ func Test_Scaling_Does_Affect_Vectors(t *testing.T) {
 	// Given
 	transform := Scaling(2, 3, 4)
 	// And
-	v := tuples.Vector(-4, 6, 8)
+	v := tuples.NewVector(-4, 6, 8)
 	// Expected
-	wanted := tuples.Vector(-8, 18, 32)
+	wanted := tuples.NewVector(-8, 18, 32)
 	// When
-	r := transform.MultiplyTuple(v)
+	r := transform.MultiplyVector(v)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, v, r, wanted)
@@ -122,11 +122,11 @@ func Test_Multiplying_By_the_Inverse_Of_a_Scaling_Matrix(t *testing.T) {
 	// And
 	inv := transform.Inverse()
 	// And
-	v := tuples.Vector(-4, 6, 8)
+	v := tuples.NewVector(-4, 6, 8)
 	// Expected
-	wanted := tuples.Vector(-2, 2, 2)
+	wanted := tuples.NewVector(-2, 2, 2)
 	// When
-	r := inv.MultiplyTuple(v)
+	r := inv.MultiplyVector(v)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", inv, v, r, wanted)
@@ -141,11 +141,11 @@ func Test_Reflection_is_Scaling_by_a_Negative_Value(t *testing.T) {
 	// Given
 	transform := Scaling(-1, 1, 1)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(-2, 3, 4)
+	wanted := tuples.NewPoint(-2, 3, 4)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -164,17 +164,17 @@ func Test_Reflection_is_Scaling_by_a_Negative_Value(t *testing.T) {
 // And full_quarter * p = point(0, 0, 1)
 func Test_Rotating_a_Point_Around_the_X_Axis(t *testing.T) {
 	// Given
-	p := tuples.Point(0, 1, 0)
+	p := tuples.NewPoint(0, 1, 0)
 	// And
 	halfQuarter := RotationX(math.Pi / 4)
 	// And
 	fullQuarter := RotationX(math.Pi / 2)
 	// Expected
-	wantedHQ := tuples.Point(0, math.Sqrt2/2, math.Sqrt2/2)
-	wantedFQ := tuples.Point(0, 0, 1)
+	wantedHQ := tuples.NewPoint(0, math.Sqrt2/2, math.Sqrt2/2)
+	wantedFQ := tuples.NewPoint(0, 0, 1)
 	// When
-	hq := halfQuarter.MultiplyTuple(p)
-	fq := fullQuarter.MultiplyTuple(p)
+	hq := halfQuarter.MultiplyPoint(p)
+	fq := fullQuarter.MultiplyPoint(p)
 	// Then
 	if !wantedHQ.Equals(*hq) {
 		t.Errorf("%v * %v = %v, expected %v", halfQuarter, p, hq, wantedHQ)
@@ -192,15 +192,15 @@ func Test_Rotating_a_Point_Around_the_X_Axis(t *testing.T) {
 // Then inv * v = point(0, √2/2, -√2/2)
 func Test_The_Inverse_of_an_X_Rotation_Rotates_in_the_Opposite_Direction(t *testing.T) {
 	// Given
-	p := tuples.Point(0, 1, 0)
+	p := tuples.NewPoint(0, 1, 0)
 	// And
 	halfQuarter := RotationX(math.Pi / 4)
 	// And
 	inv := halfQuarter.Inverse()
 	// Expected
-	wanted := tuples.Point(0, math.Sqrt2/2, -math.Sqrt2/2)
+	wanted := tuples.NewPoint(0, math.Sqrt2/2, -math.Sqrt2/2)
 	// When
-	r := inv.MultiplyTuple(p)
+	r := inv.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("inv(%v) * %v = %v, expected %v", halfQuarter, p, r, wanted)
@@ -215,17 +215,17 @@ func Test_The_Inverse_of_an_X_Rotation_Rotates_in_the_Opposite_Direction(t *test
 // And full_quarter * p = point(1, 0, 0)
 func Test_Rotating_a_Point_Around_the_Y_Axis(t *testing.T) {
 	// Given
-	p := tuples.Point(0, 0, 1)
+	p := tuples.NewPoint(0, 0, 1)
 	// And
 	halfQuarter := RotationY(math.Pi / 4)
 	// And
 	fullQuarter := RotationY(math.Pi / 2)
 	// Expected
-	wantedHQ := tuples.Point(math.Sqrt2/2, 0, math.Sqrt2/2)
-	wantedFQ := tuples.Point(1, 0, 0)
+	wantedHQ := tuples.NewPoint(math.Sqrt2/2, 0, math.Sqrt2/2)
+	wantedFQ := tuples.NewPoint(1, 0, 0)
 	// When
-	hq := halfQuarter.MultiplyTuple(p)
-	fq := fullQuarter.MultiplyTuple(p)
+	hq := halfQuarter.MultiplyPoint(p)
+	fq := fullQuarter.MultiplyPoint(p)
 	// Then
 	if !wantedHQ.Equals(*hq) {
 		t.Errorf("%v * %v = %v, expected %v", halfQuarter, p, hq, wantedHQ)
@@ -244,17 +244,17 @@ func Test_Rotating_a_Point_Around_the_Y_Axis(t *testing.T) {
 // And full_quarter * p = point(-1, 0, 0)
 func Test_Rotating_a_Point_Around_the_Z_Axis(t *testing.T) {
 	// Given
-	p := tuples.Point(0, 1, 0)
+	p := tuples.NewPoint(0, 1, 0)
 	// And
 	halfQuarter := RotationZ(math.Pi / 4)
 	// And
 	fullQuarter := RotationZ(math.Pi / 2)
 	// Expected
-	wantedHQ := tuples.Point(-math.Sqrt2/2, math.Sqrt2/2, 0)
-	wantedFQ := tuples.Point(-1, 0, 0)
+	wantedHQ := tuples.NewPoint(-math.Sqrt2/2, math.Sqrt2/2, 0)
+	wantedFQ := tuples.NewPoint(-1, 0, 0)
 	// When
-	hq := halfQuarter.MultiplyTuple(p)
-	fq := fullQuarter.MultiplyTuple(p)
+	hq := halfQuarter.MultiplyPoint(p)
+	fq := fullQuarter.MultiplyPoint(p)
 	// Then
 	if !wantedHQ.Equals(*hq) {
 		t.Errorf("%v * %v = %v, expected %v", halfQuarter, p, hq, wantedHQ)
@@ -277,11 +277,11 @@ func Test_Shearing_Transformation_Moves_X_in_Proportion_to_Y(t *testing.T) {
 	// Given
 	transform := Shearing(1, 0, 0, 0, 0, 0)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(5, 3, 4)
+	wanted := tuples.NewPoint(5, 3, 4)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -296,11 +296,11 @@ func Test_Shearing_Transformation_Moves_X_in_Proportion_to_Z(t *testing.T) {
 	// Given
 	transform := Shearing(0, 1, 0, 0, 0, 0)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(6, 3, 4)
+	wanted := tuples.NewPoint(6, 3, 4)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -315,11 +315,11 @@ func Test_Shearing_Transformation_Moves_Y_in_Proportion_to_X(t *testing.T) {
 	// Given
 	transform := Shearing(0, 0, 1, 0, 0, 0)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(2, 5, 4)
+	wanted := tuples.NewPoint(2, 5, 4)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -334,11 +334,11 @@ func Test_Shearing_Transformation_Moves_Y_in_Proportion_to_Z(t *testing.T) {
 	// Given
 	transform := Shearing(0, 0, 0, 1, 0, 0)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(2, 7, 4)
+	wanted := tuples.NewPoint(2, 7, 4)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -353,11 +353,11 @@ func Test_Shearing_Transformation_Moves_Z_in_Proportion_to_X(t *testing.T) {
 	// Given
 	transform := Shearing(0, 0, 0, 0, 1, 0)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(2, 3, 6)
+	wanted := tuples.NewPoint(2, 3, 6)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -372,11 +372,11 @@ func Test_Shearing_Transformation_Moves_Z_in_Proportion_to_Y(t *testing.T) {
 	// Given
 	transform := Shearing(0, 0, 0, 0, 0, 1)
 	// And
-	p := tuples.Point(2, 3, 4)
+	p := tuples.NewPoint(2, 3, 4)
 	// Expected
-	wanted := tuples.Point(2, 3, 7)
+	wanted := tuples.NewPoint(2, 3, 7)
 	// When
-	r := transform.MultiplyTuple(p)
+	r := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*r) {
 		t.Errorf("%v * %v = %v, expected %v", transform, p, r, wanted)
@@ -401,7 +401,7 @@ func Test_Shearing_Transformation_Moves_Z_in_Proportion_to_Y(t *testing.T) {
 // Then p4 = point(15, 0, 7)
 func Test_Individual_Transforms_Are_Applied_In_Sequence(t *testing.T) {
 	// Given
-	p := tuples.Point(1, 0, 1)
+	p := tuples.NewPoint(1, 0, 1)
 	// And
 	A := RotationX(math.Pi / 2)
 	// And
@@ -409,23 +409,23 @@ func Test_Individual_Transforms_Are_Applied_In_Sequence(t *testing.T) {
 	// And
 	C := Translation(10, 5, 7)
 	// Expected
-	wantedA := tuples.Point(1, -1, 0)
-	wantedB := tuples.Point(5, -5, 0)
-	wantedC := tuples.Point(15, 0, 7)
+	wantedA := tuples.NewPoint(1, -1, 0)
+	wantedB := tuples.NewPoint(5, -5, 0)
+	wantedC := tuples.NewPoint(15, 0, 7)
 	// When
-	p2 := A.MultiplyTuple(p)
+	p2 := A.MultiplyPoint(p)
 	// Then
 	if !wantedA.Equals(*p2) {
 		t.Errorf("%v * %v = %v, expected %v", A, p, p2, wantedA)
 	}
 	// And when
-	p3 := B.MultiplyTuple(*p2)
+	p3 := B.MultiplyPoint(*p2)
 	// Then
 	if !wantedB.Equals(*p3) {
 		t.Errorf("%v * %v = %v, expected %v", B, p2, p3, wantedB)
 	}
 	// And when
-	p4 := C.MultiplyTuple(*p3)
+	p4 := C.MultiplyPoint(*p3)
 	// Then
 	if !wantedC.Equals(*p4) {
 		t.Errorf("%v * %v = %v, expected %v", B, p3, p4, wantedC)
@@ -441,7 +441,7 @@ func Test_Individual_Transforms_Are_Applied_In_Sequence(t *testing.T) {
 // Then T * p = point(15, 0, 7)
 func Test_Chained_Transforms_Must_Be_Applied_In_Reverse_Order(t *testing.T) {
 	// Given
-	p := tuples.Point(1, 0, 1)
+	p := tuples.NewPoint(1, 0, 1)
 	// And
 	A := RotationX(math.Pi / 2)
 	// And
@@ -449,10 +449,10 @@ func Test_Chained_Transforms_Must_Be_Applied_In_Reverse_Order(t *testing.T) {
 	// And
 	C := Translation(10, 5, 7)
 	// Expected
-	wanted := tuples.Point(15, 0, 7)
+	wanted := tuples.NewPoint(15, 0, 7)
 	// When
 	transform := C.Multiply(*B).Multiply(*A)
-	p2 := transform.MultiplyTuple(p)
+	p2 := transform.MultiplyPoint(p)
 	// Then
 	if !wanted.Equals(*p2) {
 		t.Errorf("(%v * %v * %v ) * %v = %v, expected %v", C, B, A, p, p2, wanted)
@@ -15,11 +15,11 @@ import (
 // Then t = identity_matrix
 func Test_the_Transformation_Matrix_for_the_Default_Orientation(t *testing.T) {
 	// Given from ← point(0, 0, 0)
-	fromPoint := tuples.Point(0, 0, 0)
+	fromPoint := tuples.NewPoint(0, 0, 0)
 	// And to ← point(0, 0, -1)
-	toPoint := tuples.Point(0, 0, -1)
+	toPoint := tuples.NewPoint(0, 0, -1)
 	// And up ← vector(0, 1, 0)
-	upVector := tuples.Vector(0, 1, 0)
+	upVector := tuples.NewVector(0, 1, 0)
 	// When t ← view_transform(from, to, up)
 	trans := NewViewTransform(fromPoint, toPoint, upVector)
 	// Then t = identity_matrix
@@ -36,11 +36,11 @@ func Test_the_Transformation_Matrix_for_the_Default_Orientation(t *testing.T) {
 // Then t = scaling(-1, 1, -1)
 func Test_a_View_Transformation_Matrix_Looking_in_positive_Z_Direction(t *testing.T) {
 	// Given from ← point(0, 0, 0)
-	fromPoint := tuples.Point(0, 0, 0)
+	fromPoint := tuples.NewPoint(0, 0, 0)
 	// And to ← point(0, 0, 1)
-	toPoint := tuples.Point(0, 0, 1)
+	toPoint := tuples.NewPoint(0, 0, 1)
 	// And up ← vector(0, 1, 0)
-	upVector := tuples.Vector(0, 1, 0)
+	upVector := tuples.NewVector(0, 1, 0)
 	// When t ← view_transform(from, to, up)
 	trans := NewViewTransform(fromPoint, toPoint, upVector)
 	// Expected
@@ -59,10 +59,19 @@ func Test_a_View_Transformation_Matrix_Looking_in_positive_Z_Direction(t *testin
 // Then t = translation(0, 0, -8)
 func Test_The_View_Transformation_Moves_the_World(t *testing.T) {
 	// Given from ← point(0, 0, 8)
+	fromPoint := tuples.NewPoint(0, 0, 8)
 	// And to ← point(0, 0, 0)
+	toPoint := tuples.NewPoint(0, 0, 0)
 	// And up ← vector(0, 1, 0)
+	upVector := tuples.NewVector(0, 1, 0)
 	// When trans ← view_transform(from, to, up)
+	trans := NewViewTransform(fromPoint, toPoint, upVector)
+	// Expected
+	wanted := Translation(0, 0, -8)
 	// Then trans = translation(0, 0, -8)
+	if !wanted.Equals(trans) {
+		t.Errorf("NewViewTransform(%v, %v, %v) = %v, expected %v", fromPoint, toPoint, upVector, trans, wanted)
+	}
 }
 
 // Scenario: An arbitrary view transformation
@@ -77,12 +86,25 @@ func Test_The_View_Transformation_Moves_the_World(t *testing.T) {
 //       |  0.00000 | 0.00000 |  0.00000 |  1.00000 |
 func Test_an_Arbitrary_View_Transformation(t *testing.T) {
 	// Given from ← point(1, 3, 2)
+	fromPoint := tuples.NewPoint(1, 3, 2)
 	// And to ← point(4, -2, 8)
+	toPoint := tuples.NewPoint(4, -2, 8)
 	// And up ← vector(1, 1, 0)
+	upVector := tuples.NewVector(1, 1, 0)
 	// When t ← view_transform(from, to, up)
+	trans := NewViewTransform(fromPoint, toPoint, upVector)
 	// Then t is the following 4x4 matrix:
 	//       | -0.50709 | 0.50709 |  0.67612 | -2.36643 |
 	//       |  0.76772 | 0.60609 |  0.12122 | -2.82843 |
 	//       | -0.35857 | 0.59761 | -0.71714 |  0.00000 |
 	//       |  0.00000 | 0.00000 |  0.00000 |  1.00000 |
+	wanted := matrix.NewMatrix([][]float64{
+		{-0.50709, 0.50709, 0.67612, -2.36643},
+		{0.76772, 0.60609, 0.12122, -2.82843},
+		{-0.35857, 0.59761, -0.71714, 0.00000},
+		{0.00000, 0.00000, 0.00000, 1.00000},
+	})
+	if !wanted.Equals(trans) {
+		t.Errorf("NewViewTransform(%v, %v, %v) = %v, expected %v", fromPoint, toPoint, upVector, trans, wanted)
+	}
 }
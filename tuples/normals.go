@@ -0,0 +1,52 @@
+package tuples
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normal models the direction perpendicular to a surface
+// Unlike a Vector, a Normal is transformed by the inverse transpose of a transformation, see matrix.Matrix4.TransformNormal
+type Normal struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewNormal creates a new Normal
+func NewNormal(x float64, y float64, z float64) Normal {
+	return Normal{x, y, z}
+}
+
+// String formats the Normal as a string
+func (n Normal) String() string {
+	return fmt.Sprintf("Normal( %9.5f, %9.5f, %9.5f )", n.X, n.Y, n.Z)
+}
+
+// Vector converts the Normal to a Vector with the same direction
+func (n Normal) Vector() Vector {
+	return Vector{n.X, n.Y, n.Z}
+}
+
+// Equals checks if two Normals are equal
+func (n Normal) Equals(other Normal) bool {
+	return math.Abs(n.X-other.X) < Epsilon &&
+		math.Abs(n.Y-other.Y) < Epsilon &&
+		math.Abs(n.Z-other.Z) < Epsilon
+}
+
+// Negate reverses the direction of a Normal
+func (n Normal) Negate() Normal {
+	return Normal{-n.X, -n.Y, -n.Z}
+}
+
+// Normalize scales a Normal to length 1
+func (n Normal) Normalize() Normal {
+	length := math.Sqrt(n.X*n.X + n.Y*n.Y + n.Z*n.Z)
+	return Normal{n.X / length, n.Y / length, n.Z / length}
+}
+
+// Dot calculates the dot product with a Vector
+func (n Normal) Dot(v Vector) float64 {
+	return n.X*v.X + n.Y*v.Y + n.Z*v.Z
+}
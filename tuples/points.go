@@ -0,0 +1,50 @@
+package tuples
+
+import (
+	"fmt"
+	"math"
+)
+
+// Point models a position in space
+type Point struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewPoint creates a new Point
+func NewPoint(x float64, y float64, z float64) Point {
+	return Point{x, y, z}
+}
+
+// String formats the Point as a string
+func (p Point) String() string {
+	return fmt.Sprintf("Point( %9.5f, %9.5f, %9.5f )", p.X, p.Y, p.Z)
+}
+
+// Tuple converts the Point to a Tuple with w = 1.0
+func (p Point) Tuple() Tuple {
+	return Tuple{p.X, p.Y, p.Z, 1.0}
+}
+
+// Equals checks if two Points are equal
+func (p Point) Equals(other Point) bool {
+	return math.Abs(p.X-other.X) < Epsilon &&
+		math.Abs(p.Y-other.Y) < Epsilon &&
+		math.Abs(p.Z-other.Z) < Epsilon
+}
+
+// Add moves the Point along a Vector
+func (p Point) Add(v Vector) Point {
+	return Point{p.X + v.X, p.Y + v.Y, p.Z + v.Z}
+}
+
+// Subtract calculates the Vector from another Point to the current Point
+func (p Point) Subtract(other Point) Vector {
+	return Vector{p.X - other.X, p.Y - other.Y, p.Z - other.Z}
+}
+
+// SubtractVector moves the Point back along a Vector
+func (p Point) SubtractVector(v Vector) Point {
+	return Point{p.X - v.X, p.Y - v.Y, p.Z - v.Z}
+}
@@ -8,7 +8,8 @@ import (
 // Epsilon indicates the precision of calculations with Tuples and Matrices
 const Epsilon = 1e-5
 
-// Tuple models a point (w = 1.0) or vector (w = 0.0)
+// Tuple models a point (w = 1.0) or vector (w = 0.0) as four components, for calculations with matrices
+// Point, Vector and Normal are the distinct types for geometry, that only allow meaningful operations
 type Tuple struct {
 	X float64
 	Y float64
@@ -94,20 +95,11 @@ func (t Tuple) Dot(other Tuple) float64 {
 
 // Cross caculates cross product with another vector
 func (t Tuple) Cross(other Tuple) Tuple {
-	return Vector(
-		t.Y*other.Z-t.Z*other.Y,
-		t.Z*other.X-t.X*other.Z,
-		t.X*other.Y-t.Y*other.X)
-}
-
-// Point creates a new Point type Tuple
-func Point(x float64, y float64, z float64) Tuple {
-	return Tuple{x, y, z, 1.0}
-}
-
-// Vector creates a new Vector type Tuple
-func Vector(x float64, y float64, z float64) Tuple {
-	return Tuple{x, y, z, 0.0}
+	return Tuple{
+		t.Y*other.Z - t.Z*other.Y,
+		t.Z*other.X - t.X*other.Z,
+		t.X*other.Y - t.Y*other.X,
+		0.0}
 }
 
 // Reflect returns the reflection of an incoming vector with the current vector as normal
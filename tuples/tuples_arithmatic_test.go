@@ -45,26 +45,46 @@ func Test_Adding_two_points(t *testing.T) {
 	}
 }
 
+// Scenario: Adding a vector to a point
+// Given p ← point(3, -2, 5)
+// And v ← vector(-2, 3, 1)
+// Then p + v = point(1, 1, 6)
+func Test_Adding_vector_to_point(t *testing.T) {
+	// Given
+	p1 := NewPoint(3, -2, 5)
+	v := NewVector(-2, 3, 1)
+	// Expected
+	wanted := NewPoint(1, 1, 6)
+	// Then
+	p := p1.Add(v)
+	if !p.Equals(wanted) {
+		t.Errorf("%v + %v = %v, want %v", p1, v, p, wanted)
+	}
+	if !p.Tuple().IsPoint() {
+		t.Errorf("%v + %v returns Point? %t, wanted true", p1, v, p.Tuple().IsPoint())
+	}
+}
+
 // Scenario: Subtracting two points
 // Given p1 ← point(3, 2, 1)
 // And p2 ← point(5, 6, 7)
 // Then p1 - p2 = vector(-2, -4, -6)
 func Test_Subtracting_two_points(t *testing.T) {
 	// Given
-	p1 := Point(3, 2, 1)
-	p2 := Point(5, 6, 7)
+	p1 := NewPoint(3, 2, 1)
+	p2 := NewPoint(5, 6, 7)
 	// Expected
-	wanted := Vector(-2, -4, -6)
+	wanted := NewVector(-2, -4, -6)
 	// Then
 	p := p1.Subtract(p2)
 	if !p.Equals(wanted) {
 		t.Errorf("%v - %v = %v, want %v", p1, p2, p, wanted)
 	}
-	if p.IsPoint() {
-		t.Errorf("%v + %v returns Point? %t, wanted false", p1, p2, p.IsPoint())
+	if p.Tuple().IsPoint() {
+		t.Errorf("%v + %v returns Point? %t, wanted false", p1, p2, p.Tuple().IsPoint())
 	}
-	if !p.IsVector() {
-		t.Errorf("%v + %v returns Vector? %t, wanted true", p1, p2, p.IsVector())
+	if !p.Tuple().IsVector() {
+		t.Errorf("%v + %v returns Vector? %t, wanted true", p1, p2, p.Tuple().IsVector())
 	}
 }
 
@@ -74,20 +94,20 @@ func Test_Subtracting_two_points(t *testing.T) {
 // Then p - v = point(-2, -4, -6)
 func Test_Subtracting_vector_from_point(t *testing.T) {
 	// Given
-	p1 := Point(3, 2, 1)
-	v := Vector(5, 6, 7)
+	p1 := NewPoint(3, 2, 1)
+	v := NewVector(5, 6, 7)
 	// Expected
-	wanted := Point(-2, -4, -6)
+	wanted := NewPoint(-2, -4, -6)
 	// Then
-	p := p1.Subtract(v)
+	p := p1.SubtractVector(v)
 	if !p.Equals(wanted) {
 		t.Errorf("%v - %v = %v, want %v", p1, v, p, wanted)
 	}
-	if !p.IsPoint() {
-		t.Errorf("%v + %v returns Point? %t, wanted true", p1, v, p.IsPoint())
+	if !p.Tuple().IsPoint() {
+		t.Errorf("%v + %v returns Point? %t, wanted true", p1, v, p.Tuple().IsPoint())
 	}
-	if p.IsVector() {
-		t.Errorf("%v + %v returns Vector? %t, wanted false", p1, v, p.IsVector())
+	if p.Tuple().IsVector() {
+		t.Errorf("%v + %v returns Vector? %t, wanted false", p1, v, p.Tuple().IsVector())
 	}
 }
 
@@ -97,20 +117,20 @@ func Test_Subtracting_vector_from_point(t *testing.T) {
 // Then v1 - v2 = vector(-2, -4, -6)
 func Test_Subtracting_two_vectors(t *testing.T) {
 	// Given
-	v1 := Vector(3, 2, 1)
-	v2 := Vector(5, 6, 7)
+	v1 := NewVector(3, 2, 1)
+	v2 := NewVector(5, 6, 7)
 	// Expected
-	wanted := Vector(-2, -4, -6)
+	wanted := NewVector(-2, -4, -6)
 	// Then
 	v := v1.Subtract(v2)
 	if !v.Equals(wanted) {
 		t.Errorf("%v - %v = %v, want %v", v1, v2, v, wanted)
 	}
-	if v.IsPoint() {
-		t.Errorf("%v + %v returns Point? %t, wanted false", v1, v2, v.IsPoint())
+	if v.Tuple().IsPoint() {
+		t.Errorf("%v + %v returns Point? %t, wanted false", v1, v2, v.Tuple().IsPoint())
 	}
-	if !v.IsVector() {
-		t.Errorf("%v + %v returns Vector? %t, wanted true", v1, v2, v.IsVector())
+	if !v.Tuple().IsVector() {
+		t.Errorf("%v + %v returns Vector? %t, wanted true", v1, v2, v.Tuple().IsVector())
 	}
 }
 
@@ -120,20 +140,20 @@ func Test_Subtracting_two_vectors(t *testing.T) {
 // Then zero - v = vector(-1, 2, -3)
 func Test_Subtracting_vector_from_zero_vector(t *testing.T) {
 	// Given
-	v1 := Vector(1, -2, 3)
-	zero := Vector(0, 0, 0)
+	v1 := NewVector(1, -2, 3)
+	zero := NewVector(0, 0, 0)
 	// Expected
-	wanted := Vector(-1, 2, -3)
+	wanted := NewVector(-1, 2, -3)
 	// Then
 	v := zero.Subtract(v1)
 	if !v.Equals(wanted) {
 		t.Errorf("%v - %v = %v, want %v", zero, v1, v, wanted)
 	}
-	if v.IsPoint() {
-		t.Errorf("%v + %v returns Point? %t, wanted false", zero, v1, v.IsPoint())
+	if v.Tuple().IsPoint() {
+		t.Errorf("%v + %v returns Point? %t, wanted false", zero, v1, v.Tuple().IsPoint())
 	}
-	if !v.IsVector() {
-		t.Errorf("%v + %v returns Vector? %t, wanted true", zero, v1, v.IsVector())
+	if !v.Tuple().IsVector() {
+		t.Errorf("%v + %v returns Vector? %t, wanted true", zero, v1, v.Tuple().IsVector())
 	}
 }
 
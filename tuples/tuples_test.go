@@ -74,12 +74,12 @@ func Test_Tuple_with_w_0_is_vector(t *testing.T) {
 // Then p = tuple(4, -4, 3, 1)
 func Test_Point_describes_tuples_with_w_1(t *testing.T) {
 	// Given
-	p := Point(4, -4, 3)
+	p := NewPoint(4, -4, 3)
 	// Expected
 	wanted := Tuple{4, -4, 3, 1.0}
 	// Then
-	if !p.Equals(wanted) {
-		t.Errorf("Point(4, -4, 3) == %v, want %v", p, wanted)
+	if !p.Tuple().Equals(wanted) {
+		t.Errorf("NewPoint(4, -4, 3) == %v, want %v", p.Tuple(), wanted)
 	}
 }
 
@@ -88,12 +88,12 @@ func Test_Point_describes_tuples_with_w_1(t *testing.T) {
 // Then v = tuple(4, -4, 3, 0)
 func Test_Vector_describes_tuples_with_w_1(t *testing.T) {
 	// Given
-	p := Vector(4, -4, 3)
+	p := NewVector(4, -4, 3)
 	// Expected
 	wanted := Tuple{4, -4, 3, 0.0}
 	// Then
-	if !p.Equals(wanted) {
-		t.Errorf("Vector(4, -4, 3) == %v, want %v", p, wanted)
+	if !p.Tuple().Equals(wanted) {
+		t.Errorf("NewVector(4, -4, 3) == %v, want %v", p.Tuple(), wanted)
 	}
 }
 
@@ -104,13 +104,13 @@ func Test_Vector_describes_tuples_with_w_1(t *testing.T) {
 // Then r = vector(1, 1, 0)
 func Test_Reflecting_a_Vector_Approaching_at_45_degrees(t *testing.T) {
 	// Given
-	v := Vector(1, -1, 0)
+	v := NewVector(1, -1, 0)
 	// And
-	n := Vector(0, 1, 0)
+	n := NewNormal(0, 1, 0)
 	// When
-	r := n.Reflect(v)
+	r := v.Reflect(n)
 	// Expected
-	wanted := Vector(1, 1, 0)
+	wanted := NewVector(1, 1, 0)
 	// Then
 	if !wanted.Equals(r) {
 		t.Errorf("Reflect( %v, %v) == %v, want %v", v, n, r, wanted)
@@ -124,13 +124,13 @@ func Test_Reflecting_a_Vector_Approaching_at_45_degrees(t *testing.T) {
 // Then r = vector(1, 0, 0)
 func Test_Reflecting_a_Vector_off_a_Slanted_Surface(t *testing.T) {
 	// Given
-	v := Vector(0, -1, 0)
+	v := NewVector(0, -1, 0)
 	// And
-	n := Vector(math.Sqrt2/2.0, math.Sqrt2/2.0, 0)
+	n := NewNormal(math.Sqrt2/2.0, math.Sqrt2/2.0, 0)
 	// When
-	r := n.Reflect(v)
+	r := v.Reflect(n)
 	// Expected
-	wanted := Vector(1, 0, 0)
+	wanted := NewVector(1, 0, 0)
 	// Then
 	if !wanted.Equals(r) {
 		t.Errorf("Reflect( %v, %v) == %v, want %v", v, n, r, wanted)
@@ -21,7 +21,7 @@ import (
 // Given v ← vector(-1, -2, -3)
 // Then magnitude(v) = √14
 func Test_Magnitude_Of_Vector(t *testing.T) {
-	cases := []Tuple{Vector(1, 0, 0), Vector(0, 1, 0), Vector(0, 0, 1), Vector(1, 2, 3), Vector(-1, -2, -3)}
+	cases := []Vector{NewVector(1, 0, 0), NewVector(0, 1, 0), NewVector(0, 0, 1), NewVector(1, 2, 3), NewVector(-1, -2, -3)}
 	wanteds := []float64{1.0, 1.0, 1.0, math.Sqrt(14), math.Sqrt(14)}
 	for i := 0; i < len(cases); i++ {
 		// Given
@@ -47,8 +47,8 @@ func Test_Magnitude_Of_Vector(t *testing.T) {
 // When norm ← normalize(v)
 // Then magnitude(norm) = 1
 func Test_Normalizing_a_Vector(t *testing.T) {
-	cases := []Tuple{Vector(1, 0, 0), Vector(4, 0, 0), Vector(1, 2, 3)}
-	wanteds := []Tuple{Vector(1, 0, 0), Vector(1, 0, 0), Vector(0.26726, 0.53452, 0.80178)}
+	cases := []Vector{NewVector(1, 0, 0), NewVector(4, 0, 0), NewVector(1, 2, 3)}
+	wanteds := []Vector{NewVector(1, 0, 0), NewVector(1, 0, 0), NewVector(0.26726, 0.53452, 0.80178)}
 	for i := 0; i < len(cases); i++ {
 		// Given
 		v := cases[i]
@@ -71,8 +71,8 @@ func Test_Normalizing_a_Vector(t *testing.T) {
 // And b ← vector(2, 3, 4) Then a dot b = 20
 func Test_Dot_Product_of_two_Vectors(t *testing.T) {
 	// Given
-	t1 := Vector(1, 2, 3)
-	t2 := Vector(2, 3, 4)
+	t1 := NewVector(1, 2, 3)
+	t2 := NewVector(2, 3, 4)
 	// Expected
 	wanted := 20.0
 	// Then
@@ -89,11 +89,11 @@ func Test_Dot_Product_of_two_Vectors(t *testing.T) {
 // And b cross a = vector(1, -2, 1)
 func Test_Cross_Product_of_two_Vectors(t *testing.T) {
 	// Given
-	t1 := Vector(1, 2, 3)
-	t2 := Vector(2, 3, 4)
+	t1 := NewVector(1, 2, 3)
+	t2 := NewVector(2, 3, 4)
 	// Expected
-	wanted1 := Vector(-1, 2, -1)
-	wanted2 := Vector(1, -2, 1)
+	wanted1 := NewVector(-1, 2, -1)
+	wanted2 := NewVector(1, -2, 1)
 	// Then
 	v1 := t1.Cross(t2)
 	v2 := t2.Cross(t1)
@@ -104,3 +104,28 @@ func Test_Cross_Product_of_two_Vectors(t *testing.T) {
 		t.Errorf("%v x %v = %v, want %v", t2, t1, v2, wanted2)
 	}
 }
+
+// Scenario: An orthonormal basis around a vector
+// Given v ← normalize(vector(<x>, <y>, <z>))
+// When a, b ← orthonormal_basis(v)
+// Then a, b and v have length 1
+// And a, b and v are perpendicular to each other
+// Examples:
+// | x | y  | z |
+// | 0 | 0  | 1 |
+// | 1 | 0  | 0 |
+// | 1 | -2 | 3 |
+func Test_an_Orthonormal_Basis_Around_a_Vector(t *testing.T) {
+	for _, v := range []Vector{NewVector(0, 0, 1), NewVector(1, 0, 0), NewVector(1, -2, 3).Normalize()} {
+		// When
+		a, b := v.OrthonormalBasis()
+		// Then
+		if math.Abs(a.Magnitude()-1) > Epsilon || math.Abs(b.Magnitude()-1) > Epsilon {
+			t.Errorf("OrthonormalBasis(%v) = %v, %v, expected unit vectors", v, a, b)
+		}
+		// And
+		if math.Abs(a.Dot(b)) > Epsilon || math.Abs(a.Dot(v)) > Epsilon || math.Abs(b.Dot(v)) > Epsilon {
+			t.Errorf("OrthonormalBasis(%v) = %v, %v, expected perpendicular vectors", v, a, b)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package tuples
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vector models a direction and distance in space
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewVector creates a new Vector
+func NewVector(x float64, y float64, z float64) Vector {
+	return Vector{x, y, z}
+}
+
+// String formats the Vector as a string
+func (v Vector) String() string {
+	return fmt.Sprintf("Vector( %9.5f, %9.5f, %9.5f )", v.X, v.Y, v.Z)
+}
+
+// Tuple converts the Vector to a Tuple with w = 0.0
+func (v Vector) Tuple() Tuple {
+	return Tuple{v.X, v.Y, v.Z, 0.0}
+}
+
+// Equals checks if two Vectors are equal
+func (v Vector) Equals(other Vector) bool {
+	return math.Abs(v.X-other.X) < Epsilon &&
+		math.Abs(v.Y-other.Y) < Epsilon &&
+		math.Abs(v.Z-other.Z) < Epsilon
+}
+
+// Add adds a Vector to the current Vector
+func (v Vector) Add(other Vector) Vector {
+	return Vector{v.X + other.X, v.Y + other.Y, v.Z + other.Z}
+}
+
+// Subtract subtracts a Vector from the current Vector
+func (v Vector) Subtract(other Vector) Vector {
+	return Vector{v.X - other.X, v.Y - other.Y, v.Z - other.Z}
+}
+
+// Negate negates a Vector
+func (v Vector) Negate() Vector {
+	return Vector{-v.X, -v.Y, -v.Z}
+}
+
+// Multiply multiplies a Vector with a scalar
+func (v Vector) Multiply(factor float64) Vector {
+	return Vector{v.X * factor, v.Y * factor, v.Z * factor}
+}
+
+// DivideBy divides a Vector by a scalar
+func (v Vector) DivideBy(factor float64) Vector {
+	return Vector{v.X / factor, v.Y / factor, v.Z / factor}
+}
+
+// Magnitude calculates the length of a Vector
+func (v Vector) Magnitude() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Normalize scales a Vector to length 1
+func (v Vector) Normalize() Vector {
+	return v.DivideBy(v.Magnitude())
+}
+
+// Dot calculates the dot product with another Vector
+func (v Vector) Dot(other Vector) float64 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+// Cross calculates the cross product with another Vector
+func (v Vector) Cross(other Vector) Vector {
+	return Vector{
+		v.Y*other.Z - v.Z*other.Y,
+		v.Z*other.X - v.X*other.Z,
+		v.X*other.Y - v.Y*other.X,
+	}
+}
+
+// OrthonormalBasis finds two unit vectors perpendicular to the unit Vector and to each other
+func (v Vector) OrthonormalBasis() (Vector, Vector) {
+	helper := NewVector(1, 0, 0)
+	if math.Abs(v.X) > 0.9 {
+		helper = NewVector(0, 1, 0)
+	}
+	a := helper.Cross(v).Normalize()
+	return a, v.Cross(a).Normalize()
+}
+
+// Reflect returns the reflection of the Vector around a normal
+func (v Vector) Reflect(normal Normal) Vector {
+	n := normal.Vector()
+	return v.Subtract(n.Multiply(2 * v.Dot(n)))
+}
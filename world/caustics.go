@@ -0,0 +1,163 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/photons"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// DefaultCausticNeighbors is the number of photons that the radiance of a caustic is estimated from
+const DefaultCausticNeighbors = 50
+
+// maxPhotonBounces is the number of times a photon is reflected or refracted before it is given up
+const maxPhotonBounces = 16
+
+// Caustics hold the photons that reached diffuse surfaces after being reflected or refracted,
+// such as the light focused by a glass ball onto the table below it
+// The light at a point is estimated from the Neighbors photons nearest to it, within the Radius
+type Caustics struct {
+	Photons   *photons.Map
+	Neighbors int
+	Radius    float64
+}
+
+// NewCaustics creates new Caustics estimating the light from the DefaultCausticNeighbors nearest photons within radius
+func NewCaustics(photonMap *photons.Map, radius float64) *Caustics {
+	return &Caustics{photonMap, DefaultCausticNeighbors, radius}
+}
+
+// TraceCaustics emits count photons from every light source towards every reflective or transparent object,
+// follows them as they are mirrored and refracted, and keeps those arriving at a diffuse surface afterwards
+// The photons are traced at time 0. Like the direct light of ShadeHit, the light does not fall off with distance:
+// a surface facing a light receives its Intensity, so the power of a photon is scaled by the square of the
+// distance from the light to where it first hits the target
+// Set the result as the Caustics of the world to add them to ShadeHit
+func (w World) TraceCaustics(count int, radius float64, rng *rand.Rand) *Caustics {
+	stored := make([]photons.Photon, 0)
+	for _, light := range w.LightSources {
+		for i := range w.Objects {
+			target := &w.Objects[i]
+			if !target.Material.IsSpecular() {
+				continue
+			}
+			stored = append(stored, w.emitPhotons(light, target, count, rng)...)
+		}
+	}
+	return NewCaustics(photons.NewMap(stored), radius)
+}
+
+// emitPhotons emits count photons from a light into the cone around the bounding sphere of a target,
+// and returns the photons that are stored after hitting the target first
+func (w World) emitPhotons(light lights.PointLight, target *spheres.Sphere, count int, rng *rand.Rand) []photons.Photon {
+	box := target.Bounds()
+	center := box.Min.Add(box.Max.Subtract(box.Min).Multiply(0.5))
+	boundingRadius := box.Max.Subtract(box.Min).Magnitude() / 2
+	toCenter := center.Subtract(light.Position)
+	distance := toCenter.Magnitude()
+
+	// the cosine of the widest angle between a photon and the axis of the cone
+	cosMax := -1.0
+	axis := tuples.NewVector(0, 1, 0)
+	if distance > boundingRadius {
+		cosMax = math.Sqrt(1 - boundingRadius*boundingRadius/(distance*distance))
+		axis = toCenter.DivideBy(distance)
+	}
+	a, b := axis.OrthonormalBasis()
+	solidAngle := 2 * math.Pi * (1 - cosMax)
+	power := light.Intensity.Multiply(solidAngle / float64(count))
+
+	result := make([]photons.Photon, 0)
+	for i := 0; i < count; i++ {
+		cosTheta := 1 - rng.Float64()*(1-cosMax)
+		sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+		phi := 2 * math.Pi * rng.Float64()
+		direction := a.Multiply(sinTheta * math.Cos(phi)).
+			Add(b.Multiply(sinTheta * math.Sin(phi))).
+			Add(axis.Multiply(cosTheta))
+		if photon, ok := w.tracePhoton(*rays.NewRay(light.Position, direction), target, power, rng); ok {
+			result = append(result, photon)
+		}
+	}
+	return result
+}
+
+// tracePhoton follows a photon that must hit the target first, choosing at random at every surface whether
+// it is mirrored, refracted or stops, with the chances by which ShadeHit weighs the reflected and refracted light
+// ok is false when the photon leaves the world, is absorbed, or stops before it was mirrored or refracted
+// The power is scaled by the square of the distance the photon travels to the target, as TraceCaustics describes
+func (w World) tracePhoton(ray rays.Ray, target *spheres.Sphere, power colors.Color, rng *rand.Rand) (photons.Photon, bool) {
+	offset := w.tolerance().RayOffset
+	for bounce := 0; bounce < maxPhotonBounces; bounce++ {
+		hit := w.Intersect(ray).Hit()
+		if hit == nil || (bounce == 0 && hit.Object != target) {
+			return photons.Photon{}, false
+		}
+		hit.PrepareHitWithOffset(ray, offset)
+		material := hit.Object.Material.SurfaceAt(hit.Object.ShapePointAt(hit.Point, hit.RayTime))
+		if bounce == 0 {
+			power = power.Multiply(hit.Time * hit.Time)
+		}
+
+		reflectChance, refractChance := specularChances(material, *hit)
+		roll := rng.Float64()
+		switch {
+		case roll < reflectChance:
+			ray = *rays.NewRayAtTime(hit.OverPoint, reflectV(*hit), ray.Time)
+		case roll < reflectChance+refractChance:
+			direction, ok := refractV(material, *hit)
+			if !ok {
+				return photons.Photon{}, false
+			}
+			ray = *rays.NewRayAtTime(underPoint(*hit), direction, ray.Time)
+		default:
+			if bounce == 0 {
+				return photons.Photon{}, false
+			}
+			return photons.NewPhoton(hit.Point, ray.Direction, power), true
+		}
+	}
+	return photons.Photon{}, false
+}
+
+// Radiance estimates the light of the caustics reflected by a material at a hit towards the eye,
+// from the density of the photons nearest to the hit
+func (c *Caustics) Radiance(material materials.Material, hit rays.Intersection) colors.Color {
+	found := c.Photons.Nearest(hit.Point, c.Neighbors, c.Radius)
+	if len(found) == 0 {
+		return colors.Black()
+	}
+	radiusSquared := c.Radius * c.Radius
+	if len(found) == c.Neighbors {
+		v := hit.Point.Subtract(found[len(found)-1].Position)
+		radiusSquared = v.Dot(v)
+	}
+	if radiusSquared <= 0 {
+		return colors.Black()
+	}
+	result := colors.Black()
+	for _, photon := range found {
+		// photons arriving at the other side of the surface do not light this side
+		if hit.NormalV.Dot(photon.Direction) >= 0 {
+			continue
+		}
+		reflectance := photonReflectance(material, hit, photon.Direction.Negate())
+		result = result.Add(reflectance.Blend(photon.Power))
+	}
+	return result.Multiply(1 / (math.Pi * radiusSquared))
+}
+
+// photonReflectance is the factor by which a material reflects the light arriving from lightV towards the eye,
+// per unit of irradiance, matching how Lighting reflects the light of a point light
+func photonReflectance(material materials.Material, hit rays.Intersection, lightV tuples.Vector) colors.Color {
+	if material.Model == materials.CookTorrance {
+		return material.BRDF(hit.NormalV, hit.EyeV, lightV).Multiply(math.Pi)
+	}
+	return material.Color.Multiply(material.Diffuse)
+}
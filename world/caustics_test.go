@@ -0,0 +1,127 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// glassOnTable creates a world with a glass ball floating above a table lit from above
+func glassOnTable() World {
+	table := spheres.NewUnitSphere()
+	table.SetTransform(transformations.Translation(0, -100, 0).Multiply(*transformations.Scaling(100, 100, 100)))
+	table.Material.Specular = 0
+	ball := glassSphere()
+	ball.SetTransform(transformations.Translation(0, 2, 0))
+	light := lights.NewPointLight(tuples.NewPoint(0, 10, 0), colors.White())
+	return NewWorld([]spheres.Sphere{*table, *ball}, []lights.PointLight{light})
+}
+
+// shadeTableAt shades the table of glassOnTable at the point below (x, 0.5, 0)
+func shadeTableAt(w World, x float64) colors.Color {
+	r := rays.NewRay(tuples.NewPoint(x, 0.5, 0), tuples.NewVector(0, -1, 0))
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.tolerance().RayOffset)
+	return w.ShadeHit(*hit)
+}
+
+// Scenario: A world without reflective or transparent objects has no caustics
+// Given w ← default_world()
+// When caustics ← trace_caustics(w, 1000, 0.1, random(1))
+// Then caustics holds no photons
+func Test_a_World_without_Reflective_or_Transparent_Objects_has_no_Caustics(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// When
+	caustics := w.TraceCaustics(1000, 0.1, rand.New(rand.NewSource(1)))
+	// Then
+	if caustics.Photons.Len() != 0 {
+		t.Errorf("TraceCaustics() stored %d photons, expected none", caustics.Photons.Len())
+	}
+}
+
+// Scenario: Caustic photons are stored on diffuse surfaces only
+// Given w ← a glass ball above a table, lit from above
+// When caustics ← trace_caustics(w, 5000, 0.1, random(2))
+// Then caustics holds photons
+// And every photon lies on the table
+func Test_Caustic_Photons_are_Stored_on_Diffuse_Surfaces_only(t *testing.T) {
+	// Given
+	w := glassOnTable()
+	// When
+	caustics := w.TraceCaustics(5000, 0.1, rand.New(rand.NewSource(2)))
+	// Then
+	if caustics.Photons.Len() == 0 {
+		t.Fatalf("TraceCaustics() stored no photons, expected the light passing through the ball")
+	}
+	// And
+	center := tuples.NewPoint(0, -100, 0)
+	for _, photon := range caustics.Photons.Photons() {
+		if d := photon.Position.Subtract(center).Magnitude(); math.Abs(d-100) > 1e-6 {
+			t.Errorf("%v lies at %v from the center of the table, expected 100", photon, d)
+		}
+	}
+}
+
+// Scenario: A glass ball focuses light onto the table in its shadow
+// Given w ← a glass ball above a table, lit from above
+// When shadow ← shade_hit(w, table below point(0, 0.5, 0))
+// And w.caustics ← trace_caustics(w, 20000, 0.1, random(1))
+// And focus ← shade_hit(w, table below point(0, 0.5, 0))
+// And lit ← shade_hit(w, table below point(3, 0.5, 0))
+// Then shadow = color(0.1, 0.1, 0.1), the ambient light
+// And focus is brighter than lit, the table in full light
+func Test_a_Glass_Ball_Focuses_Light_onto_the_Table_in_its_Shadow(t *testing.T) {
+	// Given
+	w := glassOnTable()
+	// When
+	shadow := shadeTableAt(w, 0)
+	// And
+	w.Caustics = w.TraceCaustics(20000, 0.1, rand.New(rand.NewSource(1)))
+	focus := shadeTableAt(w, 0)
+	// And
+	lit := shadeTableAt(w, 3)
+	// Expected
+	wanted := colors.NewColor(0.1, 0.1, 0.1)
+	// Then
+	if !wanted.Equals(shadow) {
+		t.Errorf("ShadeHit() in the shadow of the ball = %v, expected %v", shadow, wanted)
+	}
+	// And
+	if focus.Red <= lit.Red {
+		t.Errorf("ShadeHit() in the focus of the ball = %v, expected brighter than %v", focus, lit)
+	}
+}
+
+// Scenario: The brightness of a caustic relative to the direct light does not depend on the distance to the light
+// Given near ← a glass ball above a table, lit from point(0, 10, 0)
+// And far ← a glass ball above a table, lit from point(0, 40, 0)
+// When the caustics of near and far are traced with 20000 photons, random(1)
+// And they are estimated from all photons within 1, which holds the whole caustic of either light
+// And ratio(w) ← shade_hit(w, table below point(0, 0.5, 0)) / shade_hit(w, table below point(3, 0.5, 0))
+// Then ratio(far) is about ratio(near)
+func Test_the_Brightness_of_a_Caustic_Relative_to_the_Direct_Light_does_not_Depend_on_the_Distance_to_the_Light(t *testing.T) {
+	// Given
+	near := glassOnTable()
+	// And
+	far := glassOnTable()
+	far.LightSources[0] = lights.NewPointLight(tuples.NewPoint(0, 40, 0), colors.White())
+	// When
+	for _, w := range []*World{&near, &far} {
+		w.Caustics = w.TraceCaustics(20000, 1, rand.New(rand.NewSource(1)))
+		w.Caustics.Neighbors = w.Caustics.Photons.Len()
+	}
+	// Then
+	nearRatio := shadeTableAt(near, 0).Red / shadeTableAt(near, 3).Red
+	farRatio := shadeTableAt(far, 0).Red / shadeTableAt(far, 3).Red
+	if math.Abs(farRatio-nearRatio) > 0.1*nearRatio {
+		t.Errorf("focus to lit ratio = %g with the light at 40, expected about %g as with the light at 10", farRatio, nearRatio)
+	}
+}
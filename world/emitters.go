@@ -0,0 +1,85 @@
+package world
+
+import (
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/sampling"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// DefaultEmitterSamples is the number of points sampled on every emissive object for the light it casts,
+// when the EmitterSamples of a World are not set
+const DefaultEmitterSamples = 16
+
+// Emitters returns the indices of the objects in the world with an emissive material
+func (w World) Emitters() []int {
+	result := make([]int, 0)
+	for i := range w.Objects {
+		if w.Objects[i].Material.IsEmissive() {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// emitterSamples returns the EmitterSamples of the world, or DefaultEmitterSamples when it is not set
+func (w World) emitterSamples() int {
+	if w.EmitterSamples <= 0 {
+		return DefaultEmitterSamples
+	}
+	return w.EmitterSamples
+}
+
+// emittedLighting calculates the light that the emissive objects in the world cast on a hit
+// Every emitter is sampled at points spread evenly over its surface
+func (w World) emittedLighting(material materials.Material, hit rays.Intersection) colors.Color {
+	if len(w.Emitters()) == 0 {
+		return colors.Black()
+	}
+	return w.sampledEmittedLighting(material, hit, sampling.NewRegularSampler().Samples(w.emitterSamples(), nil))
+}
+
+// sampledEmittedLighting calculates the light that the emissive objects in the world cast on a hit,
+// sampling every emitter at the points of its surface that the samples map to
+// Each point facing the hit acts as a point light carrying the irradiance of its share of the surface
+func (w World) sampledEmittedLighting(material materials.Material, hit rays.Intersection, samples []sampling.Sample) colors.Color {
+	result := colors.Black()
+	for _, i := range w.Emitters() {
+		emitter := &w.Objects[i]
+		if emitter == hit.Object {
+			continue
+		}
+		radiance := emitter.Material.Emitted()
+		for _, sample := range samples {
+			position, normal, area := emitter.SampleSurface(sample.X, sample.Y, hit.RayTime)
+			toHit := hit.OverPoint.Subtract(position)
+			distance := toHit.Magnitude()
+			cosine := normal.Dot(toHit.Normalize())
+			if cosine <= 0 || w.tolerance().Equal(distance, 0) {
+				continue
+			}
+			irradiance := radiance.Multiply(cosine * area / (float64(len(samples)) * distance * distance))
+			light := lights.NewPointLight(position, irradiance)
+			c := material.Lighting(
+				light,
+				hit.OverPoint,
+				hit.EyeV,
+				hit.NormalV,
+				w.isOccluded(hit.OverPoint, position, hit.RayTime))
+			result = result.Add(c)
+		}
+	}
+	return result
+}
+
+// isOccluded checks if an object lies between a point and a target point on a surface at a certain time,
+// ignoring the surface of the target itself
+func (w World) isOccluded(point, target tuples.Point, time float64) bool {
+	v := target.Subtract(point)
+	distance := v.Magnitude()
+	ray := rays.NewRayAtTime(point, v.Normalize(), time)
+	hit := w.Intersect(*ray).Hit()
+	return hit != nil && hit.Time < distance-w.tolerance().RayOffset
+}
@@ -0,0 +1,177 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/sampling"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// DefaultMaxDepth is the number of bounces after which a NewPathTracer stops following a path
+const DefaultMaxDepth = 8
+
+// DefaultRouletteDepth is the number of bounces a NewPathTracer follows before paths may be terminated at random
+const DefaultRouletteDepth = 3
+
+// maxSurvival keeps Russian roulette from following a path forever
+const maxSurvival = 0.95
+
+// Integrator calculates the light arriving along a ray in a world
+// The rng provides the randomness of integrators that sample, and is seeded per pixel by the camera
+type Integrator interface {
+	Radiance(w World, ray rays.Ray, rng *rand.Rand) colors.Color
+}
+
+// WhittedIntegrator shades the first hit of a ray with the light that reaches it directly, as ColorAt does
+type WhittedIntegrator struct{}
+
+// PathTracer follows random paths bouncing through the world, adding the indirect light reflected by other
+// objects to the direct light at every bounce, so surfaces light each other and colors bleed onto their surroundings
+// Bounces are sampled with a density proportional to the cosine with the normal, and the direct light from the
+// light sources and emitters is added at every bounce with a shadow test towards it. After RouletteDepth bounces,
+// paths are terminated at random with a chance that grows as less light is carried along them, and no path is
+// followed for more than MaxDepth bounces. A MaxDepth or RouletteDepth of 0 or less means its default
+// Phong materials reflect the indirect light as perfectly diffuse surfaces with reflectance Color times Diffuse,
+// CookTorrance materials with their BRDF. The ambient term, which stands in for indirect light in ShadeHit, is left out,
+// so the direct light matches that of the WhittedIntegrator only for materials with an Ambient of 0
+// Reflective and transparent materials mirror or refract a path instead of bouncing it, at random with the
+// chances by which ShadeHit weighs the reflected and refracted light; only the rest of the paths bounce diffusely
+type PathTracer struct {
+	MaxDepth      int
+	RouletteDepth int
+}
+
+// NewWhittedIntegrator creates a new WhittedIntegrator
+func NewWhittedIntegrator() WhittedIntegrator {
+	return WhittedIntegrator{}
+}
+
+// NewPathTracer creates a new PathTracer following paths for at most maxDepth bounces
+func NewPathTracer(maxDepth int) PathTracer {
+	return PathTracer{maxDepth, DefaultRouletteDepth}
+}
+
+// Radiance calculates the color caused by the ray
+func (i WhittedIntegrator) Radiance(w World, ray rays.Ray, rng *rand.Rand) colors.Color {
+	return w.ColorAt(ray)
+}
+
+// Radiance estimates the color caused by the ray by following one random path from it
+func (p PathTracer) Radiance(w World, ray rays.Ray, rng *rand.Rand) colors.Color {
+	result := colors.Black()
+	throughput := colors.White()
+	offset := w.tolerance().RayOffset
+	specular := false
+	for depth := 0; depth < p.maxDepth(); depth++ {
+		hit := w.Intersect(ray).Hit()
+		if hit == nil {
+			break
+		}
+		hit.PrepareHitWithOffset(ray, offset)
+		material := hit.Object.Material.SurfaceAt(hit.Object.ShapePointAt(hit.Point, hit.RayTime))
+		// emitters seen after a diffuse bounce were already sampled as direct light at the previous hit,
+		// but a mirrored or refracted path cannot be sampled that way
+		if (depth == 0 || specular) && !hit.Inside {
+			result = result.Add(throughput.Blend(material.Emitted()))
+		}
+		material.Ambient = 0
+		result = result.Add(throughput.Blend(w.directLighting(material, *hit, rng)))
+
+		var weight colors.Color
+		ray, weight, specular = scatter(material, *hit, rng)
+		throughput = throughput.Blend(weight)
+		carried := math.Max(throughput.Red, math.Max(throughput.Green, throughput.Blue))
+		if carried <= 0 {
+			break
+		}
+		if depth+1 >= p.rouletteDepth() {
+			survival := math.Min(carried, maxSurvival)
+			if rng.Float64() >= survival {
+				break
+			}
+			throughput = throughput.Multiply(1 / survival)
+		}
+	}
+	return result
+}
+
+// maxDepth returns the MaxDepth of the path tracer, or DefaultMaxDepth when it is not set
+func (p PathTracer) maxDepth() int {
+	if p.MaxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return p.MaxDepth
+}
+
+// rouletteDepth returns the RouletteDepth of the path tracer, or DefaultRouletteDepth when it is not set
+func (p PathTracer) rouletteDepth() int {
+	if p.RouletteDepth <= 0 {
+		return DefaultRouletteDepth
+	}
+	return p.RouletteDepth
+}
+
+// directLighting calculates the light that reaches a hit directly from the light sources, and from
+// a single random point on each of the emitters
+func (w World) directLighting(material materials.Material, hit rays.Intersection, rng *rand.Rand) colors.Color {
+	result := colors.Black()
+	for _, light := range w.LightSources {
+		c := material.Lighting(
+			light,
+			hit.OverPoint,
+			hit.EyeV,
+			hit.NormalV,
+			w.IsShadowedAt(light, hit.OverPoint, hit.RayTime))
+		result = result.Add(c)
+	}
+	samples := []sampling.Sample{{X: rng.Float64(), Y: rng.Float64()}}
+	return result.Add(w.sampledEmittedLighting(material, hit, samples))
+}
+
+// scatter continues a path at a hit, choosing at random to mirror or refract it with the chances of specularChances,
+// or else to bounce it diffusely in a cosine weighted direction
+// It returns the continued ray, the factor by which the light along it is weighed towards the hit, which is black
+// when the path ends, and whether the path was mirrored or refracted
+func scatter(material materials.Material, hit rays.Intersection, rng *rand.Rand) (rays.Ray, colors.Color, bool) {
+	reflectChance, refractChance := specularChances(material, hit)
+	roll := rng.Float64()
+	switch {
+	case roll < reflectChance:
+		return *rays.NewRayAtTime(hit.OverPoint, reflectV(hit), hit.RayTime), colors.White(), true
+	case roll < reflectChance+refractChance:
+		direction, ok := refractV(material, hit)
+		if !ok {
+			return rays.Ray{}, colors.Black(), true
+		}
+		return *rays.NewRayAtTime(underPoint(hit), direction, hit.RayTime), colors.White(), true
+	}
+	diffuseChance := 1 - reflectChance - refractChance
+	direction := cosineDirection(hit.NormalV, sampling.Sample{X: rng.Float64(), Y: rng.Float64()})
+	weight := bounceWeight(material, hit.NormalV, hit.EyeV, direction).Multiply(1 / diffuseChance)
+	return *rays.NewRayAtTime(hit.OverPoint, direction, hit.RayTime), weight, false
+}
+
+// bounceWeight is the BRDF of the material times the cosine with the normal, divided by the density cos / π
+// of the direction, the factor by which the light arriving from the direction is reflected towards eyeV
+func bounceWeight(material materials.Material, normalV tuples.Normal, eyeV, direction tuples.Vector) colors.Color {
+	if normalV.Dot(direction) <= 0 {
+		return colors.Black()
+	}
+	if material.Model == materials.CookTorrance {
+		return material.BRDF(normalV, eyeV, direction).Multiply(math.Pi)
+	}
+	return material.Color.Multiply(material.Diffuse)
+}
+
+// cosineDirection maps a sample to a direction around the normal, with a density proportional to
+// the cosine between the direction and the normal
+func cosineDirection(normalV tuples.Normal, s sampling.Sample) tuples.Vector {
+	n := normalV.Normalize().Vector()
+	a, b := n.OrthonormalBasis()
+	x, y, z := sampling.CosineHemisphere(s)
+	return a.Multiply(x).Add(b.Multiply(y)).Add(n.Multiply(z)).Normalize()
+}
@@ -0,0 +1,213 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The Whitted integrator shades a ray like color_at
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When c ← radiance(whitted_integrator(), w, r, random(1))
+// Then c = color_at(w, r)
+func Test_the_Whitted_Integrator_Shades_a_Ray_like_ColorAt(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	c := NewWhittedIntegrator().Radiance(w, *r, rand.New(rand.NewSource(1)))
+	// Expected
+	wanted := w.ColorAt(*r)
+	// Then
+	if !wanted.Equals(c) {
+		t.Errorf("Radiance( %v ) = %v, expected %v", r, c, wanted)
+	}
+}
+
+// Scenario: A zero path tracer follows paths like a path tracer with the default depths
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When zero ← radiance(path_tracer with no depths set, w, r, random(1))
+// And standard ← radiance(path_tracer(DefaultMaxDepth), w, r, random(1))
+// Then zero = standard
+// And zero is not black
+func Test_a_Zero_Path_Tracer_Follows_Paths_like_a_Path_Tracer_with_the_Default_Depths(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	zero := PathTracer{}.Radiance(w, *r, rand.New(rand.NewSource(1)))
+	// And
+	standard := NewPathTracer(DefaultMaxDepth).Radiance(w, *r, rand.New(rand.NewSource(1)))
+	// Then
+	if !standard.Equals(zero) {
+		t.Errorf("Radiance( %v ) = %v, expected %v", r, zero, standard)
+	}
+	// And
+	if zero.Equals(colors.Black()) {
+		t.Errorf("Radiance( %v ) is black, expected light", r)
+	}
+}
+
+// Scenario: A path tracer converges to the direct light of an emissive sphere, without the ambient term
+// Given w ← the world of the scenario "An emissive sphere lights another object", with a floor of ambient 0.1
+// And reference ← the same world with a floor of ambient 0
+// And r ← ray(point(0, 2, 0), vector(0, -1, 0))
+// When c ← the mean of radiance(path_tracer(8), w, r, random(3)) over 4000 paths
+// Then c = color_at(reference, r)
+func Test_a_Path_Tracer_Converges_to_the_Direct_Light_of_an_Emissive_Sphere(t *testing.T) {
+	// Given
+	floor := spheres.NewUnitSphere()
+	floor.Material.Ambient = 0.1
+	floor.Material.Diffuse = 1
+	floor.Material.Specular = 0
+	lamp := spheres.NewUnitSphere()
+	lamp.SetTransform(transformations.Translation(0, 3, 0).Multiply(*transformations.Scaling(0.1, 0.1, 0.1)))
+	lamp.Material = materials.NewEmissiveMaterial(colors.White(), 100)
+	w := NewWorld([]spheres.Sphere{*floor, *lamp}, nil)
+	w.EmitterSamples = 1024
+	// And
+	floor.Material.Ambient = 0
+	reference := NewWorld([]spheres.Sphere{*floor, *lamp}, nil)
+	reference.EmitterSamples = 1024
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 2, 0), tuples.NewVector(0, -1, 0))
+	// When
+	const paths = 4000
+	tracer := NewPathTracer(DefaultMaxDepth)
+	rng := rand.New(rand.NewSource(3))
+	sum := colors.Black()
+	for i := 0; i < paths; i++ {
+		sum = sum.Add(tracer.Radiance(w, *r, rng))
+	}
+	c := sum.Multiply(1.0 / paths)
+	// Expected
+	wanted := reference.ColorAt(*r)
+	// Then
+	if math.Abs(c.Red-wanted.Red) > 0.03*wanted.Red {
+		t.Errorf("mean Radiance( %v ) = %v, expected about %v", r, c, wanted)
+	}
+}
+
+// Scenario: A path tracer adds the light reflected by other objects
+// Given red ← sphere() with:
+// | material.color | (1, 0, 0) | | material.ambient | 0 | | material.specular | 0 |
+// And white ← sphere() with:
+// | transform | translation(3, 0, 0) | | material.ambient | 0 |
+// And light ← point_light(point(10, 10, 0), color(1, 1, 1))
+// And w ← world() with red, white and light
+// And r ← ray(point(1.5, 0, 0), vector(1, 0, 0)), hitting white on its side facing away from light
+// When direct ← color_at(w, r)
+// And c ← the mean of radiance(path_tracer(8), w, r, random(5)) over 1000 paths
+// Then direct = color(0, 0, 0)
+// And c is red, the light bleeding from the red sphere
+func Test_a_Path_Tracer_Adds_the_Light_Reflected_by_other_Objects(t *testing.T) {
+	// Given
+	red := spheres.NewUnitSphere()
+	red.Material.Color = colors.NewColor(1, 0, 0)
+	red.Material.Ambient = 0
+	red.Material.Specular = 0
+	// And
+	white := spheres.NewUnitSphere()
+	white.SetTransform(transformations.Translation(3, 0, 0))
+	white.Material.Ambient = 0
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(10, 10, 0), colors.White())
+	// And
+	w := NewWorld([]spheres.Sphere{*red, *white}, []lights.PointLight{light})
+	// And
+	r := rays.NewRay(tuples.NewPoint(1.5, 0, 0), tuples.NewVector(1, 0, 0))
+	// When
+	direct := w.ColorAt(*r)
+	// And
+	const paths = 1000
+	tracer := NewPathTracer(DefaultMaxDepth)
+	rng := rand.New(rand.NewSource(5))
+	sum := colors.Black()
+	for i := 0; i < paths; i++ {
+		sum = sum.Add(tracer.Radiance(w, *r, rng))
+	}
+	c := sum.Multiply(1.0 / paths)
+	// Then
+	if !colors.Black().Equals(direct) {
+		t.Errorf("ColorAt( %v ) = %v, expected %v", r, direct, colors.Black())
+	}
+	// And
+	if c.Red < 0.01 || c.Green > tuples.Epsilon || c.Blue > tuples.Epsilon {
+		t.Errorf("mean Radiance( %v ) = %v, expected a shade of red", r, c)
+	}
+}
+
+// Scenario: Russian roulette ends paths inside a closed white sphere
+// Given s ← sphere() with:
+// | material.color | (1, 1, 1) | | material.diffuse | 1 | | material.specular | 0 |
+// And light ← point_light(point(0, 0, 0), color(0.1, 0.1, 0.1))
+// And w ← world() with s and light
+// And r ← ray(point(0, 0, 0), vector(0, 0, 1))
+// When c ← radiance(path_tracer(1000000), w, r, random(9)) for 100 paths
+// Then every c is finite, and at least the direct light 0.1
+func Test_Russian_Roulette_Ends_Paths_inside_a_Closed_White_Sphere(t *testing.T) {
+	// Given
+	s := spheres.NewUnitSphere()
+	s.Material.Diffuse = 1
+	s.Material.Specular = 0
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, 0), colors.NewColor(0.1, 0.1, 0.1))
+	// And
+	w := NewWorld([]spheres.Sphere{*s}, []lights.PointLight{light})
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, 1))
+	tracer := NewPathTracer(1000000)
+	rng := rand.New(rand.NewSource(9))
+	for i := 0; i < 100; i++ {
+		// When
+		c := tracer.Radiance(w, *r, rng)
+		// Then
+		if math.IsInf(c.Red, 0) || math.IsNaN(c.Red) || c.Red < 0.1-tuples.Epsilon {
+			t.Errorf("Radiance( %v ) = %v, expected a finite color of at least 0.1", r, c)
+		}
+	}
+}
+
+// Scenario: A path tracer sees an emitter through a glass ball
+// Given w ← world() with glass_sphere() and a red lamp at point(0, 0, 5)
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When c ← the mean of radiance(path_tracer(8), w, r, random(4)) over 2000 paths
+// Then c = color_at(w, r)
+// And c is not black
+func Test_a_Path_Tracer_Sees_an_Emitter_through_a_Glass_Ball(t *testing.T) {
+	// Given
+	w := NewWorld([]spheres.Sphere{*glassSphere(), *redLamp(0, 0, 5)}, nil)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	const paths = 2000
+	tracer := NewPathTracer(DefaultMaxDepth)
+	rng := rand.New(rand.NewSource(4))
+	sum := colors.Black()
+	for i := 0; i < paths; i++ {
+		sum = sum.Add(tracer.Radiance(w, *r, rng))
+	}
+	c := sum.Multiply(1.0 / paths)
+	// Expected
+	wanted := w.ColorAt(*r)
+	// Then
+	if math.Abs(c.Red-wanted.Red) > 0.03*wanted.Red {
+		t.Errorf("mean Radiance( %v ) = %v, expected about %v", r, c, wanted)
+	}
+	// And
+	if c.Red <= 0 {
+		t.Errorf("mean Radiance( %v ) is black, expected the red of the lamp", r)
+	}
+}
@@ -0,0 +1,63 @@
+package world
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// NoObject is the ObjectIndex of a ray that hits nothing
+const NoObject = -1
+
+// Surface describes what a ray sees at its primary hit, for the auxiliary render passes
+// Distance is measured along the ray, the Normal faces the ray and Shadow is the fraction of the
+// light sources that are blocked. A ray that misses has an infinite Distance and ObjectIndex NoObject
+type Surface struct {
+	Hit         bool
+	Distance    float64
+	Normal      tuples.Normal
+	Albedo      colors.Color
+	ObjectIndex int
+	Shadow      float64
+}
+
+// SurfaceAt finds the Surface seen by a ray
+func (w World) SurfaceAt(ray rays.Ray) Surface {
+	hit := w.Intersect(ray).Hit()
+	if hit == nil {
+		return Surface{Distance: math.Inf(1), ObjectIndex: NoObject}
+	}
+	hit.PrepareHitWithOffset(ray, w.tolerance().RayOffset)
+
+	shadowed := 0
+	for _, light := range w.LightSources {
+		if w.IsShadowedAt(light, hit.OverPoint, hit.RayTime) {
+			shadowed++
+		}
+	}
+	shadow := 0.0
+	if len(w.LightSources) > 0 {
+		shadow = float64(shadowed) / float64(len(w.LightSources))
+	}
+
+	return Surface{
+		Hit:         true,
+		Distance:    hit.Time * ray.Direction.Magnitude(),
+		Normal:      hit.NormalV,
+		Albedo:      hit.Object.Material.SurfaceAt(hit.Object.ShapePointAt(hit.Point, hit.RayTime)).Color,
+		ObjectIndex: w.indexOf(hit),
+		Shadow:      shadow,
+	}
+}
+
+// indexOf finds the index of the object of an intersection found by Intersect
+func (w World) indexOf(hit *rays.Intersection) int {
+	for i := range w.Objects {
+		if hit.Object == &w.Objects[i] {
+			return i
+		}
+	}
+	return NoObject
+}
@@ -0,0 +1,97 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// Scenario: The surface seen by a ray that hits
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When s ← surface_at(w, r)
+// Then s.distance = 4
+// And s.normal = normal(0, 0, -1)
+// And s.albedo = color(0.8, 1.0, 0.6)
+// And s.object_index = 0
+// And s.shadow = 0
+func Test_the_Surface_Seen_by_a_Ray_that_Hits(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	s := w.SurfaceAt(*r)
+	// Then
+	if !s.Hit || math.Abs(s.Distance-4) > tuples.Epsilon {
+		t.Errorf("surface has hit %t at distance %g, expected a hit at 4", s.Hit, s.Distance)
+	}
+	// And
+	if wanted := tuples.NewNormal(0, 0, -1); !s.Normal.Equals(wanted) {
+		t.Errorf("surface has normal %v, expected %v", s.Normal, wanted)
+	}
+	// And
+	if wanted := colors.NewColor(0.8, 1.0, 0.6); !s.Albedo.Equals(wanted) {
+		t.Errorf("surface has albedo %v, expected %v", s.Albedo, wanted)
+	}
+	// And
+	if s.ObjectIndex != 0 {
+		t.Errorf("surface has object index %d, expected 0", s.ObjectIndex)
+	}
+	// And
+	if s.Shadow != 0 {
+		t.Errorf("surface has shadow %g, expected 0", s.Shadow)
+	}
+}
+
+// Scenario: The surface seen by a ray that misses
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 1, 0))
+// When s ← surface_at(w, r)
+// Then s.distance = ∞
+// And s.object_index = -1
+func Test_the_Surface_Seen_by_a_Ray_that_Misses(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 1, 0))
+	// When
+	s := w.SurfaceAt(*r)
+	// Then
+	if s.Hit || !math.IsInf(s.Distance, 1) {
+		t.Errorf("surface has hit %t at distance %g, expected no hit", s.Hit, s.Distance)
+	}
+	// And
+	if s.ObjectIndex != NoObject {
+		t.Errorf("surface has object index %d, expected %d", s.ObjectIndex, NoObject)
+	}
+}
+
+// Scenario: The surface of an inner object in shadow
+// Given w ← default_world()
+// And w.light.position ← point(0, 0, 10)
+// And r ← ray(point(0, 0, 0.75), vector(0, 0, -1))
+// When s ← surface_at(w, r)
+// Then s.object_index = 1
+// And s.shadow = 1
+func Test_the_Surface_of_an_Inner_Object_in_Shadow(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	w.LightSources[0].Position = tuples.NewPoint(0, 0, 10)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, 0.75), tuples.NewVector(0, 0, -1))
+	// When
+	s := w.SurfaceAt(*r)
+	// Then
+	if s.ObjectIndex != 1 {
+		t.Errorf("surface has object index %d, expected 1", s.ObjectIndex)
+	}
+	// And
+	if s.Shadow != 1 {
+		t.Errorf("surface has shadow %g, expected 1", s.Shadow)
+	}
+}
@@ -0,0 +1,112 @@
+package world
+
+import (
+	"math"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// MaxBounces is the number of times ColorAt follows a ray that is reflected or refracted,
+// which keeps rays between facing mirrors from bouncing forever
+const MaxBounces = 5
+
+// specularColor calculates the light a reflective or transparent material mirrors and passes through at a hit
+// A material that is both blends the two by the Fresnel reflectance, so glass mostly reflects at grazing angles
+func (w World) specularColor(material materials.Material, hit rays.Intersection, remaining int) colors.Color {
+	reflected := w.reflectedColor(material, hit, remaining)
+	refracted := w.refractedColor(material, hit, remaining)
+	if material.Reflective > 0 && material.Transparency > 0 {
+		reflectance := schlick(material, hit)
+		return reflected.Multiply(reflectance).Add(refracted.Multiply(1 - reflectance))
+	}
+	return reflected.Add(refracted)
+}
+
+// reflectedColor calculates the light mirrored by a reflective material at a hit
+func (w World) reflectedColor(material materials.Material, hit rays.Intersection, remaining int) colors.Color {
+	if remaining <= 0 || material.Reflective <= 0 {
+		return colors.Black()
+	}
+	ray := rays.NewRayAtTime(hit.OverPoint, reflectV(hit), hit.RayTime)
+	return w.colorAt(*ray, remaining-1).Multiply(material.Reflective)
+}
+
+// refractedColor calculates the light passed through a transparent material at a hit,
+// which is black when the light is totally reflected inside the material
+func (w World) refractedColor(material materials.Material, hit rays.Intersection, remaining int) colors.Color {
+	if remaining <= 0 || material.Transparency <= 0 {
+		return colors.Black()
+	}
+	direction, ok := refractV(material, hit)
+	if !ok {
+		return colors.Black()
+	}
+	ray := rays.NewRayAtTime(underPoint(hit), direction, hit.RayTime)
+	return w.colorAt(*ray, remaining-1).Multiply(material.Transparency)
+}
+
+// specularChances returns the chances that light at a hit is mirrored and that it is refracted, the weights of the
+// reflected and refracted light in specularColor, so random paths of light carry the same light on average
+func specularChances(material materials.Material, hit rays.Intersection) (float64, float64) {
+	reflectChance, refractChance := material.Reflective, material.Transparency
+	if material.Reflective > 0 && material.Transparency > 0 {
+		reflectance := schlick(material, hit)
+		reflectChance *= reflectance
+		refractChance *= 1 - reflectance
+	}
+	return reflectChance, refractChance
+}
+
+// reflectV calculates the direction of the ray mirrored at a hit
+func reflectV(hit rays.Intersection) tuples.Vector {
+	return hit.EyeV.Negate().Reflect(hit.NormalV)
+}
+
+// refractV calculates the direction of the ray bent into or out of a transparent material at a hit,
+// by Snell's law; ok is false when the light is totally reflected instead
+func refractV(material materials.Material, hit rays.Intersection) (tuples.Vector, bool) {
+	n1, n2 := refractiveIndices(material, hit)
+	nRatio := n1 / n2
+	cosI := hit.EyeV.Dot(hit.NormalV.Vector())
+	sin2T := nRatio * nRatio * (1 - cosI*cosI)
+	if sin2T > 1 {
+		return tuples.Vector{}, false
+	}
+	cosT := math.Sqrt(1 - sin2T)
+	direction := hit.NormalV.Vector().Multiply(nRatio*cosI - cosT).Subtract(hit.EyeV.Multiply(nRatio))
+	return direction.Normalize(), true
+}
+
+// schlick approximates the fraction of light reflected by a transparent material at a hit,
+// which is 1 when the light is totally reflected
+func schlick(material materials.Material, hit rays.Intersection) float64 {
+	n1, n2 := refractiveIndices(material, hit)
+	cos := hit.EyeV.Dot(hit.NormalV.Vector())
+	if n1 > n2 {
+		nRatio := n1 / n2
+		sin2T := nRatio * nRatio * (1 - cos*cos)
+		if sin2T > 1 {
+			return 1
+		}
+		cos = math.Sqrt(1 - sin2T)
+	}
+	r0 := math.Pow((n1-n2)/(n1+n2), 2)
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
+
+// refractiveIndices returns the refractive indices on the side the ray comes from and the side it enters
+// Objects are assumed not to overlap, so the other side of a surface is always empty space
+func refractiveIndices(material materials.Material, hit rays.Intersection) (float64, float64) {
+	if hit.Inside {
+		return material.RefractiveIndex, 1
+	}
+	return 1, material.RefractiveIndex
+}
+
+// underPoint lies just below the surface at a hit, as the origin for rays passing through it
+func underPoint(hit rays.Intersection) tuples.Point {
+	return hit.Point.Add(hit.Point.Subtract(hit.OverPoint))
+}
@@ -0,0 +1,231 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/rays"
+	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/transformations"
+	"github.com/bas-velthuizen/go-raytracer/tuples"
+)
+
+// glassSphere creates a unit sphere of glass that reflects and refracts, but does not reflect light itself
+func glassSphere() *spheres.Sphere {
+	s := spheres.NewUnitSphere()
+	s.Material = materials.NewGlassMaterial(1.5)
+	s.Material.Specular = 0
+	return s
+}
+
+// redLamp creates a unit sphere glowing red at a position
+func redLamp(x, y, z float64) *spheres.Sphere {
+	s := spheres.NewUnitSphere()
+	s.SetTransform(transformations.Translation(x, y, z))
+	s.Material = materials.NewEmissiveMaterial(colors.NewColor(1, 0, 0), 1)
+	return s
+}
+
+// Scenario: The reflected color for a nonreflective material
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// And hit ← the hit of r on the first object in w
+// When color ← reflected_color(w, hit)
+// Then color = color(0, 0, 0)
+func Test_the_Reflected_Color_for_a_Nonreflective_Material(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// And
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.tolerance().RayOffset)
+	// When
+	c := w.reflectedColor(w.Objects[0].Material, *hit, MaxBounces)
+	// Then
+	if !colors.Black().Equals(c) {
+		t.Errorf("reflectedColor( %v ) = %v, expected %v", hit, c, colors.Black())
+	}
+}
+
+// Scenario: A mirror reflects the objects in front of it
+// Given mirror ← sphere() with:
+// | material.reflective | 1 | | material.color | (0, 0, 0) | | material.ambient | 0 |
+// | material.diffuse | 0 | | material.specular | 0 |
+// And lamp ← a sphere glowing color(1, 0, 0) at point(0, 0, -5)
+// And w ← world() with mirror and lamp, and no light sources
+// And r ← ray(point(0, 0, -3), vector(0, 0, 1))
+// When c ← color_at(w, r)
+// Then c = color(1, 0, 0)
+func Test_a_Mirror_Reflects_the_Objects_in_Front_of_it(t *testing.T) {
+	// Given
+	mirror := spheres.NewUnitSphere()
+	mirror.Material.Reflective = 1
+	mirror.Material.Color = colors.Black()
+	mirror.Material.Ambient = 0
+	mirror.Material.Diffuse = 0
+	mirror.Material.Specular = 0
+	// And
+	lamp := redLamp(0, 0, -5)
+	// And
+	w := NewWorld([]spheres.Sphere{*mirror, *lamp}, nil)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -3), tuples.NewVector(0, 0, 1))
+	// When
+	c := w.ColorAt(*r)
+	// Expected
+	wanted := colors.NewColor(1, 0, 0)
+	// Then
+	if !wanted.Equals(c) {
+		t.Errorf("ColorAt( %v ) = %v, expected %v", r, c, wanted)
+	}
+}
+
+// Scenario: The reflected color at the maximum recursive depth
+// Given the mirror and lamp of the previous scenario
+// And hit ← the hit of ray(point(0, 0, -3), vector(0, 0, 1)) on mirror
+// When color ← reflected_color(w, hit, 0)
+// Then color = color(0, 0, 0)
+func Test_the_Reflected_Color_at_the_Maximum_Recursive_Depth(t *testing.T) {
+	// Given
+	mirror := spheres.NewUnitSphere()
+	mirror.Material.Reflective = 1
+	w := NewWorld([]spheres.Sphere{*mirror, *redLamp(0, 0, -5)}, nil)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -3), tuples.NewVector(0, 0, 1))
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.tolerance().RayOffset)
+	// When
+	c := w.reflectedColor(w.Objects[0].Material, *hit, 0)
+	// Then
+	if !colors.Black().Equals(c) {
+		t.Errorf("reflectedColor( %v, 0 ) = %v, expected %v", hit, c, colors.Black())
+	}
+}
+
+// Scenario: The refracted color with an opaque surface
+// Given w ← default_world()
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// And hit ← the hit of r on the first object in w
+// When c ← refracted_color(w, hit, 5)
+// Then c = color(0, 0, 0)
+func Test_the_Refracted_Color_with_an_Opaque_Surface(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// And
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.tolerance().RayOffset)
+	// When
+	c := w.refractedColor(w.Objects[0].Material, *hit, 5)
+	// Then
+	if !colors.Black().Equals(c) {
+		t.Errorf("refractedColor( %v ) = %v, expected %v", hit, c, colors.Black())
+	}
+}
+
+// Scenario: The refracted color under total internal reflection
+// Given shape ← glass_sphere()
+// And w ← world() with shape
+// And r ← ray(point(0, 0, √2/2), vector(0, 1, 0))
+// And hit ← the hit of r on shape, from inside the sphere
+// When c ← refracted_color(w, hit, 5)
+// Then c = color(0, 0, 0)
+// And schlick(hit) = 1.0
+func Test_the_Refracted_Color_under_Total_Internal_Reflection(t *testing.T) {
+	// Given
+	shape := glassSphere()
+	// And
+	w := NewWorld([]spheres.Sphere{*shape}, nil)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, math.Sqrt2/2), tuples.NewVector(0, 1, 0))
+	// And
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.tolerance().RayOffset)
+	// When
+	c := w.refractedColor(shape.Material, *hit, 5)
+	// Then
+	if !colors.Black().Equals(c) {
+		t.Errorf("refractedColor( %v ) = %v, expected %v", hit, c, colors.Black())
+	}
+	// And
+	if reflectance := schlick(shape.Material, *hit); reflectance != 1 {
+		t.Errorf("schlick( %v ) = %v, expected %v", hit, reflectance, 1.0)
+	}
+}
+
+// Scenario: The Schlick approximation with a perpendicular viewing angle
+// Given shape ← glass_sphere()
+// And r ← ray(point(0, 0, 0), vector(0, 1, 0))
+// And hit ← the hit of r on shape, from inside the sphere
+// When reflectance ← schlick(hit)
+// Then reflectance = 0.04
+func Test_the_Schlick_Approximation_with_a_Perpendicular_Viewing_Angle(t *testing.T) {
+	// Given
+	shape := glassSphere()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 1, 0))
+	// And
+	hit := r.Intersect(shape).Hit()
+	hit.PrepareHit(*r)
+	// When
+	reflectance := schlick(shape.Material, *hit)
+	// Then
+	if math.Abs(reflectance-0.04) > tuples.Epsilon {
+		t.Errorf("schlick( %v ) = %v, expected %v", hit, reflectance, 0.04)
+	}
+}
+
+// Scenario: The Schlick approximation with small angle and n2 > n1
+// Given shape ← glass_sphere()
+// And r ← ray(point(0, 0.99, -2), vector(0, 0, 1))
+// And hit ← the hit of r on shape
+// When reflectance ← schlick(hit)
+// Then reflectance = 0.48873
+func Test_the_Schlick_Approximation_with_Small_Angle_and_N2_Greater_than_N1(t *testing.T) {
+	// Given
+	shape := glassSphere()
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0.99, -2), tuples.NewVector(0, 0, 1))
+	// And
+	hit := r.Intersect(shape).Hit()
+	hit.PrepareHit(*r)
+	// When
+	reflectance := schlick(shape.Material, *hit)
+	// Then
+	if math.Abs(reflectance-0.48873) > 1e-4 {
+		t.Errorf("schlick( %v ) = %v, expected %v", hit, reflectance, 0.48873)
+	}
+}
+
+// Scenario: Looking through a clear sphere shows what lies behind it
+// Given shape ← glass_sphere() with:
+// | material.reflective | 0 | | material.refractive_index | 1 |
+// And lamp ← a sphere glowing color(1, 0, 0) at point(0, 0, 5)
+// And w ← world() with shape and lamp, and no light sources
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When c ← color_at(w, r)
+// Then c = color(1, 0, 0)
+func Test_Looking_through_a_Clear_Sphere_Shows_what_Lies_behind_it(t *testing.T) {
+	// Given
+	shape := glassSphere()
+	shape.Material.Reflective = 0
+	shape.Material.RefractiveIndex = 1
+	// And
+	lamp := redLamp(0, 0, 5)
+	// And
+	w := NewWorld([]spheres.Sphere{*shape, *lamp}, nil)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	c := w.ColorAt(*r)
+	// Expected
+	wanted := colors.NewColor(1, 0, 0)
+	// Then
+	if !wanted.Equals(c) {
+		t.Errorf("ColorAt( %v ) = %v, expected %v", r, c, wanted)
+	}
+}
@@ -3,8 +3,10 @@ package world
 import (
 	"sort"
 
+	"github.com/bas-velthuizen/go-raytracer/bounds"
 	"github.com/bas-velthuizen/go-raytracer/colors"
 	"github.com/bas-velthuizen/go-raytracer/lights"
+	"github.com/bas-velthuizen/go-raytracer/precision"
 	"github.com/bas-velthuizen/go-raytracer/rays"
 	"github.com/bas-velthuizen/go-raytracer/spheres"
 	"github.com/bas-velthuizen/go-raytracer/transformations"
@@ -12,19 +14,29 @@ import (
 )
 
 // World defines the light sources and objects in a world
+// The Tolerance determines how far rays leaving a surface start from it; it is fitted to the
+// scale of the objects by FitTolerance, and is the default tolerance when it is not set
+// Objects with an emissive material light the world as well, sampled at EmitterSamples points each
+// The Caustics hold the light focused by reflective and transparent objects, traced by TraceCaustics
 type World struct {
-	Objects      []spheres.Sphere
-	LightSources []lights.PointLight
+	Objects        []spheres.Sphere
+	LightSources   []lights.PointLight
+	Tolerance      precision.Tolerance
+	EmitterSamples int
+	Caustics       *Caustics
 }
 
-// NewWorld returns a new World object with the provides Objects and Light Source
+// NewWorld returns a new World object with the provides Objects and Light Source,
+// with a Tolerance that fits the objects
 func NewWorld(Objects []spheres.Sphere, LightSources []lights.PointLight) World {
-	return World{Objects, LightSources}
+	w := World{Objects: Objects, LightSources: LightSources}
+	w.FitTolerance()
+	return w
 }
 
 // DefaultWorld returns a new Default World object
 func DefaultWorld() World {
-	light := lights.NewPointLight(tuples.Point(-10, 10, -10), colors.White())
+	light := lights.NewPointLight(tuples.NewPoint(-10, 10, -10), colors.White())
 
 	s1 := spheres.NewUnitSphere()
 	s1.Material.Color = colors.NewColor(0.8, 1.0, 0.6)
@@ -32,9 +44,34 @@ func DefaultWorld() World {
 	s1.Material.Specular = 0.2
 
 	s2 := spheres.NewUnitSphere()
-	s2.SetTransform(transformations.Scaling(0.5, 0.5, 0.5))
+	if err := s2.SetTransform(transformations.Scaling(0.5, 0.5, 0.5)); err != nil {
+		panic(err)
+	}
+
+	return NewWorld([]spheres.Sphere{*s1, *s2}, []lights.PointLight{light})
+}
 
-	return World{Objects: []spheres.Sphere{*s1, *s2}, LightSources: []lights.PointLight{light}}
+// Bounds calculates the box that contains all objects in the world
+func (w World) Bounds() bounds.Box {
+	result := bounds.Empty()
+	for _, object := range w.Objects {
+		result = result.Union(object.Bounds())
+	}
+	return result
+}
+
+// FitTolerance sets the Tolerance to fit the scale of the objects in the world
+// Call it again after changing the objects
+func (w *World) FitTolerance() {
+	w.Tolerance = precision.ForScale(w.Bounds().Scale())
+}
+
+// tolerance returns the Tolerance of the world, or the default Tolerance when it is not set
+func (w World) tolerance() precision.Tolerance {
+	if w.Tolerance.IsZero() {
+		return precision.Default()
+	}
+	return w.Tolerance
 }
 
 // Contains checks whether the world contains this object
@@ -59,21 +96,58 @@ func (w World) Intersect(ray rays.Ray) *rays.Intersections {
 }
 
 // ShadeHit calculates the color of a hit in the world
+// The outside of an emissive object adds its emission to the light it reflects, reflective and transparent
+// objects add the light they mirror and pass through, and the Caustics, when traced, add the light focused
+// onto the hit by such objects
 func (w World) ShadeHit(hit rays.Intersection) colors.Color {
+	return w.shadeHit(hit, MaxBounces)
+}
+
+// shadeHit calculates the color of a hit, following reflected and refracted rays for remaining more bounces
+func (w World) shadeHit(hit rays.Intersection, remaining int) colors.Color {
 	result := colors.Black()
+	material := hit.Object.Material.SurfaceAt(hit.Object.ShapePointAt(hit.Point, hit.RayTime))
+	if !hit.Inside {
+		result = result.Add(material.Emitted())
+	}
 	for i := 0; i < len(w.LightSources); i++ {
-		c := hit.Object.Material.Lighting(
+		c := material.Lighting(
 			w.LightSources[i],
-			hit.Point,
+			hit.OverPoint,
 			hit.EyeV,
-			hit.NormalV)
+			hit.NormalV,
+			w.IsShadowedAt(w.LightSources[i], hit.OverPoint, hit.RayTime))
 		result = result.Add(c)
 	}
-	return result
+	result = result.Add(w.emittedLighting(material, hit))
+	if w.Caustics != nil {
+		result = result.Add(w.Caustics.Radiance(material, hit))
+	}
+	return result.Add(w.specularColor(material, hit, remaining))
+}
+
+// IsShadowed checks if an object lies between a point and a light source
+func (w World) IsShadowed(light lights.PointLight, point tuples.Point) bool {
+	return w.IsShadowedAt(light, point, 0)
+}
+
+// IsShadowedAt checks if an object lies between a point and a light source at a certain time
+// An object that touches the light within the equality tolerance of the world does not cast a shadow
+func (w World) IsShadowedAt(light lights.PointLight, point tuples.Point, time float64) bool {
+	v := light.Position.Subtract(point)
+	distance := v.Magnitude()
+	ray := rays.NewRayAtTime(point, v.Normalize(), time)
+	hit := w.Intersect(*ray).Hit()
+	return hit != nil && hit.Time < distance && !w.tolerance().Equal(hit.Time, distance)
 }
 
 // ColorAt calculates the color caused by a ray
 func (w World) ColorAt(ray rays.Ray) colors.Color {
+	return w.colorAt(ray, MaxBounces)
+}
+
+// colorAt calculates the color caused by a ray, following reflected and refracted rays for remaining more bounces
+func (w World) colorAt(ray rays.Ray, remaining int) colors.Color {
 	// 1. Call intersect_world to find the intersections of the given ray with the given
 	// world.
 	xs := w.Intersect(ray)
@@ -84,8 +158,8 @@ func (w World) ColorAt(ray rays.Ray) colors.Color {
 		return colors.Black()
 	}
 	// 4. Otherwise, prepare the hit with prepare_hit.
-	hit.PrepareHit(ray)
+	hit.PrepareHitWithOffset(ray, w.tolerance().RayOffset)
 	// 5. Finally, call shade_hit to find the color at the hit intersection.
-	result := w.ShadeHit(*hit)
+	result := w.shadeHit(*hit, remaining)
 	return result
 }
@@ -1,10 +1,16 @@
 package world
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
+	"github.com/bas-velthuizen/go-raytracer/bounds"
 	"github.com/bas-velthuizen/go-raytracer/colors"
+	"github.com/bas-velthuizen/go-raytracer/materials"
+	"github.com/bas-velthuizen/go-raytracer/precision"
 	"github.com/bas-velthuizen/go-raytracer/spheres"
+	"github.com/bas-velthuizen/go-raytracer/textures"
 	"github.com/bas-velthuizen/go-raytracer/transformations"
 	"github.com/bas-velthuizen/go-raytracer/tuples"
 
@@ -41,7 +47,7 @@ func Test_Creating_a_World(t *testing.T) {
 // And world contains s2
 func Test_The_Default_World(t *testing.T) {
 	// Given
-	light := lights.NewPointLight(tuples.Point(-10, 10, -10), colors.White())
+	light := lights.NewPointLight(tuples.NewPoint(-10, 10, -10), colors.White())
 	// And
 	s1 := spheres.NewUnitSphere()
 	s1.Material.Color = colors.NewColor(0.8, 1.0, 0.6)
@@ -79,7 +85,7 @@ func Test_Intersect_a_World_With_a_Ray(t *testing.T) {
 	// Given
 	world := DefaultWorld()
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// When
 	xs := world.Intersect(*ray)
 	// Then
@@ -116,7 +122,7 @@ func Test_Shading_an_Intersection(t *testing.T) {
 	// Given
 	world := DefaultWorld()
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// And
 	shape := world.Objects[0]
 	// And
@@ -146,10 +152,10 @@ func Test_Shading_an_Intersection_from_the_Inside(t *testing.T) {
 	// Given
 	world := DefaultWorld()
 	// And
-	light := lights.NewPointLight(tuples.Point(0, 0.25, 0), colors.White())
+	light := lights.NewPointLight(tuples.NewPoint(0, 0.25, 0), colors.White())
 	world.LightSources = []lights.PointLight{light}
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, 0), tuples.Vector(0, 0, 1))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, 0), tuples.NewVector(0, 0, 1))
 	// And
 	shape := world.Objects[1]
 	// And
@@ -175,7 +181,7 @@ func Test_the_Color_When_a_Ray_Misses(t *testing.T) {
 	// Given
 	world := DefaultWorld()
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 1, 0))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 1, 0))
 	// When
 	c := world.ColorAt(*ray)
 	// Then
@@ -193,7 +199,7 @@ func Test_the_Color_When_a_Ray_Hits(t *testing.T) {
 	// Given
 	world := DefaultWorld()
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, -5), tuples.Vector(0, 0, 1))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
 	// When
 	c := world.ColorAt(*ray)
 	// Expected
@@ -225,7 +231,7 @@ func Test_the_Color_With_an_Intersection_Behind_the_Ray(t *testing.T) {
 	// And
 	inner.Material.Ambient = 1
 	// And
-	ray := rays.NewRay(tuples.Point(0, 0, 0.75), tuples.Vector(0, 0, -1))
+	ray := rays.NewRay(tuples.NewPoint(0, 0, 0.75), tuples.NewVector(0, 0, -1))
 	// When
 	c := world.ColorAt(*ray)
 	// Expected
@@ -236,3 +242,336 @@ func Test_the_Color_With_an_Intersection_Behind_the_Ray(t *testing.T) {
 	}
 
 }
+
+// Scenario: There is no shadow when nothing is collinear with point and light
+// Given w ← default_world()
+// And p ← point(0, 10, 0)
+// Then is_shadowed(w, p) is false
+//
+// Scenario: The shadow when an object is between the point and the light
+// Given w ← default_world()
+// And p ← point(10, -10, 10)
+// Then is_shadowed(w, p) is true
+//
+// Scenario: There is no shadow when an object is behind the light
+// Given w ← default_world()
+// And p ← point(-20, 20, -20)
+// Then is_shadowed(w, p) is false
+//
+// Scenario: There is no shadow when an object is behind the point
+// Given w ← default_world()
+// And p ← point(-2, 2, -2)
+// Then is_shadowed(w, p) is false
+func Test_Shadows_in_the_Default_World(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	cases := []struct {
+		point  tuples.Point
+		wanted bool
+	}{
+		{tuples.NewPoint(0, 10, 0), false},
+		{tuples.NewPoint(10, -10, 10), true},
+		{tuples.NewPoint(-20, 20, -20), false},
+		{tuples.NewPoint(-2, 2, -2), false},
+	}
+	for _, c := range cases {
+		// Then
+		if shadowed := w.IsShadowed(w.LightSources[0], c.point); shadowed != c.wanted {
+			t.Errorf("IsShadowed( %v ) = %t, expected %t", c.point, shadowed, c.wanted)
+		}
+	}
+}
+
+// Scenario: shade_hit() is given an intersection in shadow
+// Given w ← world()
+// And w.light ← point_light(point(0, 0, -10), color(1, 1, 1))
+// And s1 ← sphere()
+// And s1 is added to w
+// And s2 ← sphere() with:
+// | transform | translation(0, 0, 10) |
+// And s2 is added to w
+// And r ← ray(point(0, 0, 5), vector(0, 0, 1))
+// And i ← intersection(4, s2)
+// When prepare_hit(i, r)
+// And c ← shade_hit(w, i)
+// Then c = color(0.1, 0.1, 0.1)
+func Test_Shade_Hit_is_Given_an_Intersection_in_Shadow(t *testing.T) {
+	// Given
+	light := lights.NewPointLight(tuples.NewPoint(0, 0, -10), colors.White())
+	// And
+	s1 := spheres.NewUnitSphere()
+	// And
+	s2 := spheres.NewUnitSphere()
+	s2.SetTransform(transformations.Translation(0, 0, 10))
+	w := NewWorld([]spheres.Sphere{*s1, *s2}, []lights.PointLight{light})
+	// And
+	ray := rays.NewRay(tuples.NewPoint(0, 0, 5), tuples.NewVector(0, 0, 1))
+	// And
+	hit := rays.NewIntersection(4, &w.Objects[1])
+	// When
+	hit.PrepareHit(*ray)
+	// And
+	c := w.ShadeHit(*hit)
+	// Expected
+	wanted := colors.NewColor(0.1, 0.1, 0.1)
+	// Then
+	if !wanted.Equals(c) {
+		t.Errorf("world.ShadeHit(%v) = %v, Expected %v", hit, c, wanted)
+	}
+}
+
+// Scenario: The tolerance of a world fits the scale of its objects
+// Given w ← default_world()
+// Then w.tolerance = tolerance_for_scale(scale(bounds(w)))
+// And bounds(w) = box(point(-1, -1, -1), point(1, 1, 1))
+func Test_the_Tolerance_of_a_World_Fits_the_Scale_of_its_Objects(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	// Expected
+	wantedBounds := bounds.NewBox(tuples.NewPoint(-1, -1, -1), tuples.NewPoint(1, 1, 1))
+	wantedTolerance := precision.ForScale(wantedBounds.Scale())
+	// Then
+	if w.Tolerance != wantedTolerance {
+		t.Errorf("%v has tolerance %v, expected %v", w, w.Tolerance, wantedTolerance)
+	}
+	// And
+	if b := w.Bounds(); !b.Min.Equals(wantedBounds.Min) || !b.Max.Equals(wantedBounds.Max) {
+		t.Errorf("Bounds( %v ) = %v, expected %v", w, b, wantedBounds)
+	}
+}
+
+// Scenario: A very large scene is free of acne
+// Given s ← sphere() with:
+// | transform | scaling(1e12, 1e12, 1e12) |
+// And light ← point_light(point(-1e13, 1e13, -1e13), color(1, 1, 1))
+// And w ← world() with s and light
+// When rays parallel to the z axis at random positions hit the lit side of s
+// Then no over point is shadowed with the tolerance of w
+// But over points with the fixed tuples.Epsilon offset are
+func Test_a_Very_Large_Scene_is_Free_of_Acne(t *testing.T) {
+	// Given
+	scale := 1e12
+	s := spheres.NewUnitSphere()
+	s.SetTransform(transformations.Scaling(scale, scale, scale))
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(-10*scale, 10*scale, -10*scale), colors.White())
+	// And
+	w := NewWorld([]spheres.Sphere{*s}, []lights.PointLight{light})
+	// When
+	// rays at random positions, as a regular grid could happen to hit only points that round well
+	rng := rand.New(rand.NewSource(1))
+	acneFitted, acneFixed := 0, 0
+	for i := 0; i < 400; i++ {
+		x := (rng.Float64() - 0.5) * 0.9 * scale
+		y := (rng.Float64() - 0.5) * 0.9 * scale
+		ray := rays.NewRay(tuples.NewPoint(x, y, -5*scale), tuples.NewVector(0, 0, 1))
+		hit := w.Intersect(*ray).Hit()
+		hit.PrepareHitWithOffset(*ray, w.Tolerance.RayOffset)
+		if hit.NormalV.Dot(light.Position.Subtract(hit.Point).Normalize()) < 0.05 {
+			continue
+		}
+		if w.IsShadowed(light, hit.OverPoint) {
+			acneFitted++
+		}
+		hit.PrepareHitWithOffset(*ray, tuples.Epsilon)
+		if w.IsShadowed(light, hit.OverPoint) {
+			acneFixed++
+		}
+	}
+	// Then
+	if acneFitted != 0 {
+		t.Errorf("%d points shadow themselves with %v, expected none", acneFitted, w.Tolerance)
+	}
+	// But
+	if acneFixed == 0 {
+		t.Errorf("no points shadow themselves with a fixed offset of %g, expected acne", tuples.Epsilon)
+	}
+}
+
+// Scenario: A very small scene does not miss shadows
+// Given s1 ← sphere() with:
+// | transform | scaling(1e-7, 1e-7, 1e-7) |
+// And s2 ← sphere() with:
+// | transform | translation(0, 3e-7, 0) * scaling(0.25e-7, 0.25e-7, 0.25e-7) |
+// And light ← point_light(point(0, 1, 0), color(1, 1, 1))
+// And w ← world() with s1, s2 and light
+// And r ← ray(point(0, 1e-6, 0), vector(0, -1, 0))
+// When hit ← the hit of r on s1, prepared with the tolerance of w
+// Then is_shadowed(w, hit.over_point) is true
+// But with the fixed tuples.Epsilon offset the over point lies above s2, missing the shadow
+func Test_a_Very_Small_Scene_does_not_Miss_Shadows(t *testing.T) {
+	// Given
+	s1 := spheres.NewUnitSphere()
+	s1.SetTransform(transformations.Scaling(1e-7, 1e-7, 1e-7))
+	// And
+	s2 := spheres.NewUnitSphere()
+	s2.SetTransform(transformations.NewBuilder().Scale(0.25e-7, 0.25e-7, 0.25e-7).Translate(0, 3e-7, 0).Matrix())
+	// And
+	light := lights.NewPointLight(tuples.NewPoint(0, 1, 0), colors.White())
+	// And
+	w := NewWorld([]spheres.Sphere{*s1, *s2}, []lights.PointLight{light})
+	// And
+	ray := rays.NewRay(tuples.NewPoint(0, 1e-6, 0), tuples.NewVector(0, -1, 0))
+	// When
+	hit := ray.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*ray, w.Tolerance.RayOffset)
+	// Then
+	if !w.IsShadowed(light, hit.OverPoint) {
+		t.Errorf("IsShadowed( %v ) = false with %v, expected true", hit.OverPoint, w.Tolerance)
+	}
+	// But
+	hit.PrepareHitWithOffset(*ray, tuples.Epsilon)
+	if w.IsShadowed(light, hit.OverPoint) {
+		t.Errorf("IsShadowed( %v ) = true with a fixed offset of %g, expected the shadow to be missed", hit.OverPoint, tuples.Epsilon)
+	}
+}
+
+// Scenario: Shading a textured sphere uses the texture in the space of the sphere
+// Given w ← default_world() without its second object
+// And s ← the first object in w
+// And s.transform ← translation(0, 0, 5) * scaling(2, 2, 2)
+// And s.material.texture ← texture_map(uv_checkers(2, 1, color(1, 0, 0), color(0, 0, 1)), spherical_map)
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When c ← color_at(w, r)
+// Then c is red, not blue
+func Test_Shading_a_Textured_Sphere_Uses_the_Texture_in_the_Space_of_the_Sphere(t *testing.T) {
+	// Given
+	w := DefaultWorld()
+	w.Objects = w.Objects[:1]
+	// And
+	s := &w.Objects[0]
+	// And
+	s.SetTransform(transformations.Translation(0, 0, 5).Multiply(*transformations.Scaling(2, 2, 2)))
+	// And
+	s.Material.Texture = textures.NewTextureMap(textures.NewUVCheckers(2, 1, colors.NewColor(1, 0, 0), colors.NewColor(0, 0, 1)), textures.NewSphericalMapping())
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	c := w.ColorAt(*r)
+	// Then
+	if c.Red <= 0 || c.Blue > tuples.Epsilon {
+		t.Errorf("ColorAt( %v ) = %v, expected a shade of red", r, c)
+	}
+}
+
+// Scenario: An emissive sphere is visible by its own light
+// Given w ← world() with s and no light sources
+// And s.material ← emissive_material(color(1, 0.5, 0), 2)
+// And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+// When c ← color_at(w, r)
+// Then c = color(2, 1, 0)
+func Test_an_Emissive_Sphere_is_Visible_by_its_own_Light(t *testing.T) {
+	// Given
+	s := spheres.NewUnitSphere()
+	w := NewWorld([]spheres.Sphere{*s}, nil)
+	// And
+	w.Objects[0].Material = materials.NewEmissiveMaterial(colors.NewColor(1, 0.5, 0), 2)
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 0, -5), tuples.NewVector(0, 0, 1))
+	// When
+	c := w.ColorAt(*r)
+	// Expected
+	wanted := colors.NewColor(2, 1, 0)
+	// Then
+	if !wanted.Equals(c) {
+		t.Errorf("ColorAt( %v ) = %v, expected %v", r, c, wanted)
+	}
+}
+
+// Scenario: An emissive sphere lights another object
+// Given floor ← sphere() with:
+// | material.ambient | 0 | | material.diffuse | 1 | | material.specular | 0 |
+// And lamp ← sphere() with:
+// | transform | translation(0, 3, 0) * scaling(0.1, 0.1, 0.1) |
+// | material | emissive_material(color(1, 1, 1), 100) |
+// And w ← world() with floor and lamp, no light sources and 1024 emitter samples
+// And r ← ray(point(0, 2, 0), vector(0, -1, 0))
+// When c ← color_at(w, r)
+// Then c = color(π / 4, π / 4, π / 4), the irradiance π · 100 · 0.1² / 2² of the lamp on the top of the floor
+func Test_an_Emissive_Sphere_Lights_another_Object(t *testing.T) {
+	// Given
+	floor := spheres.NewUnitSphere()
+	floor.Material.Ambient = 0
+	floor.Material.Diffuse = 1
+	floor.Material.Specular = 0
+	// And
+	lamp := spheres.NewUnitSphere()
+	lamp.SetTransform(transformations.Translation(0, 3, 0).Multiply(*transformations.Scaling(0.1, 0.1, 0.1)))
+	lamp.Material = materials.NewEmissiveMaterial(colors.White(), 100)
+	// And
+	w := NewWorld([]spheres.Sphere{*floor, *lamp}, nil)
+	w.EmitterSamples = 1024
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 2, 0), tuples.NewVector(0, -1, 0))
+	// When
+	c := w.ColorAt(*r)
+	// Expected
+	wanted := math.Pi / 4
+	// Then
+	if math.Abs(c.Red-wanted) > 0.02*wanted || math.Abs(c.Green-c.Red) > tuples.Epsilon || math.Abs(c.Blue-c.Red) > tuples.Epsilon {
+		t.Errorf("ColorAt( %v ) = %v, expected about %v in every component", r, c, wanted)
+	}
+}
+
+// Scenario: An emissive sphere with a radius lights another object
+// Given floor ← sphere() with:
+// | material.ambient | 0 | | material.diffuse | 1 | | material.specular | 0 |
+// And lamp ← sphere(point(0, 3, 0), 0.1) with:
+// | material | emissive_material(color(1, 1, 1), 100) |
+// And w ← world() with floor and lamp, no light sources and 1024 emitter samples
+// And r ← ray(point(0, 2, 0), vector(0, -1, 0))
+// When c ← color_at(w, r)
+// Then c = color(π / 4, π / 4, π / 4), as for the scaled lamp of the previous scenario
+func Test_an_Emissive_Sphere_with_a_Radius_Lights_another_Object(t *testing.T) {
+	// Given
+	floor := spheres.NewUnitSphere()
+	floor.Material.Ambient = 0
+	floor.Material.Diffuse = 1
+	floor.Material.Specular = 0
+	// And
+	lamp := spheres.NewSphere(tuples.NewPoint(0, 3, 0), 0.1)
+	lamp.Material = materials.NewEmissiveMaterial(colors.White(), 100)
+	// And
+	w := NewWorld([]spheres.Sphere{*floor, *lamp}, nil)
+	w.EmitterSamples = 1024
+	// And
+	r := rays.NewRay(tuples.NewPoint(0, 2, 0), tuples.NewVector(0, -1, 0))
+	// When
+	c := w.ColorAt(*r)
+	// Expected
+	wanted := math.Pi / 4
+	// Then
+	if math.Abs(c.Red-wanted) > 0.02*wanted || math.Abs(c.Green-c.Red) > tuples.Epsilon || math.Abs(c.Blue-c.Red) > tuples.Epsilon {
+		t.Errorf("ColorAt( %v ) = %v, expected about %v in every component", r, c, wanted)
+	}
+}
+
+// Scenario: The light of an emissive sphere is blocked by objects in between
+// Given the floor and lamp of the previous scenario
+// And blocker ← sphere() with:
+// | transform | translation(0, 2, 0) * scaling(0.5, 0.5, 0.5) |
+// And w ← world() with floor, lamp and blocker, and no light sources
+// When c ← shade_hit(w, the hit of ray(point(0, 1.2, 0), vector(0, -1, 0)) on floor)
+// Then c = color(0, 0, 0)
+func Test_the_Light_of_an_Emissive_Sphere_is_Blocked_by_Objects_in_Between(t *testing.T) {
+	// Given
+	floor := spheres.NewUnitSphere()
+	floor.Material.Ambient = 0
+	lamp := spheres.NewUnitSphere()
+	lamp.SetTransform(transformations.Translation(0, 3, 0).Multiply(*transformations.Scaling(0.1, 0.1, 0.1)))
+	lamp.Material = materials.NewEmissiveMaterial(colors.White(), 100)
+	// And
+	blocker := spheres.NewUnitSphere()
+	blocker.SetTransform(transformations.Translation(0, 2, 0).Multiply(*transformations.Scaling(0.5, 0.5, 0.5)))
+	// And
+	w := NewWorld([]spheres.Sphere{*floor, *lamp, *blocker}, nil)
+	// When
+	r := rays.NewRay(tuples.NewPoint(0, 1.2, 0), tuples.NewVector(0, -1, 0))
+	hit := r.Intersect(&w.Objects[0]).Hit()
+	hit.PrepareHitWithOffset(*r, w.Tolerance.RayOffset)
+	c := w.ShadeHit(*hit)
+	// Then
+	if !colors.Black().Equals(c) {
+		t.Errorf("ShadeHit( %v ) = %v, expected %v", hit, c, colors.Black())
+	}
+}